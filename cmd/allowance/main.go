@@ -0,0 +1,68 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	token "go-ethereum-example/gen"
+	"go-ethereum-example/pkg/block"
+	"go-ethereum-example/pkg/units"
+	"os"
+
+	_ "github.com/joho/godotenv/autoload"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+func main() {
+	contract := flag.String("contract", "0x5FbDB2315678afecb367f032d93F642f64180aa3", "token contract address")
+	owner := flag.String("owner", "", "owner address")
+	spender := flag.String("spender", "", "spender address")
+	blockFlag := flag.String("block", "latest", block.Usage)
+	flag.Parse()
+
+	if !common.IsHexAddress(*owner) || !common.IsHexAddress(*spender) {
+		handleError(fmt.Errorf("invalid owner %q or spender %q address", *owner, *spender))
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Connect to Ethereum client with RPC endpoint
+	client, err := ethclient.DialContext(ctx, os.Getenv("RPC_ENDPOINT"))
+	handleError(err)
+
+	defer client.Close()
+
+	// Create an instance of the contract, specifying its address
+	tokenInstance, err := token.NewToken(common.HexToAddress(*contract), client)
+	handleError(err)
+
+	// Resolve the block to read at, so the result can be reproduced
+	at, err := block.Resolve(ctx, client, *blockFlag)
+	handleError(err)
+
+	fmt.Printf("At %s\n", at)
+
+	decimals, err := tokenInstance.Decimals(at.CallOpts(ctx))
+	handleError(err)
+
+	// Call the contract method (read-only)
+	allowance, err := tokenInstance.Allowance(at.CallOpts(ctx), common.HexToAddress(*owner), common.HexToAddress(*spender))
+	handleError(err)
+
+	if allowance.Cmp(math.MaxBig256) == 0 {
+		fmt.Println("Allowance: unlimited")
+		return
+	}
+
+	fmt.Printf("Allowance: %s (%d base units)\n", units.Format(allowance, decimals), allowance)
+}
+
+func handleError(err error) {
+	if err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,146 @@
+package main
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"flag"
+	"fmt"
+	token "go-ethereum-example/gen"
+	"go-ethereum-example/pkg/allowance"
+	"go-ethereum-example/pkg/block"
+	"go-ethereum-example/pkg/units"
+	"os"
+	"strings"
+
+	_ "github.com/joho/godotenv/autoload"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+func main() {
+	contract := flag.String("contract", "0x5FbDB2315678afecb367f032d93F642f64180aa3", "token contract address")
+	owner := flag.String("owner", "", "owner address to report on")
+	fromBlock := flag.Uint64("from-block", 0, "first block to scan for Approval events")
+	blockFlag := flag.String("block", "latest", block.Usage)
+	revoke := flag.String("revoke", "", "comma-separated spenders to revoke, or \"all\" for every non-zero allowance (requires PRIVATE_KEY of the owner)")
+	flag.Parse()
+
+	if !common.IsHexAddress(*owner) {
+		handleError(fmt.Errorf("invalid owner address %q", *owner))
+	}
+
+	ownerAddress := common.HexToAddress(*owner)
+
+	// Revoking acts on the current allowances, not those of a past block
+	if *revoke != "" && *blockFlag != "latest" {
+		handleError(errors.New("-revoke cannot be combined with -block"))
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Connect to Ethereum client with RPC endpoint
+	client, err := ethclient.DialContext(ctx, os.Getenv("RPC_ENDPOINT"))
+	handleError(err)
+
+	defer client.Close()
+
+	// Create an instance of the contract, specifying its address
+	tokenInstance, err := token.NewToken(common.HexToAddress(*contract), client)
+	handleError(err)
+
+	// Resolve the block to read at, so the report can be reproduced
+	at, err := block.Resolve(ctx, client, *blockFlag)
+	handleError(err)
+
+	decimals, err := tokenInstance.Decimals(at.CallOpts(ctx))
+	handleError(err)
+
+	// Find every approved spender and its allowance at the block
+	allowances, err := allowance.Scan(ctx, at, tokenInstance, ownerAddress, *fromBlock)
+	handleError(err)
+
+	fmt.Printf("Owner %s has approved %d spenders at %s\n", ownerAddress.Hex(), len(allowances), at)
+
+	for _, a := range allowances {
+		amount := units.Format(a.Amount, decimals)
+		if a.Amount.Cmp(math.MaxBig256) == 0 {
+			amount = "unlimited"
+		}
+
+		fmt.Printf("%s  %s  (last approval in block %d)\n", a.Spender.Hex(), amount, a.LastBlock)
+	}
+
+	if *revoke == "" {
+		return
+	}
+
+	// Select the spenders to revoke
+	var spenders []common.Address
+
+	if *revoke == "all" {
+		for _, a := range allowances {
+			if a.Amount.Sign() > 0 {
+				spenders = append(spenders, a.Spender)
+			}
+		}
+	} else {
+		for _, s := range strings.Split(*revoke, ",") {
+			if !common.IsHexAddress(strings.TrimSpace(s)) {
+				handleError(fmt.Errorf("invalid spender address %q", s))
+			}
+
+			spenders = append(spenders, common.HexToAddress(strings.TrimSpace(s)))
+		}
+	}
+
+	if len(spenders) == 0 {
+		fmt.Println("Nothing to revoke")
+		return
+	}
+
+	// Parse wallet private key, which must belong to the owner
+	privateKey := mustParsePrivateKey()
+
+	if crypto.PubkeyToAddress(privateKey.PublicKey) != ownerAddress {
+		handleError(fmt.Errorf("PRIVATE_KEY does not belong to owner %s", ownerAddress.Hex()))
+	}
+
+	nonce, err := client.PendingNonceAt(ctx, ownerAddress)
+	handleError(err)
+
+	chainID, err := client.ChainID(ctx)
+	handleError(err)
+
+	// Create an transactor with the private key and chain ID
+	signer, err := bind.NewKeyedTransactorWithChainID(privateKey, chainID)
+	handleError(err)
+
+	receipts, err := allowance.Revoke(ctx, client, tokenInstance, signer, nonce, spenders)
+	handleError(err)
+
+	for i, receipt := range receipts {
+		fmt.Printf("Revoked %s: transaction %s status %d\n", spenders[i].Hex(), receipt.TxHash.Hex(), receipt.Status)
+	}
+}
+
+func mustParsePrivateKey() *ecdsa.PrivateKey {
+	rawPrivateKey := os.Getenv("PRIVATE_KEY")
+
+	// Parse the private key
+	privateKey, err := crypto.HexToECDSA(rawPrivateKey)
+	handleError(err)
+
+	return privateKey
+}
+
+func handleError(err error) {
+	if err != nil {
+		panic(err)
+	}
+}
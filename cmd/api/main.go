@@ -0,0 +1,136 @@
+package main
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"flag"
+	"fmt"
+	"go-ethereum-example/pkg/api"
+	"go-ethereum-example/pkg/watcher"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	_ "github.com/joho/godotenv/autoload"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+func main() {
+	listen := flag.String("listen", ":8080", "address to serve the API on")
+	contract := flag.String("contract", "0x5FbDB2315678afecb367f032d93F642f64180aa3", "comma-separated token contract addresses")
+	fromBlock := flag.Uint64("from-block", 1, "first block to index transfers from")
+	pollInterval := flag.Duration("poll-interval", watcher.DefaultPollInterval, "time between polls when RPC_WS_ENDPOINT is unset")
+	flag.Parse()
+
+	tokens := mustParseAddresses("contract", *contract)
+
+	// Stop on SIGINT and SIGTERM
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	// Connect to Ethereum client with RPC endpoint
+	client, err := ethclient.DialContext(ctx, os.Getenv("RPC_ENDPOINT"))
+	handleError(err)
+
+	defer client.Close()
+
+	fmt.Println("Successfully connected to Ethereum client")
+
+	// Index transfers over WebSocket, or by polling over HTTP
+	endpoint, interval := os.Getenv("RPC_WS_ENDPOINT"), time.Duration(0)
+	if endpoint == "" {
+		endpoint, interval = os.Getenv("RPC_ENDPOINT"), *pollInterval
+	}
+
+	index := api.NewIndex()
+
+	w := &watcher.Watcher{
+		Dial: func(ctx context.Context) (watcher.Client, error) {
+			return watcher.Dial(ctx, endpoint)
+		},
+		Tokens:       tokens,
+		Sink:         index,
+		FromBlock:    *fromBlock,
+		PollInterval: interval,
+		Logf: func(format string, args ...any) {
+			fmt.Printf(format+"\n", args...)
+		},
+	}
+
+	server := &api.Server{
+		Backend:  client,
+		Tokens:   tokens,
+		Index:    index,
+		Watcher:  w,
+		APIToken: os.Getenv("API_TOKEN"),
+	}
+
+	// Sign transfers with the wallet, only behind an API token
+	if os.Getenv("PRIVATE_KEY") != "" && server.APIToken != "" {
+		chainID, err := client.ChainID(ctx)
+		handleError(err)
+
+		server.Signer, err = bind.NewKeyedTransactorWithChainID(mustParsePrivateKey(), chainID)
+		handleError(err)
+
+		fmt.Printf("Transfers are signed by %s\n", server.Signer.From.Hex())
+	} else {
+		fmt.Println("Transfers are disabled, set PRIVATE_KEY and API_TOKEN to enable them")
+	}
+
+	httpServer := &http.Server{Addr: *listen, Handler: server.Handler(), ReadHeaderTimeout: 10 * time.Second}
+
+	go func() {
+		if err := httpServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			handleError(err)
+		}
+	}()
+
+	fmt.Printf("Serving the API on %s\n", *listen)
+
+	err = w.Run(ctx)
+
+	shutdownCtx, cancelShutdown := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancelShutdown()
+
+	httpServer.Shutdown(shutdownCtx)
+
+	handleError(err)
+}
+
+func mustParseAddresses(name, list string) []common.Address {
+	var addresses []common.Address
+	for _, s := range strings.Split(list, ",") {
+		if !common.IsHexAddress(strings.TrimSpace(s)) {
+			handleError(fmt.Errorf("invalid %s address %q", name, s))
+		}
+
+		addresses = append(addresses, common.HexToAddress(strings.TrimSpace(s)))
+	}
+
+	return addresses
+}
+
+func mustParsePrivateKey() *ecdsa.PrivateKey {
+	rawPrivateKey := os.Getenv("PRIVATE_KEY")
+
+	// Parse the private key
+	privateKey, err := crypto.HexToECDSA(rawPrivateKey)
+	handleError(err)
+
+	return privateKey
+}
+
+func handleError(err error) {
+	if err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"flag"
+	"fmt"
+	token "go-ethereum-example/gen"
+	"go-ethereum-example/pkg/units"
+	"math/big"
+	"os"
+
+	_ "github.com/joho/godotenv/autoload"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+func main() {
+	contract := flag.String("contract", "0x5FbDB2315678afecb367f032d93F642f64180aa3", "token contract address")
+	spender := flag.String("spender", "", "spender address")
+	amount := flag.String("amount", "", "allowance in token units, or \"max\" for an unlimited allowance")
+	flag.Parse()
+
+	if !common.IsHexAddress(*spender) {
+		handleError(fmt.Errorf("invalid spender address %q", *spender))
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Connect to Ethereum client with RPC endpoint
+	client, err := ethclient.DialContext(ctx, os.Getenv("RPC_ENDPOINT"))
+	handleError(err)
+
+	defer client.Close()
+
+	fmt.Println("Successfully connected to Ethereum client")
+
+	// Create an instance of the contract, specifying its address
+	tokenInstance, err := token.NewToken(common.HexToAddress(*contract), client)
+	handleError(err)
+
+	decimals, err := tokenInstance.Decimals(&bind.CallOpts{Context: ctx})
+	handleError(err)
+
+	value := math.MaxBig256
+	if *amount != "max" {
+		value, err = units.Parse(*amount, decimals)
+		handleError(err)
+	}
+
+	// Parse wallet private key
+	privateKey := mustParsePrivateKey()
+
+	chainID, err := client.ChainID(ctx)
+	handleError(err)
+
+	// Create an transactor with the private key and chain ID
+	signer, err := bind.NewKeyedTransactorWithChainID(privateKey, chainID)
+	handleError(err)
+
+	signer.Context = ctx
+
+	// Call approve method (state-changing)
+	tx, err := tokenInstance.Approve(signer, common.HexToAddress(*spender), value)
+	handleError(err)
+
+	fmt.Printf("Transaction hash: %s\n", tx.Hash().Hex())
+
+	// Wait for the transaction to be mined
+	receipt, err := bind.WaitMined(ctx, client, tx)
+	handleError(err)
+
+	fmt.Printf("Transaction receipt status %d\n", receipt.Status)
+
+	// Extract the approval event from the receipt
+	for _, log := range receipt.Logs {
+		approval, err := tokenInstance.ParseApproval(*log)
+		if err == nil {
+			fmt.Printf("Approved %s to spend %s tokens of %s\n", approval.Spender.Hex(), formatAllowance(approval.Value, decimals), approval.Owner.Hex())
+			break
+		}
+	}
+}
+
+func formatAllowance(value *big.Int, decimals uint8) string {
+	if value.Cmp(math.MaxBig256) == 0 {
+		return "unlimited"
+	}
+
+	return units.Format(value, decimals)
+}
+
+func mustParsePrivateKey() *ecdsa.PrivateKey {
+	rawPrivateKey := os.Getenv("PRIVATE_KEY")
+
+	// Parse the private key
+	privateKey, err := crypto.HexToECDSA(rawPrivateKey)
+	handleError(err)
+
+	return privateKey
+}
+
+func handleError(err error) {
+	if err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,155 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"flag"
+	"fmt"
+	"go-ethereum-example/pkg/block"
+	"go-ethereum-example/pkg/multicall"
+	"go-ethereum-example/pkg/units"
+	"math/big"
+	"os"
+	"strings"
+
+	_ "github.com/joho/godotenv/autoload"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+func main() {
+	contract := flag.String("contract", "0x5FbDB2315678afecb367f032d93F642f64180aa3", "token contract address")
+	multicallAddress := flag.String("multicall", multicall.Address.Hex(), "Multicall3 contract address")
+	holders := flag.String("holders", "", "file with one holder address per line (or in the first CSV column)")
+	addresses := flag.String("addresses", "", "comma-separated holder addresses")
+	blockFlag := flag.String("block", "latest", block.Usage)
+	maxGas := flag.Uint64("max-gas", multicall.DefaultMaxGas, "expected gas limit of one aggregate3 request")
+	maxCalldata := flag.Int("max-calldata", multicall.DefaultMaxCalldata, "calldata size limit of one aggregate3 request in bytes")
+	flag.Parse()
+
+	var owners []common.Address
+
+	if *holders != "" {
+		fromFile, err := readAddresses(*holders)
+		handleError(err)
+
+		owners = append(owners, fromFile...)
+	}
+
+	if *addresses != "" {
+		for _, s := range strings.Split(*addresses, ",") {
+			if !common.IsHexAddress(strings.TrimSpace(s)) {
+				handleError(fmt.Errorf("invalid holder address %q", s))
+			}
+
+			owners = append(owners, common.HexToAddress(strings.TrimSpace(s)))
+		}
+	}
+
+	if len(owners) == 0 {
+		handleError(fmt.Errorf("no holders, use -holders or -addresses"))
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Connect to Ethereum client with RPC endpoint
+	client, err := ethclient.DialContext(ctx, os.Getenv("RPC_ENDPOINT"))
+	handleError(err)
+
+	defer client.Close()
+
+	fmt.Println("Successfully connected to Ethereum client")
+
+	// Create a multicall caller, specifying the Multicall3 address
+	caller, err := multicall.New(common.HexToAddress(*multicallAddress), client)
+	handleError(err)
+
+	caller.MaxGas = *maxGas
+	caller.MaxCalldata = *maxCalldata
+
+	// Resolve the block to read at, so the report can be reproduced
+	at, err := block.Resolve(ctx, client, *blockFlag)
+	handleError(err)
+
+	fmt.Printf("At %s\n", at)
+
+	tokenAddress := common.HexToAddress(*contract)
+
+	metadata, err := caller.Metadata(at.CallOpts(ctx), tokenAddress)
+	handleError(err)
+
+	fmt.Printf("%s (%s), total supply %s\n", metadata.Name, metadata.Symbol, units.Format(metadata.TotalSupply, metadata.Decimals))
+
+	// Read every balance in batched aggregate3 calls
+	t := multicall.Token{Address: tokenAddress}
+
+	calls := make([]multicall.Call, len(owners))
+	for i, owner := range owners {
+		calls[i] = t.BalanceOf(owner)
+	}
+
+	results, err := caller.Do(at.CallOpts(ctx), calls)
+	handleError(err)
+
+	total := new(big.Int)
+	failed := 0
+
+	for i, result := range results {
+		balance, err := multicall.Value[*big.Int](result)
+		if err != nil {
+			fmt.Printf("%s  failed: %v\n", owners[i].Hex(), err)
+			failed++
+			continue
+		}
+
+		fmt.Printf("%s  %s %s\n", owners[i].Hex(), units.Format(balance, metadata.Decimals), metadata.Symbol)
+		total.Add(total, balance)
+	}
+
+	fmt.Printf("Read %d balances in %d requests (%d failed), total %s %s\n",
+		len(owners), len(caller.Batches(calls)), failed, units.Format(total, metadata.Decimals), metadata.Symbol)
+}
+
+// readAddresses reads one address per line, taking the first column of CSV
+// lines and skipping blank lines, comments and a header.
+func readAddresses(path string) ([]common.Address, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+
+	defer f.Close()
+
+	var (
+		owners []common.Address
+		line   int
+	)
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line++
+
+		field, _, _ := strings.Cut(scanner.Text(), ",")
+		field = strings.TrimSpace(field)
+
+		if field == "" || strings.HasPrefix(field, "#") || (len(owners) == 0 && strings.EqualFold(field, "address")) {
+			continue
+		}
+
+		if !common.IsHexAddress(field) {
+			return nil, fmt.Errorf("%s line %d: invalid address %q", path, line, field)
+		}
+
+		owners = append(owners, common.HexToAddress(field))
+	}
+
+	return owners, scanner.Err()
+}
+
+func handleError(err error) {
+	if err != nil {
+		panic(err)
+	}
+}
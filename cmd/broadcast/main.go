@@ -0,0 +1,67 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"go-ethereum-example/pkg/offline"
+	"os"
+
+	_ "github.com/joho/godotenv/autoload"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+func main() {
+	in := flag.String("in", "signed.json", "signed transaction file")
+	flag.Parse()
+
+	var signed offline.SignedTx
+
+	err := offline.ReadFile(*in, &signed)
+	handleError(err)
+
+	tx, err := signed.Transaction()
+	handleError(err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Connect to Ethereum client with RPC endpoint
+	client, err := ethclient.DialContext(ctx, os.Getenv("RPC_ENDPOINT"))
+	handleError(err)
+
+	defer client.Close()
+
+	fmt.Println("Successfully connected to Ethereum client")
+
+	chainID, err := client.ChainID(ctx)
+	handleError(err)
+
+	if chainID.Cmp(tx.ChainId()) != 0 {
+		handleError(fmt.Errorf("transaction is for chain %d but client is on chain %d", tx.ChainId(), chainID))
+	}
+
+	// Submit the raw transaction
+	err = client.SendTransaction(ctx, tx)
+	handleError(err)
+
+	fmt.Printf("Transaction hash: %s\n", tx.Hash().Hex())
+
+	// Wait for the transaction to be mined
+	receipt, err := bind.WaitMined(ctx, client, tx)
+	handleError(err)
+
+	fmt.Printf("Transaction receipt status %d (block %d, gas used %d)\n", receipt.Status, receipt.BlockNumber, receipt.GasUsed)
+
+	if tx.To() == nil && receipt.Status == 1 {
+		fmt.Printf("Contract deployed! Contract address: %s\n", receipt.ContractAddress.Hex())
+	}
+}
+
+func handleError(err error) {
+	if err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,132 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"go-ethereum-example/pkg/offline"
+	"math/big"
+	"os"
+
+	_ "github.com/joho/godotenv/autoload"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+func main() {
+	kind := flag.String("kind", "transfer", "transaction kind: transfer or deploy")
+	from := flag.String("from", "", "address of the account that will sign the transaction")
+	contract := flag.String("contract", "0x5FbDB2315678afecb367f032d93F642f64180aa3", "token contract address (transfer)")
+	to := flag.String("to", "", "recipient address (transfer)")
+	amount := flag.String("amount", "", "amount in base units (transfer) or initial supply (deploy)")
+	out := flag.String("out", "unsigned.json", "output file")
+	flag.Parse()
+
+	if !common.IsHexAddress(*from) {
+		handleError(fmt.Errorf("invalid from address %q", *from))
+	}
+
+	value, ok := new(big.Int).SetString(*amount, 10)
+	if !ok {
+		handleError(fmt.Errorf("invalid amount %q", *amount))
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Connect to Ethereum client with RPC endpoint
+	client, err := ethclient.DialContext(ctx, os.Getenv("RPC_ENDPOINT"))
+	handleError(err)
+
+	defer client.Close()
+
+	fmt.Println("Successfully connected to Ethereum client")
+
+	// Build calldata for the requested transaction
+	var (
+		txTo *common.Address
+		data []byte
+	)
+
+	switch *kind {
+	case "transfer":
+		if !common.IsHexAddress(*contract) || !common.IsHexAddress(*to) {
+			handleError(fmt.Errorf("invalid contract %q or to %q address", *contract, *to))
+		}
+
+		contractAddress := common.HexToAddress(*contract)
+		txTo = &contractAddress
+
+		data, err = offline.TransferData(common.HexToAddress(*to), value)
+	case "deploy":
+		data, err = offline.DeployData(value)
+	default:
+		err = fmt.Errorf("unknown kind %q", *kind)
+	}
+	handleError(err)
+
+	decoded, err := offline.Decode(txTo, data)
+	handleError(err)
+
+	// Get nonce, fees, gas limit and chain ID from the Ethereum client
+	fromAddress := common.HexToAddress(*from)
+
+	nonce, err := client.PendingNonceAt(ctx, fromAddress)
+	handleError(err)
+
+	chainID, err := client.ChainID(ctx)
+	handleError(err)
+
+	fmt.Printf("Chain ID: %d\n", chainID)
+
+	gas, err := client.EstimateGas(ctx, ethereum.CallMsg{From: fromAddress, To: txTo, Data: data})
+	handleError(err)
+
+	tx := &offline.UnsignedTx{
+		ChainID: (*hexutil.Big)(chainID),
+		From:    fromAddress,
+		Nonce:   hexutil.Uint64(nonce),
+		To:      txTo,
+		Value:   new(hexutil.Big),
+		Gas:     hexutil.Uint64(gas),
+		Data:    data,
+		Decoded: decoded,
+	}
+
+	header, err := client.HeaderByNumber(ctx, nil)
+	handleError(err)
+
+	if header.BaseFee != nil {
+		// EIP-1559: leave room for the base fee to double before inclusion
+		tip, err := client.SuggestGasTipCap(ctx)
+		handleError(err)
+
+		feeCap := new(big.Int).Add(tip, new(big.Int).Mul(header.BaseFee, big.NewInt(2)))
+
+		tx.GasTipCap = (*hexutil.Big)(tip)
+		tx.GasFeeCap = (*hexutil.Big)(feeCap)
+
+		fmt.Printf("Max fee per gas: %s, max priority fee per gas: %s\n", feeCap, tip)
+	} else {
+		gasPrice, err := client.SuggestGasPrice(ctx)
+		handleError(err)
+
+		tx.GasPrice = (*hexutil.Big)(gasPrice)
+
+		fmt.Printf("Suggested gas price: %s\n", gasPrice)
+	}
+
+	err = offline.WriteFile(*out, tx)
+	handleError(err)
+
+	fmt.Printf("Unsigned %s transaction (nonce %d, gas %d) written to %s\n", decoded.Method, nonce, gas, *out)
+}
+
+func handleError(err error) {
+	if err != nil {
+		panic(err)
+	}
+}
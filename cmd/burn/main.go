@@ -0,0 +1,107 @@
+package main
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"flag"
+	"fmt"
+	token "go-ethereum-example/gen"
+	"go-ethereum-example/pkg/revert"
+	"go-ethereum-example/pkg/units"
+	"os"
+
+	_ "github.com/joho/godotenv/autoload"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+func main() {
+	contract := flag.String("contract", "0x5FbDB2315678afecb367f032d93F642f64180aa3", "MyAdminToken contract address")
+	from := flag.String("from", "", "burn from this account using the signer's allowance (default: the signer's own balance)")
+	amount := flag.String("amount", "", "amount to burn in token units")
+	flag.Parse()
+
+	if *from != "" && !common.IsHexAddress(*from) {
+		handleError(fmt.Errorf("invalid from address %q", *from))
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Connect to Ethereum client with RPC endpoint
+	client, err := ethclient.DialContext(ctx, os.Getenv("RPC_ENDPOINT"))
+	handleError(err)
+
+	defer client.Close()
+
+	fmt.Println("Successfully connected to Ethereum client")
+
+	// Create an instance of the contract, specifying its address
+	tokenInstance, err := token.NewAdminToken(common.HexToAddress(*contract), client)
+	handleError(err)
+
+	decimals, err := tokenInstance.Decimals(&bind.CallOpts{Context: ctx})
+	handleError(err)
+
+	value, err := units.Parse(*amount, decimals)
+	handleError(err)
+
+	// Parse wallet private key
+	privateKey := mustParsePrivateKey()
+
+	chainID, err := client.ChainID(ctx)
+	handleError(err)
+
+	// Create an transactor with the private key and chain ID
+	signer, err := bind.NewKeyedTransactorWithChainID(privateKey, chainID)
+	handleError(err)
+
+	signer.Context = ctx
+
+	account := signer.From
+
+	// Call burn or burnFrom method (state-changing)
+	var tx *types.Transaction
+	if *from == "" {
+		tx, err = tokenInstance.Burn(signer, value)
+	} else {
+		account = common.HexToAddress(*from)
+		tx, err = tokenInstance.BurnFrom(signer, account, value)
+	}
+	handleError(revert.Decode(err))
+
+	fmt.Printf("Transaction hash: %s\n", tx.Hash().Hex())
+
+	// Wait for the transaction to be mined
+	receipt, err := bind.WaitMined(ctx, client, tx)
+	handleError(err)
+
+	fmt.Printf("Transaction receipt status %d\n", receipt.Status)
+
+	handleError(revert.Receipt(ctx, client, tx, receipt))
+
+	balance, err := tokenInstance.BalanceOf(&bind.CallOpts{Context: ctx}, account)
+	handleError(err)
+
+	fmt.Printf("Burned %s tokens from %s, remaining balance %s\n", units.Format(value, decimals), account.Hex(), units.Format(balance, decimals))
+}
+
+func mustParsePrivateKey() *ecdsa.PrivateKey {
+	rawPrivateKey := os.Getenv("PRIVATE_KEY")
+
+	// Parse the private key
+	privateKey, err := crypto.HexToECDSA(rawPrivateKey)
+	handleError(err)
+
+	return privateKey
+}
+
+func handleError(err error) {
+	if err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"go-ethereum-example/pkg/abiargs"
+	"go-ethereum-example/pkg/block"
+	"go-ethereum-example/pkg/revert"
+	"os"
+	"strings"
+
+	_ "github.com/joho/godotenv/autoload"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+func main() {
+	address := flag.String("address", "0x5FbDB2315678afecb367f032d93F642f64180aa3", "contract address")
+	build := flag.String("build", "build", "directory of the <contract>.abi files")
+	from := flag.String("from", "", "sender of the call (default: zero address)")
+	blockFlag := flag.String("block", "latest", block.Usage)
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: call [flags] <contract or abi file> <method> [args...]\n\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if flag.NArg() < 2 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	// Load the ABI and parse the arguments before connecting
+	parsed, err := abiargs.Load(*build, flag.Arg(0))
+	handleError(err)
+
+	method, err := abiargs.Method(parsed, flag.Arg(1))
+	handleError(err)
+
+	args, err := abiargs.ParseArgs(method.Inputs, flag.Args()[2:])
+	handleError(err)
+
+	data, err := parsed.Pack(method.Name, args...)
+	handleError(err)
+
+	var sender common.Address
+	if *from != "" {
+		sender, err = abiargs.ParseAddress(*from)
+		handleError(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Connect to Ethereum client with RPC endpoint
+	client, err := ethclient.DialContext(ctx, os.Getenv("RPC_ENDPOINT"))
+	handleError(err)
+
+	defer client.Close()
+
+	fmt.Println("Successfully connected to Ethereum client")
+
+	// Resolve the block to read at, so the result can be reproduced
+	at, err := block.Resolve(ctx, client, *blockFlag)
+	handleError(err)
+
+	contractAddress := common.HexToAddress(*address)
+	msg := ethereum.CallMsg{From: sender, To: &contractAddress, Data: data}
+
+	fmt.Printf("Calling %s on %s at %s\n", method.Sig, contractAddress.Hex(), at)
+
+	var out []byte
+	if at.Pending {
+		out, err = client.PendingCallContract(ctx, msg)
+	} else {
+		out, err = client.CallContract(ctx, msg, at.Number)
+	}
+
+	if err != nil {
+		// Decode the revert with the errors of the contract's own ABI
+		if revertData, ok := revert.Data(err); ok {
+			if decoded, ok := abiargs.DecodeError(parsed, revertData); ok {
+				handleError(fmt.Errorf("call reverted: %s", decoded))
+			}
+		}
+
+		handleError(err)
+	}
+
+	values, err := method.Outputs.Unpack(out)
+	if err != nil {
+		handleError(fmt.Errorf("decode %s output %s: %w", method.Name, hexutil.Encode(out), err))
+	}
+
+	fmt.Println(strings.Join(abiargs.Values(method.Outputs, values), "\n"))
+}
+
+func handleError(err error) {
+	if err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"go-ethereum-example/pkg/compile"
+	"os"
+	"path/filepath"
+)
+
+func main() {
+	root := flag.String("root", ".", "repository root holding contracts/, build/, gen/ and node_modules/")
+	config := flag.String("config", compile.ConfigFile, "compiler configuration, relative to the root")
+	check := flag.Bool("check", false, "only check that the committed artifacts and bindings are up to date")
+	flag.Parse()
+
+	// Load the pinned compiler configuration
+	cfg, err := compile.LoadConfig(filepath.Join(*root, *config))
+	handleError(err)
+
+	// Compile every target in memory
+	files, err := compile.Build(context.Background(), *root, cfg)
+	handleError(err)
+
+	if *check {
+		stale, err := files.Stale(*root, cfg.Build)
+		handleError(err)
+
+		if len(stale) > 0 {
+			fmt.Fprintf(os.Stderr, "Stale artifacts of %s, run go generate:\n", *config)
+			for _, p := range stale {
+				fmt.Fprintf(os.Stderr, "  %s\n", p)
+			}
+			os.Exit(1)
+		}
+
+		fmt.Printf("%d artifacts and bindings are up to date\n", len(files))
+		return
+	}
+
+	// Remove artifacts of contracts that no longer exist
+	stale, err := files.Stale(*root, cfg.Build)
+	handleError(err)
+
+	for _, p := range stale {
+		if _, ok := files[p]; !ok {
+			handleError(os.Remove(filepath.Join(*root, filepath.FromSlash(p))))
+			fmt.Printf("Removed %s\n", p)
+		}
+	}
+
+	handleError(files.Write(*root))
+
+	fmt.Printf("Compiled %d targets with solc %s, wrote %d files (%d changed)\n", len(cfg.Targets), cfg.Solc, len(files), len(stale))
+}
+
+func handleError(err error) {
+	if err != nil {
+		panic(err)
+	}
+}
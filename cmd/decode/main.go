@@ -0,0 +1,140 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"go-ethereum-example/pkg/decoder"
+	"go-ethereum-example/pkg/revert"
+	"os"
+
+	_ "github.com/joho/godotenv/autoload"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+func main() {
+	build := flag.String("build", "build", "directory of the .abi files to decode with, searched recursively")
+	data := flag.String("data", "", "decode hex calldata or revert data instead of a transaction, without a node")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: decode [flags] <transaction hash>\n       decode [flags] -data <hex>\n\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	// Index every method, event and error of the build directory
+	registry, err := decoder.Load(*build)
+	handleError(err)
+
+	if *data != "" {
+		raw, err := hexutil.Decode(*data)
+		handleError(err)
+
+		decodeData(registry, raw)
+		return
+	}
+
+	if flag.NArg() != 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	rawHash, err := hexutil.Decode(flag.Arg(0))
+	if err != nil || len(rawHash) != common.HashLength {
+		handleError(fmt.Errorf("invalid transaction hash %q", flag.Arg(0)))
+	}
+
+	hash := common.BytesToHash(rawHash)
+
+	ctx := context.Background()
+
+	// Connect to Ethereum client with RPC endpoint
+	client, err := ethclient.DialContext(ctx, os.Getenv("RPC_ENDPOINT"))
+	handleError(err)
+
+	defer client.Close()
+
+	tx, pending, err := client.TransactionByHash(ctx, hash)
+	handleError(err)
+
+	from, err := types.Sender(types.LatestSignerForChainID(tx.ChainId()), tx)
+	handleError(err)
+
+	fmt.Printf("Transaction %s\n", hash.Hex())
+	fmt.Printf("From: %s\n", from.Hex())
+
+	if tx.To() == nil {
+		fmt.Println("To: contract creation")
+	} else {
+		fmt.Printf("To: %s\n", tx.To().Hex())
+	}
+
+	fmt.Printf("Value: %s wei\n", tx.Value())
+
+	// Decode the input, unless the transaction deploys a contract or sends ether
+	switch {
+	case tx.To() == nil:
+		fmt.Printf("Input: %d bytes of creation code\n", len(tx.Data()))
+	case len(tx.Data()) == 0:
+		fmt.Println("Input: none")
+	default:
+		call, err := registry.DecodeCall(tx.Data())
+		fmt.Printf("Input: %s\n", decoder.Describe(call, err))
+	}
+
+	if pending {
+		fmt.Println("Status: pending")
+		return
+	}
+
+	receipt, err := client.TransactionReceipt(ctx, hash)
+	handleError(err)
+
+	fmt.Printf("Status: %d in block %d, gas used %d\n", receipt.Status, receipt.BlockNumber, receipt.GasUsed)
+
+	if receipt.ContractAddress != (common.Address{}) {
+		fmt.Printf("Contract deployed at %s\n", receipt.ContractAddress.Hex())
+	}
+
+	// Receipts carry no revert data, replay the transaction to get it
+	if receipt.Status == types.ReceiptStatusFailed {
+		err := revert.Receipt(ctx, client, tx, receipt)
+
+		if revertData, ok := revert.Data(err); ok {
+			decoded, decodeErr := registry.DecodeError(revertData)
+			fmt.Printf("Revert: %s\n", decoder.Describe(decoded, decodeErr))
+		} else {
+			fmt.Printf("Revert: %v\n", err)
+		}
+	}
+
+	for _, log := range receipt.Logs {
+		decoded, err := registry.DecodeLog(log)
+		fmt.Printf("Log %d from %s: %s\n", log.Index, log.Address.Hex(), decoder.Describe(decoded, err))
+	}
+}
+
+// decodeData decodes data as calldata, then as revert data.
+func decodeData(registry *decoder.Registry, data []byte) {
+	call, err := registry.DecodeCall(data)
+	if err == nil {
+		fmt.Printf("Call: %s\n", decoder.Describe(call, nil))
+		return
+	}
+
+	if decoded, err := registry.DecodeError(data); err == nil {
+		fmt.Printf("Revert: %s\n", decoder.Describe(decoded, nil))
+		return
+	}
+
+	fmt.Printf("Call: %s\n", decoder.Describe(nil, err))
+}
+
+func handleError(err error) {
+	if err != nil {
+		panic(err)
+	}
+}
@@ -3,6 +3,7 @@ package main
 import (
 	"context"
 	"crypto/ecdsa"
+	"flag"
 	"fmt"
 	token "go-ethereum-example/gen"
 	"math/big"
@@ -11,11 +12,16 @@ import (
 	_ "github.com/joho/godotenv/autoload"
 
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/ethclient"
 )
 
 func main() {
+	withPermit := flag.Bool("permit", false, "deploy MyTokenPermit (EIP-2612) instead of MyToken")
+	withAdmin := flag.Bool("admin", false, "deploy MyAdminToken (mintable, burnable, pausable, capped) instead of MyToken")
+	flag.Parse()
+
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
@@ -59,7 +65,18 @@ func main() {
 	// Deploy the contract with initial supply of 1,000,000 tokens
 	initialSupply := big.NewInt(0).Mul(big.NewInt(1000000), big.NewInt(0).Exp(big.NewInt(10), big.NewInt(18), nil))
 
-	_, tx, _, err := token.DeployToken(signer, client, initialSupply)
+	var tx *types.Transaction
+
+	switch {
+	case *withPermit && *withAdmin:
+		handleError(fmt.Errorf("-permit and -admin are mutually exclusive"))
+	case *withPermit:
+		_, tx, _, err = token.DeployTokenPermit(signer, client, initialSupply)
+	case *withAdmin:
+		_, tx, _, err = token.DeployAdminToken(signer, client, initialSupply)
+	default:
+		_, tx, _, err = token.DeployToken(signer, client, initialSupply)
+	}
 	handleError(err)
 
 	fmt.Printf("Transaction hash: %s\n", tx.Hash().Hex())
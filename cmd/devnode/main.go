@@ -0,0 +1,88 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"go-ethereum-example/pkg/devnode"
+	"go-ethereum-example/pkg/multicall"
+	"go-ethereum-example/pkg/units"
+	"log/slog"
+	"math/big"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+func main() {
+	cfg := devnode.DefaultConfig()
+
+	host := flag.String("host", cfg.Host, "listen host for HTTP and WebSocket JSON-RPC")
+	port := flag.Int("port", cfg.Port, "listen port shared by HTTP and WebSocket JSON-RPC")
+	chainID := flag.Int64("chain-id", cfg.ChainID.Int64(), "chain ID")
+	blockTime := flag.Duration("block-time", 0, "mine a block every interval instead of on every transaction (e.g. 2s)")
+	deployToken := flag.Bool("deploy-token", false, "deploy MyToken with 1,000,000 tokens from the first account at startup")
+	verbose := flag.Bool("v", false, "show the node's logs")
+	flag.Parse()
+
+	cfg.Host = *host
+	cfg.Port = *port
+	cfg.ChainID = big.NewInt(*chainID)
+	cfg.BlockTime = *blockTime
+	cfg.DeployToken = *deployToken
+
+	level := log.LevelWarn
+	if *verbose {
+		level = log.LevelInfo
+	}
+	log.SetDefault(log.NewLogger(log.NewTerminalHandlerWithLevel(os.Stderr, slog.Level(level), true)))
+
+	// Start the chain and its RPC servers
+	node, err := devnode.Start(cfg)
+	handleError(err)
+
+	defer node.Close()
+
+	fmt.Println("Available Accounts")
+	fmt.Println("==================")
+	for i, a := range node.Accounts {
+		fmt.Printf("(%d) %s (%s ETH)\n", i, a.Address.Hex(), units.Format(cfg.Balance, 18))
+	}
+
+	fmt.Println()
+	fmt.Println("Private Keys")
+	fmt.Println("==================")
+	for i, a := range node.Accounts {
+		fmt.Printf("(%d) 0x%x\n", i, crypto.FromECDSA(a.Key))
+	}
+
+	fmt.Println()
+	fmt.Printf("Chain ID: %d\n", cfg.ChainID)
+	if cfg.BlockTime > 0 {
+		fmt.Printf("Mining: every %s\n", cfg.BlockTime)
+	} else {
+		fmt.Println("Mining: automine")
+	}
+	fmt.Printf("Multicall3 deployed at %s\n", multicall.Address.Hex())
+	if cfg.DeployToken {
+		fmt.Printf("MyToken deployed at %s\n", node.Token.Hex())
+	}
+
+	fmt.Println()
+	fmt.Printf("Listening on %s and %s\n", node.HTTPEndpoint(), node.WSEndpoint())
+
+	// Serve until interrupted
+	sig := make(chan os.Signal, 1)
+	signal.Notify(sig, os.Interrupt, syscall.SIGTERM)
+	<-sig
+
+	fmt.Println("Shutting down")
+}
+
+func handleError(err error) {
+	if err != nil {
+		panic(err)
+	}
+}
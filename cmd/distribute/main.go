@@ -0,0 +1,137 @@
+package main
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"flag"
+	"fmt"
+	token "go-ethereum-example/gen"
+	"go-ethereum-example/pkg/distribute"
+	"go-ethereum-example/pkg/units"
+	"math/big"
+	"os"
+	"os/signal"
+
+	_ "github.com/joho/godotenv/autoload"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+func main() {
+	csvPath := flag.String("csv", "recipients.csv", "CSV file of address,amount rows (amounts in token units)")
+	journalPath := flag.String("journal", "", "journal file (default: <csv>.journal)")
+	contract := flag.String("contract", "0x5FbDB2315678afecb367f032d93F642f64180aa3", "token contract address")
+	concurrency := flag.Int("concurrency", 4, "maximum number of unconfirmed transactions")
+	dryRun := flag.Bool("dry-run", false, "validate and print the summary without sending")
+	flag.Parse()
+
+	if *journalPath == "" {
+		*journalPath = *csvPath + ".journal"
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancel()
+
+	// Connect to Ethereum client with RPC endpoint
+	client, err := ethclient.DialContext(ctx, os.Getenv("RPC_ENDPOINT"))
+	handleError(err)
+
+	defer client.Close()
+
+	fmt.Println("Successfully connected to Ethereum client")
+
+	// Create an instance of the contract, specifying its address
+	tokenInstance, err := token.NewToken(common.HexToAddress(*contract), client)
+	handleError(err)
+
+	// Parse wallet private key
+	privateKey := mustParsePrivateKey()
+	address := crypto.PubkeyToAddress(privateKey.PublicKey)
+
+	decimals, err := tokenInstance.Decimals(&bind.CallOpts{Context: ctx})
+	handleError(err)
+
+	symbol, err := tokenInstance.Symbol(&bind.CallOpts{Context: ctx})
+	handleError(err)
+
+	// Read and validate every row before sending anything
+	f, err := os.Open(*csvPath)
+	handleError(err)
+
+	rows, err := distribute.ReadCSV(f, decimals)
+	f.Close()
+	handleError(err)
+
+	journal, err := distribute.OpenJournal(*journalPath)
+	handleError(err)
+
+	defer journal.Close()
+
+	chainID, err := client.ChainID(ctx)
+	handleError(err)
+
+	// Create an transactor with the private key and chain ID; nonces are managed by the distributor
+	signer, err := bind.NewKeyedTransactorWithChainID(privateKey, chainID)
+	handleError(err)
+
+	distributor := &distribute.Distributor{
+		Backend:     client,
+		Token:       tokenInstance,
+		Opts:        signer,
+		Journal:     journal,
+		Concurrency: *concurrency,
+		OnEntry: func(entry distribute.Entry) {
+			fmt.Printf("line %d: %s %s tx=%s nonce=%d\n", entry.Line, entry.Status, entry.Address.Hex(), entry.Hash.Hex(), entry.Nonce)
+		},
+	}
+
+	done, sent, fresh, err := distributor.Plan(rows)
+	handleError(err)
+
+	// Transactions of a previous run that are already mined are no longer owed
+	owed, err := distributor.Owed(ctx, rows)
+	handleError(err)
+
+	balance, err := tokenInstance.BalanceOf(&bind.CallOpts{Context: ctx}, address)
+	handleError(err)
+
+	// Print the dry-run summary
+	fmt.Printf("Sender: %s\n", address.Hex())
+	fmt.Printf("Recipients: %d (total %s %s)\n", len(rows), units.Format(distribute.Total(rows), decimals), symbol)
+	fmt.Printf("Already confirmed in journal: %d (%s %s)\n", len(done), units.Format(distribute.Total(done), decimals), symbol)
+	fmt.Printf("Sent, awaiting confirmation: %d (%s %s)\n", len(sent), units.Format(distribute.Total(sent), decimals), symbol)
+	fmt.Printf("To send: %d (%s %s)\n", len(fresh), units.Format(distribute.Total(fresh), decimals), symbol)
+	fmt.Printf("Sender balance: %s %s\n", units.Format(balance, decimals), symbol)
+
+	if balance.Cmp(owed) < 0 {
+		handleError(fmt.Errorf("insufficient balance: short by %s %s", units.Format(new(big.Int).Sub(owed, balance), decimals), symbol))
+	}
+
+	if *dryRun || len(sent)+len(fresh) == 0 {
+		return
+	}
+
+	err = distributor.Run(ctx, rows)
+	handleError(err)
+
+	fmt.Printf("Distribution complete: %d transfers confirmed\n", len(rows))
+}
+
+func mustParsePrivateKey() *ecdsa.PrivateKey {
+	rawPrivateKey := os.Getenv("PRIVATE_KEY")
+
+	// Parse the private key
+	privateKey, err := crypto.HexToECDSA(rawPrivateKey)
+	handleError(err)
+
+	return privateKey
+}
+
+func handleError(err error) {
+	if err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,110 @@
+package main
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"flag"
+	"fmt"
+	token "go-ethereum-example/gen"
+	"go-ethereum-example/pkg/revert"
+	"go-ethereum-example/pkg/tokengen"
+	"os"
+
+	_ "github.com/joho/godotenv/autoload"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+func main() {
+	contract := flag.String("contract", "0x5FbDB2315678afecb367f032d93F642f64180aa3", "MyAdminToken contract address")
+	role := flag.String("role", "", "role to grant: admin, minter or pauser")
+	account := flag.String("account", "", "account receiving the role")
+	revoke := flag.Bool("revoke", false, "revoke the role instead of granting it")
+	flag.Parse()
+
+	roleName, ok := tokengen.Roles[*role]
+	if !ok {
+		handleError(fmt.Errorf("unknown role %q", *role))
+	}
+
+	if !common.IsHexAddress(*account) {
+		handleError(fmt.Errorf("invalid account address %q", *account))
+	}
+
+	// DEFAULT_ADMIN_ROLE is the zero hash, the others are the hash of their name
+	var roleHash common.Hash
+	if *role != "admin" {
+		roleHash = crypto.Keccak256Hash([]byte(roleName))
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Connect to Ethereum client with RPC endpoint
+	client, err := ethclient.DialContext(ctx, os.Getenv("RPC_ENDPOINT"))
+	handleError(err)
+
+	defer client.Close()
+
+	fmt.Println("Successfully connected to Ethereum client")
+
+	// Create an instance of the contract, specifying its address
+	tokenInstance, err := token.NewAdminToken(common.HexToAddress(*contract), client)
+	handleError(err)
+
+	// Parse wallet private key
+	privateKey := mustParsePrivateKey()
+
+	chainID, err := client.ChainID(ctx)
+	handleError(err)
+
+	// Create an transactor with the private key and chain ID
+	signer, err := bind.NewKeyedTransactorWithChainID(privateKey, chainID)
+	handleError(err)
+
+	signer.Context = ctx
+
+	// Call grantRole or revokeRole method (state-changing, requires the role's admin)
+	var tx *types.Transaction
+	if *revoke {
+		tx, err = tokenInstance.RevokeRole(signer, roleHash, common.HexToAddress(*account))
+	} else {
+		tx, err = tokenInstance.GrantRole(signer, roleHash, common.HexToAddress(*account))
+	}
+	handleError(revert.Decode(err))
+
+	fmt.Printf("Transaction hash: %s\n", tx.Hash().Hex())
+
+	// Wait for the transaction to be mined
+	receipt, err := bind.WaitMined(ctx, client, tx)
+	handleError(err)
+
+	fmt.Printf("Transaction receipt status %d\n", receipt.Status)
+
+	handleError(revert.Receipt(ctx, client, tx, receipt))
+
+	has, err := tokenInstance.HasRole(&bind.CallOpts{Context: ctx}, roleHash, common.HexToAddress(*account))
+	handleError(err)
+
+	fmt.Printf("%s has %s: %t\n", common.HexToAddress(*account).Hex(), roleName, has)
+}
+
+func mustParsePrivateKey() *ecdsa.PrivateKey {
+	rawPrivateKey := os.Getenv("PRIVATE_KEY")
+
+	// Parse the private key
+	privateKey, err := crypto.HexToECDSA(rawPrivateKey)
+	handleError(err)
+
+	return privateKey
+}
+
+func handleError(err error) {
+	if err != nil {
+		panic(err)
+	}
+}
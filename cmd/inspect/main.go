@@ -0,0 +1,80 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"go-ethereum-example/pkg/block"
+	"go-ethereum-example/pkg/erc20"
+	"go-ethereum-example/pkg/units"
+	"os"
+
+	_ "github.com/joho/godotenv/autoload"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+func main() {
+	contract := flag.String("contract", "0x5FbDB2315678afecb367f032d93F642f64180aa3", "token contract address")
+	holder := flag.String("holder", "", "holder address to read the balance of and to detect transfer quirks with")
+	blockFlag := flag.String("block", "latest", block.Usage)
+	flag.Parse()
+
+	if *holder != "" && !common.IsHexAddress(*holder) {
+		handleError(fmt.Errorf("invalid holder address %q", *holder))
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Connect to Ethereum client with RPC endpoint
+	client, err := ethclient.DialContext(ctx, os.Getenv("RPC_ENDPOINT"))
+	handleError(err)
+
+	defer client.Close()
+
+	fmt.Println("Successfully connected to Ethereum client")
+
+	// Resolve the block to read at, so the result can be reproduced
+	at, err := block.Resolve(ctx, client, *blockFlag)
+	handleError(err)
+
+	fmt.Printf("At %s\n", at)
+
+	// Create a generic client for the token, which may not follow the standard
+	tok := erc20.New(common.HexToAddress(*contract), client)
+
+	metadata, err := tok.Metadata(at.CallOpts(ctx))
+	handleError(err)
+
+	fmt.Printf("Name: %q\n", metadata.Name)
+	fmt.Printf("Symbol: %q\n", metadata.Symbol)
+	fmt.Printf("Decimals: %d\n", metadata.Decimals)
+	fmt.Printf("Total supply: %s (%d base units)\n", units.Format(metadata.TotalSupply, metadata.Decimals), metadata.TotalSupply)
+
+	quirks := metadata.Quirks
+
+	if *holder != "" {
+		holderAddress := common.HexToAddress(*holder)
+
+		balance, err := tok.BalanceOf(at.CallOpts(ctx), holderAddress)
+		handleError(err)
+
+		fmt.Printf("Balance of %s: %s\n", holderAddress.Hex(), units.Format(balance, metadata.Decimals))
+
+		// Simulate a zero transfer to see what transfer returns
+		transferQuirks, err := tok.DetectTransfer(ctx, holderAddress)
+		handleError(err)
+
+		quirks |= transferQuirks
+	}
+
+	fmt.Printf("Quirks: %s\n", quirks)
+}
+
+func handleError(err error) {
+	if err != nil {
+		panic(err)
+	}
+}
@@ -1,23 +1,37 @@
 package main
 
 import (
+	"bufio"
 	"context"
 	"crypto/ecdsa"
+	"errors"
+	"flag"
 	"fmt"
 	token "go-ethereum-example/gen"
+	"go-ethereum-example/pkg/block"
+	"go-ethereum-example/pkg/preflight"
+	"go-ethereum-example/pkg/revert"
 	"math/big"
 	"os"
+	"strings"
 
 	_ "github.com/joho/godotenv/autoload"
 
-	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/ethclient"
 )
 
+// gasMargin is the percentage added to the estimated gas, so the transfer
+// still fits when the state it runs against changes before it is mined.
+const gasMargin = 20
+
 func main() {
+	blockFlag := flag.String("block", "latest", "block to read the balance at after the transfer: a number, a hash, or latest, pending, safe or finalized")
+	yes := flag.Bool("yes", false, "send the transfer without asking for confirmation after the preflight")
+	flag.Parse()
+
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
@@ -59,12 +73,39 @@ func main() {
 	signer, err := bind.NewKeyedTransactorWithChainID(privateKey, chainID)
 	handleError(err)
 
+	amount := big.NewInt(1000000)
+
+	// Simulate the transfer at the pending block before signing it, so a
+	// revert costs no gas
+	report, err := preflight.Transfer(ctx, preflight.Client{Client: client}, contractAddress, address, toAddress, amount)
+	handleError(err)
+
+	if report.Err != nil {
+		printRevert("Preflight failed, transaction not sent", report.Err)
+		return
+	}
+
+	fmt.Printf("Preflight passed, estimated gas: %d\n", report.Gas)
+
+	for _, transfer := range report.Events {
+		fmt.Printf("Expected Transfer event: %d tokens from %s to %s\n", transfer.Value, transfer.From.Hex(), transfer.To.Hex())
+	}
+
+	for _, change := range report.Balances {
+		fmt.Printf("Expected balance of %s: %d -> %d\n", change.Holder.Hex(), change.Before, change.After)
+	}
+
+	if !*yes && !confirm("Send transaction?") {
+		fmt.Println("Transaction not sent")
+		return
+	}
+
 	signer.GasPrice = gasPrice
-	signer.GasLimit = 3000000
+	signer.GasLimit = report.Gas + report.Gas*gasMargin/100
 	signer.Nonce = big.NewInt(int64(nonce))
 
 	// Call transfer method (state-changing)
-	tx, err := tokenInstance.Transfer(signer, toAddress, big.NewInt(1000000))
+	tx, err := tokenInstance.Transfer(signer, toAddress, amount)
 	handleError(err)
 
 	fmt.Printf("Transaction hash: %s\n", tx.Hash().Hex())
@@ -75,19 +116,9 @@ func main() {
 
 	fmt.Printf("Transaction receipt status %d\n", receipt.Status)
 
-	// If the transaction was reverted by the EVM, we can see the reason
+	// If the transaction was reverted by the EVM, replay it to see the reason
 	if receipt.Status == 0 {
-		msg := ethereum.CallMsg{
-			From:     address,
-			To:       tx.To(),
-			Gas:      tx.Gas(),
-			GasPrice: tx.GasPrice(),
-			Value:    tx.Value(),
-			Data:     tx.Data(),
-		}
-
-		_, err = client.CallContract(ctx, msg, nil)
-		fmt.Printf("Transaction reverted: %v\n", err)
+		printRevert("Transaction reverted", revert.Receipt(ctx, client, tx, receipt))
 		return
 	}
 
@@ -105,11 +136,34 @@ func main() {
 		fmt.Printf("Transferred %d tokens from %s to %s\n", transferred.Value, transferred.From.Hex(), transferred.To.Hex())
 	}
 
+	// Resolve the block to read at, e.g. the block of the transfer
+	at, err := block.Resolve(ctx, client, *blockFlag)
+	handleError(err)
+
 	// Call the contract method (read-only)
-	toBalance, err := tokenInstance.BalanceOf(&bind.CallOpts{Context: ctx}, toAddress)
+	toBalance, err := tokenInstance.BalanceOf(at.CallOpts(ctx), toAddress)
 	handleError(err)
 
-	fmt.Printf("To balance: %d\n", toBalance)
+	fmt.Printf("To balance: %d at %s\n", toBalance, at)
+}
+
+func printRevert(message string, err error) {
+	var insufficient *token.ERC20InsufficientBalance
+	if errors.As(err, &insufficient) {
+		fmt.Printf("Insufficient balance: %s has %s, needs %s\n", insufficient.Sender.Hex(), insufficient.Balance, insufficient.Needed)
+	}
+
+	fmt.Printf("%s: %v\n", message, err)
+}
+
+// confirm asks a yes or no question on the terminal, no by default.
+func confirm(question string) bool {
+	fmt.Printf("%s [y/N] ", question)
+
+	answer, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+	answer = strings.ToLower(strings.TrimSpace(answer))
+
+	return answer == "y" || answer == "yes"
 }
 
 func mustParsePrivateKey() *ecdsa.PrivateKey {
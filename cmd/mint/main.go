@@ -0,0 +1,98 @@
+package main
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"flag"
+	"fmt"
+	token "go-ethereum-example/gen"
+	"go-ethereum-example/pkg/revert"
+	"go-ethereum-example/pkg/units"
+	"os"
+
+	_ "github.com/joho/godotenv/autoload"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+func main() {
+	contract := flag.String("contract", "0x5FbDB2315678afecb367f032d93F642f64180aa3", "MyAdminToken contract address")
+	to := flag.String("to", "", "recipient address")
+	amount := flag.String("amount", "", "amount to mint in token units")
+	flag.Parse()
+
+	if !common.IsHexAddress(*to) {
+		handleError(fmt.Errorf("invalid recipient address %q", *to))
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Connect to Ethereum client with RPC endpoint
+	client, err := ethclient.DialContext(ctx, os.Getenv("RPC_ENDPOINT"))
+	handleError(err)
+
+	defer client.Close()
+
+	fmt.Println("Successfully connected to Ethereum client")
+
+	// Create an instance of the contract, specifying its address
+	tokenInstance, err := token.NewAdminToken(common.HexToAddress(*contract), client)
+	handleError(err)
+
+	decimals, err := tokenInstance.Decimals(&bind.CallOpts{Context: ctx})
+	handleError(err)
+
+	value, err := units.Parse(*amount, decimals)
+	handleError(err)
+
+	// Parse wallet private key
+	privateKey := mustParsePrivateKey()
+
+	chainID, err := client.ChainID(ctx)
+	handleError(err)
+
+	// Create an transactor with the private key and chain ID
+	signer, err := bind.NewKeyedTransactorWithChainID(privateKey, chainID)
+	handleError(err)
+
+	signer.Context = ctx
+
+	// Call mint method (state-changing, requires MINTER_ROLE)
+	tx, err := tokenInstance.Mint(signer, common.HexToAddress(*to), value)
+	handleError(revert.Decode(err))
+
+	fmt.Printf("Transaction hash: %s\n", tx.Hash().Hex())
+
+	// Wait for the transaction to be mined
+	receipt, err := bind.WaitMined(ctx, client, tx)
+	handleError(err)
+
+	fmt.Printf("Transaction receipt status %d\n", receipt.Status)
+
+	handleError(revert.Receipt(ctx, client, tx, receipt))
+
+	totalSupply, err := tokenInstance.TotalSupply(&bind.CallOpts{Context: ctx})
+	handleError(err)
+
+	fmt.Printf("Minted %s tokens to %s, total supply %s\n", units.Format(value, decimals), common.HexToAddress(*to).Hex(), units.Format(totalSupply, decimals))
+}
+
+func mustParsePrivateKey() *ecdsa.PrivateKey {
+	rawPrivateKey := os.Getenv("PRIVATE_KEY")
+
+	// Parse the private key
+	privateKey, err := crypto.HexToECDSA(rawPrivateKey)
+	handleError(err)
+
+	return privateKey
+}
+
+func handleError(err error) {
+	if err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"flag"
+	"fmt"
+	token "go-ethereum-example/gen"
+	"go-ethereum-example/pkg/revert"
+	"os"
+
+	_ "github.com/joho/godotenv/autoload"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+func main() {
+	contract := flag.String("contract", "0x5FbDB2315678afecb367f032d93F642f64180aa3", "MyAdminToken contract address")
+	unpause := flag.Bool("unpause", false, "unpause the token instead of pausing it")
+	flag.Parse()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Connect to Ethereum client with RPC endpoint
+	client, err := ethclient.DialContext(ctx, os.Getenv("RPC_ENDPOINT"))
+	handleError(err)
+
+	defer client.Close()
+
+	fmt.Println("Successfully connected to Ethereum client")
+
+	// Create an instance of the contract, specifying its address
+	tokenInstance, err := token.NewAdminToken(common.HexToAddress(*contract), client)
+	handleError(err)
+
+	paused, err := tokenInstance.Paused(&bind.CallOpts{Context: ctx})
+	handleError(err)
+
+	if paused != *unpause {
+		fmt.Printf("Nothing to do, token paused: %t\n", paused)
+		return
+	}
+
+	// Parse wallet private key
+	privateKey := mustParsePrivateKey()
+
+	chainID, err := client.ChainID(ctx)
+	handleError(err)
+
+	// Create an transactor with the private key and chain ID
+	signer, err := bind.NewKeyedTransactorWithChainID(privateKey, chainID)
+	handleError(err)
+
+	signer.Context = ctx
+
+	// Call pause or unpause method (state-changing, requires PAUSER_ROLE)
+	var tx *types.Transaction
+	if *unpause {
+		tx, err = tokenInstance.Unpause(signer)
+	} else {
+		tx, err = tokenInstance.Pause(signer)
+	}
+	handleError(revert.Decode(err))
+
+	fmt.Printf("Transaction hash: %s\n", tx.Hash().Hex())
+
+	// Wait for the transaction to be mined
+	receipt, err := bind.WaitMined(ctx, client, tx)
+	handleError(err)
+
+	fmt.Printf("Transaction receipt status %d\n", receipt.Status)
+
+	handleError(revert.Receipt(ctx, client, tx, receipt))
+
+	paused, err = tokenInstance.Paused(&bind.CallOpts{Context: ctx})
+	handleError(err)
+
+	fmt.Printf("Token paused: %t\n", paused)
+}
+
+func mustParsePrivateKey() *ecdsa.PrivateKey {
+	rawPrivateKey := os.Getenv("PRIVATE_KEY")
+
+	// Parse the private key
+	privateKey, err := crypto.HexToECDSA(rawPrivateKey)
+	handleError(err)
+
+	return privateKey
+}
+
+func handleError(err error) {
+	if err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,137 @@
+package main
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"encoding/json"
+	"flag"
+	"fmt"
+	token "go-ethereum-example/gen"
+	"go-ethereum-example/pkg/permit"
+	"go-ethereum-example/pkg/units"
+	"math/big"
+	"os"
+	"time"
+
+	_ "github.com/joho/godotenv/autoload"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+// Output is the signed permit as handed to the spender.
+type Output struct {
+	Token     common.Address   `json:"token"`
+	Owner     common.Address   `json:"owner"`
+	Spender   common.Address   `json:"spender"`
+	Value     string           `json:"value"`
+	Nonce     string           `json:"nonce"`
+	Deadline  string           `json:"deadline"`
+	Signature permit.Signature `json:"signature"`
+	Packed    string           `json:"packed"`
+}
+
+func main() {
+	contract := flag.String("contract", "0x5FbDB2315678afecb367f032d93F642f64180aa3", "MyTokenPermit contract address")
+	spender := flag.String("spender", "", "spender address")
+	amount := flag.String("amount", "", "allowance in token units")
+	validFor := flag.Duration("valid-for", time.Hour, "how long the permit stays valid")
+	submit := flag.Bool("submit", false, "submit the permit on-chain instead of only printing it")
+	flag.Parse()
+
+	if !common.IsHexAddress(*spender) {
+		handleError(fmt.Errorf("invalid spender address %q", *spender))
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Connect to Ethereum client with RPC endpoint
+	client, err := ethclient.DialContext(ctx, os.Getenv("RPC_ENDPOINT"))
+	handleError(err)
+
+	defer client.Close()
+
+	// Create an instance of the contract, specifying its address
+	tokenInstance, err := token.NewTokenPermit(common.HexToAddress(*contract), client)
+	handleError(err)
+
+	decimals, err := tokenInstance.Decimals(&bind.CallOpts{Context: ctx})
+	handleError(err)
+
+	value, err := units.Parse(*amount, decimals)
+	handleError(err)
+
+	// Parse wallet private key of the owner
+	privateKey := mustParsePrivateKey()
+	owner := crypto.PubkeyToAddress(privateKey.PublicKey)
+
+	// Read the EIP-712 domain from the token and sign the permit
+	domain, err := permit.DomainOf(ctx, tokenInstance)
+	handleError(err)
+
+	deadline := big.NewInt(time.Now().Add(*validFor).Unix())
+
+	p, err := permit.New(ctx, tokenInstance, owner, common.HexToAddress(*spender), value, deadline)
+	handleError(err)
+
+	sig, err := permit.Sign(p, domain, privateKey)
+	handleError(err)
+
+	out, err := json.MarshalIndent(Output{
+		Token:     domain.VerifyingContract,
+		Owner:     p.Owner,
+		Spender:   p.Spender,
+		Value:     p.Value.String(),
+		Nonce:     p.Nonce.String(),
+		Deadline:  p.Deadline.String(),
+		Signature: sig,
+		Packed:    sig.String(),
+	}, "", "  ")
+	handleError(err)
+
+	fmt.Println(string(out))
+
+	if !*submit {
+		return
+	}
+
+	chainID, err := client.ChainID(ctx)
+	handleError(err)
+
+	// Create an transactor with the private key and chain ID
+	signer, err := bind.NewKeyedTransactorWithChainID(privateKey, chainID)
+	handleError(err)
+
+	signer.Context = ctx
+
+	// Call permit method (state-changing)
+	tx, err := tokenInstance.Permit(signer, p.Owner, p.Spender, p.Value, p.Deadline, sig.V, sig.R, sig.S)
+	handleError(err)
+
+	fmt.Printf("Transaction hash: %s\n", tx.Hash().Hex())
+
+	// Wait for the transaction to be mined
+	receipt, err := bind.WaitMined(ctx, client, tx)
+	handleError(err)
+
+	fmt.Printf("Transaction receipt status %d\n", receipt.Status)
+}
+
+func mustParsePrivateKey() *ecdsa.PrivateKey {
+	rawPrivateKey := os.Getenv("PRIVATE_KEY")
+
+	// Parse the private key
+	privateKey, err := crypto.HexToECDSA(rawPrivateKey)
+	handleError(err)
+
+	return privateKey
+}
+
+func handleError(err error) {
+	if err != nil {
+		panic(err)
+	}
+}
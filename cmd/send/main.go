@@ -0,0 +1,141 @@
+package main
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"flag"
+	"fmt"
+	"go-ethereum-example/pkg/abiargs"
+	"go-ethereum-example/pkg/revert"
+	"math/big"
+	"os"
+
+	_ "github.com/joho/godotenv/autoload"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+func main() {
+	address := flag.String("address", "0x5FbDB2315678afecb367f032d93F642f64180aa3", "contract address")
+	build := flag.String("build", "build", "directory of the <contract>.abi files")
+	value := flag.String("value", "0", "ether to send with payable methods, e.g. 0.1ether or 1000 (wei)")
+	gasLimit := flag.Uint64("gas-limit", 0, "gas limit (default: estimated)")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: send [flags] <contract or abi file> <method> [args...]\n\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if flag.NArg() < 2 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	// Load the ABI and parse the arguments before connecting
+	parsed, err := abiargs.Load(*build, flag.Arg(0))
+	handleError(err)
+
+	method, err := abiargs.Method(parsed, flag.Arg(1))
+	handleError(err)
+
+	args, err := abiargs.ParseArgs(method.Inputs, flag.Args()[2:])
+	handleError(err)
+
+	uint256, _ := abi.NewType("uint256", "", nil)
+
+	amount, err := abiargs.Parse(uint256, *value)
+	handleError(err)
+
+	if amount.(*big.Int).Sign() > 0 && !method.Payable {
+		handleError(fmt.Errorf("%s is not payable", method.Sig))
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Connect to Ethereum client with RPC endpoint
+	client, err := ethclient.DialContext(ctx, os.Getenv("RPC_ENDPOINT"))
+	handleError(err)
+
+	defer client.Close()
+
+	fmt.Println("Successfully connected to Ethereum client")
+
+	// Parse wallet private key
+	privateKey := mustParsePrivateKey()
+
+	chainID, err := client.ChainID(ctx)
+	handleError(err)
+
+	// Create an transactor with the private key and chain ID
+	signer, err := bind.NewKeyedTransactorWithChainID(privateKey, chainID)
+	handleError(err)
+
+	signer.Context = ctx
+	signer.Value = amount.(*big.Int)
+	signer.GasLimit = *gasLimit
+
+	// Create an instance of the contract from the loaded ABI, specifying its address
+	contractAddress := common.HexToAddress(*address)
+	contract := bind.NewBoundContract(contractAddress, *parsed, client, client, client)
+
+	tx, err := contract.Transact(signer, method.Name, args...)
+	handleError(decodeError(parsed, err))
+
+	fmt.Printf("Sent %s to %s: transaction %s\n", method.Sig, contractAddress.Hex(), tx.Hash().Hex())
+
+	// Wait for the transaction to be mined
+	receipt, err := bind.WaitMined(ctx, client, tx)
+	handleError(err)
+
+	fmt.Printf("Mined in block %d, status %d, gas used %d\n", receipt.BlockNumber, receipt.Status, receipt.GasUsed)
+
+	handleError(decodeError(parsed, revert.Receipt(ctx, client, tx, receipt)))
+
+	// Decode the events emitted by the contract
+	for _, log := range receipt.Logs {
+		if log.Address != contractAddress {
+			continue
+		}
+
+		if event, err := abiargs.DecodeLog(parsed, log); err == nil {
+			fmt.Printf("Event %s\n", event)
+		}
+	}
+}
+
+// decodeError adds the custom error of the contract's ABI to errors that carry
+// revert data.
+func decodeError(parsed *abi.ABI, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	if data, ok := revert.Data(err); ok {
+		if decoded, ok := abiargs.DecodeError(parsed, data); ok {
+			return fmt.Errorf("%s: %w", decoded, err)
+		}
+	}
+
+	return err
+}
+
+func mustParsePrivateKey() *ecdsa.PrivateKey {
+	rawPrivateKey := os.Getenv("PRIVATE_KEY")
+
+	// Parse the private key
+	privateKey, err := crypto.HexToECDSA(rawPrivateKey)
+	handleError(err)
+
+	return privateKey
+}
+
+func handleError(err error) {
+	if err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"crypto/ecdsa"
+	"flag"
+	"fmt"
+	"go-ethereum-example/pkg/eip712"
+	"os"
+
+	_ "github.com/joho/godotenv/autoload"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// sign-typed-data signs eth_signTypedData_v4 JSON with PRIVATE_KEY, or
+// recovers the signer of an existing signature with -verify.
+func main() {
+	file := flag.String("file", "", "typed data JSON file (eth_signTypedData_v4 format)")
+	verify := flag.String("verify", "", "signature to verify instead of signing")
+	flag.Parse()
+
+	b, err := os.ReadFile(*file)
+	handleError(err)
+
+	td, err := eip712.Parse(b)
+	handleError(err)
+
+	separator, err := td.DomainSeparator()
+	handleError(err)
+
+	messageHash, err := td.MessageHash()
+	handleError(err)
+
+	digest, err := td.Digest()
+	handleError(err)
+
+	fmt.Printf("Domain separator: %s\n", separator.Hex())
+	fmt.Printf("Message hash (%s): %s\n", td.PrimaryType, messageHash.Hex())
+	fmt.Printf("Digest: %s\n", digest.Hex())
+
+	if *verify != "" {
+		sig, err := hexutil.Decode(*verify)
+		handleError(err)
+
+		signer, err := td.Recover(sig)
+		handleError(err)
+
+		fmt.Printf("Signer: %s\n", signer.Hex())
+		return
+	}
+
+	// Parse wallet private key
+	privateKey := mustParsePrivateKey()
+
+	sig, err := td.Sign(privateKey)
+	handleError(err)
+
+	fmt.Printf("Signer: %s\n", crypto.PubkeyToAddress(privateKey.PublicKey).Hex())
+	fmt.Printf("Signature: %s\n", hexutil.Encode(sig))
+}
+
+func mustParsePrivateKey() *ecdsa.PrivateKey {
+	rawPrivateKey := os.Getenv("PRIVATE_KEY")
+
+	// Parse the private key
+	privateKey, err := crypto.HexToECDSA(rawPrivateKey)
+	handleError(err)
+
+	return privateKey
+}
+
+func handleError(err error) {
+	if err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"crypto/ecdsa"
+	"flag"
+	"fmt"
+	"go-ethereum-example/pkg/offline"
+	"os"
+	"sort"
+
+	_ "github.com/joho/godotenv/autoload"
+
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// sign needs no network access: run it on the offline machine holding the key.
+func main() {
+	in := flag.String("in", "unsigned.json", "unsigned transaction file")
+	out := flag.String("out", "signed.json", "signed transaction file")
+	flag.Parse()
+
+	var tx offline.UnsignedTx
+
+	err := offline.ReadFile(*in, &tx)
+	handleError(err)
+
+	// Show what is about to be signed
+	to := "<contract creation>"
+	if tx.To != nil {
+		to = tx.To.Hex()
+	}
+
+	fmt.Printf("Chain ID: %d\n", tx.ChainID.ToInt())
+	fmt.Printf("From: %s\n", tx.From.Hex())
+	fmt.Printf("To: %s\n", to)
+	fmt.Printf("Nonce: %d, gas: %d\n", tx.Nonce, tx.Gas)
+	fmt.Printf("Value: %s wei\n", tx.Transaction().Value())
+	fmt.Printf("Max fee: %s wei\n", tx.MaxFee())
+
+	if tx.Decoded != nil {
+		fmt.Printf("Call: %s\n", tx.Decoded.Method)
+
+		keys := make([]string, 0, len(tx.Decoded.Args))
+		for k := range tx.Decoded.Args {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		for _, k := range keys {
+			fmt.Printf("  %s: %s\n", k, tx.Decoded.Args[k])
+		}
+	}
+
+	// Parse wallet private key
+	privateKey := mustParsePrivateKey()
+
+	signed, err := offline.Sign(&tx, privateKey)
+	handleError(err)
+
+	err = offline.WriteFile(*out, signed)
+	handleError(err)
+
+	fmt.Printf("Transaction hash: %s\n", signed.Hash.Hex())
+	fmt.Printf("Signed transaction written to %s\n", *out)
+}
+
+func mustParsePrivateKey() *ecdsa.PrivateKey {
+	rawPrivateKey := os.Getenv("PRIVATE_KEY")
+
+	// Parse the private key
+	privateKey, err := crypto.HexToECDSA(rawPrivateKey)
+	handleError(err)
+
+	return privateKey
+}
+
+func handleError(err error) {
+	if err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"go-ethereum-example/pkg/stream"
+	"go-ethereum-example/pkg/watcher"
+	"net"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	_ "github.com/joho/godotenv/autoload"
+
+	"github.com/ethereum/go-ethereum/common"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/reflection"
+)
+
+func main() {
+	listen := flag.String("listen", ":50051", "address to serve gRPC on")
+	contract := flag.String("contract", "0x5FbDB2315678afecb367f032d93F642f64180aa3", "comma-separated token contract addresses")
+	fromBlock := flag.Uint64("from-block", 1, "first block whose events are held, streams cannot start before it")
+	confirmations := flag.Uint64("confirmations", 0, "blocks to stay behind head, so events of short reorgs are not streamed")
+	pollInterval := flag.Duration("poll-interval", watcher.DefaultPollInterval, "time between polls when RPC_WS_ENDPOINT is unset")
+	flag.Parse()
+
+	// Follow heads over WebSocket, or poll over HTTP
+	endpoint, interval := os.Getenv("RPC_WS_ENDPOINT"), time.Duration(0)
+	if endpoint == "" {
+		endpoint, interval = os.Getenv("RPC_ENDPOINT"), *pollInterval
+	}
+
+	srv := &stream.Server{
+		Dial: func(ctx context.Context) (watcher.Client, error) {
+			// Connect to Ethereum client with RPC endpoint
+			client, err := watcher.Dial(ctx, endpoint)
+			if err != nil {
+				return nil, err
+			}
+
+			fmt.Println("Successfully connected to Ethereum client")
+
+			return client, nil
+		},
+		Tokens:        mustParseAddresses("contract", *contract),
+		FromBlock:     *fromBlock,
+		Confirmations: *confirmations,
+		PollInterval:  interval,
+		Logf: func(format string, args ...any) {
+			fmt.Printf(format+"\n", args...)
+		},
+	}
+
+	server := grpc.NewServer()
+	stream.RegisterTokenEventsServer(server, srv)
+
+	// Let grpcurl and other clients list the service
+	reflection.Register(server)
+
+	listener, err := net.Listen("tcp", *listen)
+	handleError(err)
+
+	// Stop on SIGINT and SIGTERM
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	go func() {
+		handleError(server.Serve(listener))
+	}()
+
+	fmt.Printf("Serving token events over gRPC on %s\n", listener.Addr())
+
+	// One watcher feeds every stream
+	err = srv.Run(ctx)
+
+	// Streams end once the watcher stops, so there is nothing to wait for
+	server.Stop()
+
+	handleError(err)
+}
+
+func mustParseAddresses(name, list string) []common.Address {
+	var addresses []common.Address
+	for _, s := range strings.Split(list, ",") {
+		if !common.IsHexAddress(strings.TrimSpace(s)) {
+			handleError(fmt.Errorf("invalid %s address %q", name, s))
+		}
+
+		addresses = append(addresses, common.HexToAddress(strings.TrimSpace(s)))
+	}
+
+	return addresses
+}
+
+func handleError(err error) {
+	if err != nil {
+		panic(err)
+	}
+}
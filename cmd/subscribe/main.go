@@ -2,54 +2,292 @@ package main
 
 import (
 	"context"
+	"errors"
+	"flag"
 	"fmt"
-	token "go-ethereum-example/gen"
+	"go-ethereum-example/pkg/abiargs"
+	"go-ethereum-example/pkg/alert"
+	"go-ethereum-example/pkg/sink"
+	"go-ethereum-example/pkg/watcher"
+	"io"
+	"net/http"
 	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
 
 	_ "github.com/joho/godotenv/autoload"
 
-	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
-	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 )
 
 func main() {
-	ctx, cancel := context.WithCancel(context.Background())
+	contract := flag.String("contract", "0x5FbDB2315678afecb367f032d93F642f64180aa3", "comma-separated token contract addresses")
+	manifest := flag.String("manifest", "", "JSON file listing the tokens to watch instead of -contract, reloaded when it changes")
+	reloadInterval := flag.Duration("reload-interval", watcher.DefaultReloadInterval, "time between reads of -manifest")
+	fromBlock := flag.Uint64("from-block", 0, "first block to deliver events from, e.g. to resume after a restart (default: next block)")
+	events := flag.String("events", "Transfer", "comma-separated events to deliver: "+strings.Join(watcher.Events, ", "))
+	from := flag.String("from", "", "comma-separated senders of transfers and owners of approvals to deliver")
+	to := flag.String("to", "", "comma-separated recipients of transfers and spenders of approvals to deliver")
+	involving := flag.String("involving", "", "comma-separated addresses on either side of the events to deliver")
+	confirmations := flag.Uint64("confirmations", 0, "blocks to stay behind head, so events of short reorgs are not delivered")
+	jsonl := flag.Bool("jsonl", false, "print events as JSON lines instead of text, and status messages to stderr")
+	file := flag.String("file", "", "also append events as JSON lines to this file, rotated by size")
+	fileMaxSize := flag.Int64("file-max-size", sink.DefaultMaxSize, "size in bytes at which -file is rotated")
+	fileKeep := flag.Int("file-keep", 5, "number of rotated files to keep")
+	webhook := flag.String("webhook", "", "also post events to this URL, signed with WEBHOOK_SECRET")
+	deadLetter := flag.String("dead-letter", "", "append events the webhook rejects to this file and continue (default: stop)")
+	alerts := flag.String("alerts", "", "JSON file of alert rules evaluated on transfers, alerts are printed with status messages")
+	alertWebhook := flag.String("alert-webhook", "", "also post alerts to this URL, signed with WEBHOOK_SECRET")
+	listen := flag.String("listen", "", "serve /metrics, /healthz and /readyz on this address, e.g. :9090")
+	maxLag := flag.Uint64("max-lag", 10, "blocks behind head, confirmations included, beyond which /readyz fails")
+	grace := flag.Duration("grace", 5*time.Minute, "time disconnected or lagging without progress before /healthz fails")
+	drainTimeout := flag.Duration("drain-timeout", watcher.DefaultDrainTimeout, "time to finish delivering events in flight on SIGTERM")
+	poll := flag.Bool("poll", false, "poll RPC_ENDPOINT over HTTP instead of subscribing over RPC_WS_ENDPOINT (default when RPC_WS_ENDPOINT is unset)")
+	pollInterval := flag.Duration("poll-interval", watcher.DefaultPollInterval, "time between polls with -poll")
+	flag.Parse()
+
+	status := os.Stdout
+	if *jsonl {
+		status = os.Stderr
+	}
+
+	tokens := mustParseAddresses("contract", *contract)
+	if *manifest != "" {
+		m, err := watcher.LoadManifest(*manifest)
+		handleError(err)
+
+		tokens = m.Tokens
+	}
+
+	// Set up the sinks before connecting, so bad flags fail fast
+	deliveries, closeSinks := mustOpenSinks(*jsonl, *file, *fileMaxSize, *fileKeep, *webhook, *deadLetter)
+	defer closeSinks()
+
+	var sinks []sink.Sink
+	if !*jsonl {
+		sinks = append(sinks, textSink{})
+	}
+
+	// Evaluate alert rules before delivering. Multi writes to every sink, so
+	// events raise their alerts even when the webhook fails to deliver them,
+	// and failed alerts do not hold back deliveries
+	if *alerts != "" {
+		engine := mustOpenAlerts(*alerts, *alertWebhook, status)
+		defer engine.Close()
+
+		sinks = append(sinks, engine)
+	}
+
+	out := sink.Multi(append(sinks, deliveries)...)
+
+	// Drain on SIGINT and SIGTERM
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
 	defer cancel()
 
-	// Connect to Ethereum client with RPC endpoint
-	client, err := ethclient.DialContext(ctx, os.Getenv("RPC_WS_ENDPOINT"))
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(collectors.NewGoCollector(), collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}))
+
+	// Subscribe to new heads over WebSocket, or poll over HTTP
+	endpoint, interval := os.Getenv("RPC_WS_ENDPOINT"), time.Duration(0)
+	if *poll || endpoint == "" {
+		endpoint, interval = os.Getenv("RPC_ENDPOINT"), *pollInterval
+	}
+
+	w := &watcher.Watcher{
+		Dial: func(ctx context.Context) (watcher.Client, error) {
+			// Connect to Ethereum client with RPC endpoint
+			client, err := watcher.Dial(ctx, endpoint)
+			if err != nil {
+				return nil, err
+			}
+
+			fmt.Fprintln(status, "Successfully connected to Ethereum client")
+
+			return client, nil
+		},
+		Tokens:        tokens,
+		Sink:          out,
+		Events:        strings.Split(*events, ","),
+		From:          mustParseAddresses("from", *from),
+		To:            mustParseAddresses("to", *to),
+		Involving:     mustParseAddresses("involving", *involving),
+		FromBlock:     *fromBlock,
+		Confirmations: *confirmations,
+		PollInterval:  interval,
+		DrainTimeout:  *drainTimeout,
+		Metrics:       watcher.NewMetrics(registry),
+		Logf: func(format string, args ...any) {
+			fmt.Fprintf(status, format+"\n", args...)
+		},
+	}
+
+	// Serve metrics and health checks in service mode
+	var server *http.Server
+	if *listen != "" {
+		health := &watcher.Health{Watcher: w, MaxLag: *maxLag, Grace: *grace}
+
+		mux := http.NewServeMux()
+		mux.Handle("/metrics", promhttp.HandlerFor(registry, promhttp.HandlerOpts{}))
+		mux.HandleFunc("/healthz", health.Healthz)
+		mux.HandleFunc("/readyz", health.Readyz)
+
+		server = &http.Server{Addr: *listen, Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+
+		go func() {
+			if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				handleError(err)
+			}
+		}()
+
+		fmt.Fprintf(status, "Serving metrics and health checks on %s\n", *listen)
+	}
+
+	// Pick up edits of the manifest without a restart
+	if *manifest != "" {
+		go w.WatchManifest(ctx, *manifest, *reloadInterval)
+	}
+
+	err := w.Run(ctx)
+
+	last := w.Status().Last
+	fmt.Fprintf(status, "Stopped after block %d, resume with -from-block %d\n", last, last+1)
+
+	if server != nil {
+		shutdownCtx, cancelShutdown := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancelShutdown()
+
+		server.Shutdown(shutdownCtx)
+	}
+
 	handleError(err)
+}
 
-	defer client.Close()
+// textSink prints events as readable lines.
+type textSink struct{}
 
-	fmt.Println("Successfully connected to Ethereum client")
+func (textSink) Write(ctx context.Context, event sink.Event) error {
+	token := event.Symbol
+	if token == "" {
+		token = event.Token.Hex()
+	}
 
-	// Change these addresses to match your contract!
-	contractAddress := common.HexToAddress("0x5FbDB2315678afecb367f032d93F642f64180aa3")
+	if event.Event == "Approval" {
+		fmt.Printf("Approval event received: token=%s owner=%s spender=%s value=%s\n", token, event.From.Hex(), event.To.Hex(), event.Value)
+		return nil
+	}
+
+	fmt.Printf("Transfer event received: token=%s from=%s to=%s value=%s\n", token, event.From.Hex(), event.To.Hex(), event.Value)
+	return nil
+}
 
-	// Create an instance of the contract, specifying its address
-	tokenInstance, err := token.NewToken(contractAddress, client)
+func (textSink) Close() error {
+	return nil
+}
+
+func mustOpenSinks(jsonl bool, file string, fileMaxSize int64, fileKeep int, webhook, deadLetter string) (sink.Sink, func()) {
+	var sinks []sink.Sink
+	var files []*os.File
+
+	if jsonl {
+		sinks = append(sinks, sink.NewJSONL(os.Stdout))
+	}
+
+	if file != "" {
+		fileSink, err := sink.NewFile(file)
+		handleError(err)
+
+		fileSink.MaxSize = fileMaxSize
+		fileSink.Keep = fileKeep
+
+		sinks = append(sinks, fileSink)
+	}
+
+	if webhook != "" {
+		secret := os.Getenv("WEBHOOK_SECRET")
+		if secret == "" {
+			handleError(errors.New("WEBHOOK_SECRET is required to sign webhooks"))
+		}
+
+		webhookSink := sink.NewWebhook(webhook, []byte(secret))
+
+		if deadLetter != "" {
+			f, err := os.OpenFile(deadLetter, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+			handleError(err)
+
+			webhookSink.DeadLetter = f
+			files = append(files, f)
+		}
+
+		sinks = append(sinks, webhookSink)
+	}
+
+	out := sink.Multi(sinks...)
+
+	return out, func() {
+		out.Close()
+
+		for _, f := range files {
+			f.Close()
+		}
+	}
+}
+
+// alertText prints alerts as readable lines.
+type alertText struct {
+	w io.Writer
+}
+
+func (s alertText) Write(ctx context.Context, event sink.Event) error {
+	fmt.Fprintf(s.w, "ALERT %s: %s\n", event.Alert.Rule, event.Alert.Message)
+	return nil
+}
+
+func (alertText) Close() error {
+	return nil
+}
+
+func mustOpenAlerts(rules, webhook string, status io.Writer) *alert.Engine {
+	config, err := alert.LoadConfig(rules)
 	handleError(err)
 
-	transferChan := make(chan *token.TokenTransfer)
+	alerts := []sink.Sink{alertText{w: status}}
 
-	// Subscribe to Transfer events
-	sub, err := tokenInstance.WatchTransfer(&bind.WatchOpts{}, transferChan, nil, nil)
+	if webhook != "" {
+		secret := os.Getenv("WEBHOOK_SECRET")
+		if secret == "" {
+			handleError(errors.New("WEBHOOK_SECRET is required to sign webhooks"))
+		}
+
+		alerts = append(alerts, sink.NewWebhook(webhook, []byte(secret)))
+	}
+
+	engine, err := alert.New(config, sink.Multi(alerts...))
 	handleError(err)
 
-	defer sub.Unsubscribe()
+	return engine
+}
 
-	fmt.Println("Successfully subscribed to Transfer events")
+func mustParseAddresses(name, list string) []common.Address {
+	if list == "" {
+		return nil
+	}
 
-	for {
-		select {
-		case err := <-sub.Err():
-			handleError(err)
-		case transfer := <-transferChan:
-			fmt.Printf("Transfer event received: from=%s to=%s value=%d\n", transfer.From.Hex(), transfer.To.Hex(), transfer.Value)
+	var addresses []common.Address
+	for _, s := range strings.Split(list, ",") {
+		address, err := abiargs.ParseAddress(strings.TrimSpace(s))
+		if err != nil {
+			handleError(fmt.Errorf("-%s: %w", name, err))
 		}
+
+		addresses = append(addresses, address)
 	}
+
+	return addresses
 }
 
 func handleError(err error) {
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"go-ethereum-example/pkg/tokengen"
+	"path/filepath"
+)
+
+func main() {
+	spec := flag.String("spec", "", "token spec (JSON)")
+	root := flag.String("root", ".", "repository root holding contracts/, build/, gen/ and node_modules/")
+	dryRun := flag.Bool("dry-run", false, "print the rendered Solidity without compiling")
+	flag.Parse()
+
+	if *spec == "" {
+		handleError(fmt.Errorf("-spec is required"))
+	}
+
+	// Load and validate the token spec
+	s, err := tokengen.LoadSpec(*spec)
+	handleError(err)
+
+	specPath, err := filepath.Rel(*root, *spec)
+	if err != nil {
+		specPath = *spec
+	}
+	specPath = filepath.ToSlash(specPath)
+
+	if *dryRun {
+		source, err := tokengen.Render(s, specPath)
+		handleError(err)
+
+		fmt.Print(string(source))
+		return
+	}
+
+	// Render the token, then compile and bind it with the pinned settings
+	files, err := tokengen.Generate(context.Background(), *root, s, specPath)
+	handleError(err)
+
+	fmt.Printf("Generated contracts/%s.sol\n", s.Contract)
+	fmt.Printf("Generated gen/%s (type %s)\n", s.BindingFile(), s.BindingType())
+	fmt.Printf("Updated %d artifacts and bindings\n", len(files))
+}
+
+func handleError(err error) {
+	if err != nil {
+		panic(err)
+	}
+}
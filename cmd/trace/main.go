@@ -0,0 +1,72 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"go-ethereum-example/pkg/decoder"
+	"go-ethereum-example/pkg/trace"
+	"os"
+
+	_ "github.com/joho/godotenv/autoload"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+func main() {
+	build := flag.String("build", "build", "directory of the .abi files to decode with, searched recursively")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: trace [flags] <transaction hash>\n\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if flag.NArg() != 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	rawHash, err := hexutil.Decode(flag.Arg(0))
+	if err != nil || len(rawHash) != common.HashLength {
+		handleError(fmt.Errorf("invalid transaction hash %q", flag.Arg(0)))
+	}
+
+	hash := common.BytesToHash(rawHash)
+
+	// Index every method, event and error of the build directory
+	registry, err := decoder.Load(*build)
+	handleError(err)
+
+	ctx := context.Background()
+
+	// Connect to Ethereum client with RPC endpoint
+	client, err := ethclient.DialContext(ctx, os.Getenv("RPC_ENDPOINT"))
+	handleError(err)
+
+	defer client.Close()
+
+	// Replay the transaction with the callTracer, which needs the debug
+	// namespace of the node
+	root, err := trace.Transaction(ctx, client.Client(), hash)
+	handleError(err)
+
+	fmt.Printf("Transaction %s\n", hash.Hex())
+	trace.Render(os.Stdout, registry, root)
+
+	if failed := root.Failed(); failed != nil {
+		to := "a new contract"
+		if failed.To != nil {
+			to = failed.To.Hex()
+		}
+
+		fmt.Printf("Failed in the call from %s to %s: %s\n", failed.From.Hex(), to, failed.Error)
+	}
+}
+
+func handleError(err error) {
+	if err != nil {
+		panic(err)
+	}
+}
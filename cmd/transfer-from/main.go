@@ -0,0 +1,114 @@
+package main
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"flag"
+	"fmt"
+	token "go-ethereum-example/gen"
+	"go-ethereum-example/pkg/revert"
+	"go-ethereum-example/pkg/units"
+	"os"
+
+	_ "github.com/joho/godotenv/autoload"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+// transfer-from moves tokens out of the owner's account using the allowance
+// granted to the PRIVATE_KEY account.
+func main() {
+	contract := flag.String("contract", "0x5FbDB2315678afecb367f032d93F642f64180aa3", "token contract address")
+	from := flag.String("from", "", "owner address to transfer from")
+	to := flag.String("to", "", "recipient address")
+	amount := flag.String("amount", "", "amount in token units")
+	flag.Parse()
+
+	if !common.IsHexAddress(*from) || !common.IsHexAddress(*to) {
+		handleError(fmt.Errorf("invalid from %q or to %q address", *from, *to))
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Connect to Ethereum client with RPC endpoint
+	client, err := ethclient.DialContext(ctx, os.Getenv("RPC_ENDPOINT"))
+	handleError(err)
+
+	defer client.Close()
+
+	fmt.Println("Successfully connected to Ethereum client")
+
+	// Create an instance of the contract, specifying its address
+	tokenInstance, err := token.NewToken(common.HexToAddress(*contract), client)
+	handleError(err)
+
+	decimals, err := tokenInstance.Decimals(&bind.CallOpts{Context: ctx})
+	handleError(err)
+
+	value, err := units.Parse(*amount, decimals)
+	handleError(err)
+
+	// Parse wallet private key of the spender
+	privateKey := mustParsePrivateKey()
+	spender := crypto.PubkeyToAddress(privateKey.PublicKey)
+
+	// Check the allowance before paying for a transaction that would revert
+	allowance, err := tokenInstance.Allowance(&bind.CallOpts{Context: ctx}, common.HexToAddress(*from), spender)
+	handleError(err)
+
+	if allowance.Cmp(value) < 0 {
+		handleError(fmt.Errorf("allowance of %s for %s is %s, need %s", spender.Hex(), *from, units.Format(allowance, decimals), *amount))
+	}
+
+	chainID, err := client.ChainID(ctx)
+	handleError(err)
+
+	// Create an transactor with the private key and chain ID
+	signer, err := bind.NewKeyedTransactorWithChainID(privateKey, chainID)
+	handleError(err)
+
+	signer.Context = ctx
+
+	// Call transferFrom method (state-changing)
+	tx, err := tokenInstance.TransferFrom(signer, common.HexToAddress(*from), common.HexToAddress(*to), value)
+	handleError(revert.Decode(err))
+
+	fmt.Printf("Transaction hash: %s\n", tx.Hash().Hex())
+
+	// Wait for the transaction to be mined
+	receipt, err := bind.WaitMined(ctx, client, tx)
+	handleError(err)
+
+	fmt.Printf("Transaction receipt status %d\n", receipt.Status)
+
+	handleError(revert.Receipt(ctx, client, tx, receipt))
+
+	// Extract the transfer event from the receipt
+	for _, log := range receipt.Logs {
+		transferred, err := tokenInstance.ParseTransfer(*log)
+		if err == nil {
+			fmt.Printf("Transferred %s tokens from %s to %s\n", units.Format(transferred.Value, decimals), transferred.From.Hex(), transferred.To.Hex())
+			break
+		}
+	}
+}
+
+func mustParsePrivateKey() *ecdsa.PrivateKey {
+	rawPrivateKey := os.Getenv("PRIVATE_KEY")
+
+	// Parse the private key
+	privateKey, err := crypto.HexToECDSA(rawPrivateKey)
+	handleError(err)
+
+	return privateKey
+}
+
+func handleError(err error) {
+	if err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,136 @@
+// SPDX-License-Identifier: MIT
+pragma solidity ^0.8.20;
+
+// Test fixtures for pkg/erc20: tokens that deviate from ERC-20 the way some
+// deployed tokens do. They only exist to be deployed on test chains.
+
+/// @dev Balance bookkeeping shared by the fixtures. The internal functions
+/// return false instead of reverting, each fixture decides what to do.
+abstract contract FixtureToken {
+    mapping(address => uint256) public balanceOf;
+    mapping(address => mapping(address => uint256)) public allowance;
+    uint256 public totalSupply;
+
+    event Transfer(address indexed from, address indexed to, uint256 value);
+    event Approval(address indexed owner, address indexed spender, uint256 value);
+
+    constructor(uint256 supply) {
+        balanceOf[msg.sender] = supply;
+        totalSupply = supply;
+        emit Transfer(address(0), msg.sender, supply);
+    }
+
+    function _transfer(address from, address to, uint256 value) internal returns (bool) {
+        if (balanceOf[from] < value) {
+            return false;
+        }
+        balanceOf[from] -= value;
+        balanceOf[to] += value;
+        emit Transfer(from, to, value);
+        return true;
+    }
+
+    function _transferFrom(address from, address to, uint256 value) internal returns (bool) {
+        if (allowance[from][msg.sender] < value || balanceOf[from] < value) {
+            return false;
+        }
+        allowance[from][msg.sender] -= value;
+        return _transfer(from, to, value);
+    }
+
+    function _approve(address spender, uint256 value) internal {
+        allowance[msg.sender][spender] = value;
+        emit Approval(msg.sender, spender, value);
+    }
+}
+
+/// @notice Returns name and symbol as bytes32 and decimals as uint256, like MKR.
+contract Bytes32MetadataToken is FixtureToken {
+    bytes32 public constant name = "Maker";
+    bytes32 public constant symbol = "MKR";
+    uint256 public constant decimals = 18;
+
+    constructor(uint256 supply) FixtureToken(supply) {}
+
+    function transfer(address to, uint256 value) external returns (bool) {
+        require(_transfer(msg.sender, to, value), "ds-token-insufficient-balance");
+        return true;
+    }
+
+    function transferFrom(address from, address to, uint256 value) external returns (bool) {
+        require(_transferFrom(from, to, value), "ds-token-insufficient-approval");
+        return true;
+    }
+
+    function approve(address spender, uint256 value) external returns (bool) {
+        _approve(spender, value);
+        return true;
+    }
+}
+
+/// @notice Returns nothing from transfer, transferFrom and approve and reverts
+/// on failure, like USDT. Changing a non-zero allowance to another non-zero
+/// value reverts, too.
+contract NoReturnToken is FixtureToken {
+    string public constant name = "Tether USD";
+    string public constant symbol = "USDT";
+    uint8 public constant decimals = 6;
+
+    constructor(uint256 supply) FixtureToken(supply) {}
+
+    function transfer(address to, uint256 value) external {
+        require(_transfer(msg.sender, to, value));
+    }
+
+    function transferFrom(address from, address to, uint256 value) external {
+        require(_transferFrom(from, to, value));
+    }
+
+    function approve(address spender, uint256 value) external {
+        require(value == 0 || allowance[msg.sender][spender] == 0);
+        _approve(spender, value);
+    }
+}
+
+/// @notice Returns false instead of reverting when a transfer fails, like some
+/// early tokens.
+contract FalseReturnToken is FixtureToken {
+    string public constant name = "False Return Token";
+    string public constant symbol = "FRT";
+    uint8 public constant decimals = 18;
+
+    constructor(uint256 supply) FixtureToken(supply) {}
+
+    function transfer(address to, uint256 value) external returns (bool) {
+        return _transfer(msg.sender, to, value);
+    }
+
+    function transferFrom(address from, address to, uint256 value) external returns (bool) {
+        return _transferFrom(from, to, value);
+    }
+
+    function approve(address spender, uint256 value) external returns (bool) {
+        _approve(spender, value);
+        return true;
+    }
+}
+
+/// @notice Implements none of the optional name, symbol and decimals.
+contract NoMetadataToken is FixtureToken {
+    constructor(uint256 supply) FixtureToken(supply) {}
+
+    function transfer(address to, uint256 value) external returns (bool) {
+        require(_transfer(msg.sender, to, value), "insufficient balance");
+        return true;
+    }
+
+    function transferFrom(address from, address to, uint256 value) external returns (bool) {
+        require(_transferFrom(from, to, value), "insufficient allowance");
+        return true;
+    }
+
+    function approve(address spender, uint256 value) external returns (bool) {
+        _approve(spender, value);
+        return true;
+    }
+}
@@ -0,0 +1,450 @@
+// Code generated by cmd/compile - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package token
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = big.NewInt
+	_ = common.Big1
+)
+
+// ErrorsABI is the input ABI of the custom errors bound in this file.
+const ErrorsABI = "[{\"inputs\":[{\"internalType\":\"address\",\"name\":\"sender\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"balance\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"needed\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"tokenId\",\"type\":\"uint256\"}],\"name\":\"ERC1155InsufficientBalance\",\"type\":\"error\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"approver\",\"type\":\"address\"}],\"name\":\"ERC1155InvalidApprover\",\"type\":\"error\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"idsLength\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"valuesLength\",\"type\":\"uint256\"}],\"name\":\"ERC1155InvalidArrayLength\",\"type\":\"error\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"operator\",\"type\":\"address\"}],\"name\":\"ERC1155InvalidOperator\",\"type\":\"error\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"receiver\",\"type\":\"address\"}],\"name\":\"ERC1155InvalidReceiver\",\"type\":\"error\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"sender\",\"type\":\"address\"}],\"name\":\"ERC1155InvalidSender\",\"type\":\"error\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"operator\",\"type\":\"address\"},{\"internalType\":\"address\",\"name\":\"owner\",\"type\":\"address\"}],\"name\":\"ERC1155MissingApprovalForAll\",\"type\":\"error\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"spender\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"allowance\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"needed\",\"type\":\"uint256\"}],\"name\":\"ERC20InsufficientAllowance\",\"type\":\"error\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"sender\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"balance\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"needed\",\"type\":\"uint256\"}],\"name\":\"ERC20InsufficientBalance\",\"type\":\"error\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"approver\",\"type\":\"address\"}],\"name\":\"ERC20InvalidApprover\",\"type\":\"error\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"receiver\",\"type\":\"address\"}],\"name\":\"ERC20InvalidReceiver\",\"type\":\"error\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"sender\",\"type\":\"address\"}],\"name\":\"ERC20InvalidSender\",\"type\":\"error\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"spender\",\"type\":\"address\"}],\"name\":\"ERC20InvalidSpender\",\"type\":\"error\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"sender\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"tokenId\",\"type\":\"uint256\"},{\"internalType\":\"address\",\"name\":\"owner\",\"type\":\"address\"}],\"name\":\"ERC721IncorrectOwner\",\"type\":\"error\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"operator\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"tokenId\",\"type\":\"uint256\"}],\"name\":\"ERC721InsufficientApproval\",\"type\":\"error\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"approver\",\"type\":\"address\"}],\"name\":\"ERC721InvalidApprover\",\"type\":\"error\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"operator\",\"type\":\"address\"}],\"name\":\"ERC721InvalidOperator\",\"type\":\"error\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"owner\",\"type\":\"address\"}],\"name\":\"ERC721InvalidOwner\",\"type\":\"error\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"receiver\",\"type\":\"address\"}],\"name\":\"ERC721InvalidReceiver\",\"type\":\"error\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"sender\",\"type\":\"address\"}],\"name\":\"ERC721InvalidSender\",\"type\":\"error\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"tokenId\",\"type\":\"uint256\"}],\"name\":\"ERC721NonexistentToken\",\"type\":\"error\"},{\"inputs\":[],\"name\":\"AccessControlBadConfirmation\",\"type\":\"error\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"account\",\"type\":\"address\"},{\"internalType\":\"bytes32\",\"name\":\"neededRole\",\"type\":\"bytes32\"}],\"name\":\"AccessControlUnauthorizedAccount\",\"type\":\"error\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"increasedSupply\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"cap\",\"type\":\"uint256\"}],\"name\":\"ERC20ExceededCap\",\"type\":\"error\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"cap\",\"type\":\"uint256\"}],\"name\":\"ERC20InvalidCap\",\"type\":\"error\"},{\"inputs\":[],\"name\":\"EnforcedPause\",\"type\":\"error\"},{\"inputs\":[],\"name\":\"ExpectedPause\",\"type\":\"error\"},{\"inputs\":[],\"name\":\"ECDSAInvalidSignature\",\"type\":\"error\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"length\",\"type\":\"uint256\"}],\"name\":\"ECDSAInvalidSignatureLength\",\"type\":\"error\"},{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"s\",\"type\":\"bytes32\"}],\"name\":\"ECDSAInvalidSignatureS\",\"type\":\"error\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"deadline\",\"type\":\"uint256\"}],\"name\":\"ERC2612ExpiredSignature\",\"type\":\"error\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"signer\",\"type\":\"address\"},{\"internalType\":\"address\",\"name\":\"owner\",\"type\":\"address\"}],\"name\":\"ERC2612InvalidSigner\",\"type\":\"error\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"account\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"currentNonce\",\"type\":\"uint256\"}],\"name\":\"InvalidAccountNonce\",\"type\":\"error\"},{\"inputs\":[],\"name\":\"InvalidShortString\",\"type\":\"error\"},{\"inputs\":[{\"internalType\":\"string\",\"name\":\"str\",\"type\":\"string\"}],\"name\":\"StringTooLong\",\"type\":\"error\"}]"
+
+var errorsABI = func() abi.ABI {
+	parsed, err := abi.JSON(strings.NewReader(ErrorsABI))
+	if err != nil {
+		panic(err)
+	}
+	return parsed
+}()
+
+var customErrors = map[string]func() error{
+	"AccessControlBadConfirmation":     func() error { return new(AccessControlBadConfirmation) },
+	"AccessControlUnauthorizedAccount": func() error { return new(AccessControlUnauthorizedAccount) },
+	"ECDSAInvalidSignature":            func() error { return new(ECDSAInvalidSignature) },
+	"ECDSAInvalidSignatureLength":      func() error { return new(ECDSAInvalidSignatureLength) },
+	"ECDSAInvalidSignatureS":           func() error { return new(ECDSAInvalidSignatureS) },
+	"ERC1155InsufficientBalance":       func() error { return new(ERC1155InsufficientBalance) },
+	"ERC1155InvalidApprover":           func() error { return new(ERC1155InvalidApprover) },
+	"ERC1155InvalidArrayLength":        func() error { return new(ERC1155InvalidArrayLength) },
+	"ERC1155InvalidOperator":           func() error { return new(ERC1155InvalidOperator) },
+	"ERC1155InvalidReceiver":           func() error { return new(ERC1155InvalidReceiver) },
+	"ERC1155InvalidSender":             func() error { return new(ERC1155InvalidSender) },
+	"ERC1155MissingApprovalForAll":     func() error { return new(ERC1155MissingApprovalForAll) },
+	"ERC20ExceededCap":                 func() error { return new(ERC20ExceededCap) },
+	"ERC20InsufficientAllowance":       func() error { return new(ERC20InsufficientAllowance) },
+	"ERC20InsufficientBalance":         func() error { return new(ERC20InsufficientBalance) },
+	"ERC20InvalidApprover":             func() error { return new(ERC20InvalidApprover) },
+	"ERC20InvalidCap":                  func() error { return new(ERC20InvalidCap) },
+	"ERC20InvalidReceiver":             func() error { return new(ERC20InvalidReceiver) },
+	"ERC20InvalidSender":               func() error { return new(ERC20InvalidSender) },
+	"ERC20InvalidSpender":              func() error { return new(ERC20InvalidSpender) },
+	"ERC2612ExpiredSignature":          func() error { return new(ERC2612ExpiredSignature) },
+	"ERC2612InvalidSigner":             func() error { return new(ERC2612InvalidSigner) },
+	"ERC721IncorrectOwner":             func() error { return new(ERC721IncorrectOwner) },
+	"ERC721InsufficientApproval":       func() error { return new(ERC721InsufficientApproval) },
+	"ERC721InvalidApprover":            func() error { return new(ERC721InvalidApprover) },
+	"ERC721InvalidOperator":            func() error { return new(ERC721InvalidOperator) },
+	"ERC721InvalidOwner":               func() error { return new(ERC721InvalidOwner) },
+	"ERC721InvalidReceiver":            func() error { return new(ERC721InvalidReceiver) },
+	"ERC721InvalidSender":              func() error { return new(ERC721InvalidSender) },
+	"ERC721NonexistentToken":           func() error { return new(ERC721NonexistentToken) },
+	"EnforcedPause":                    func() error { return new(EnforcedPause) },
+	"ExpectedPause":                    func() error { return new(ExpectedPause) },
+	"InvalidAccountNonce":              func() error { return new(InvalidAccountNonce) },
+	"InvalidShortString":               func() error { return new(InvalidShortString) },
+	"StringTooLong":                    func() error { return new(StringTooLong) },
+}
+
+// UnpackError decodes revert data into the matching custom error, e.g.
+// *ERC20InsufficientBalance. It returns nil if the selector is unknown or the
+// arguments do not decode.
+func UnpackError(data []byte) error {
+	if len(data) < 4 {
+		return nil
+	}
+
+	e, err := errorsABI.ErrorByID([4]byte(data[:4]))
+	if err != nil {
+		return nil
+	}
+
+	values, err := e.Inputs.Unpack(data[4:])
+	if err != nil {
+		return nil
+	}
+
+	v := customErrors[e.Name]()
+	if err := e.Inputs.Copy(v, values); err != nil {
+		return nil
+	}
+
+	return v
+}
+
+// AccessControlBadConfirmation is the custom error AccessControlBadConfirmation() from the MyAdminToken ABI.
+type AccessControlBadConfirmation struct{}
+
+// Error implements the error interface.
+func (e *AccessControlBadConfirmation) Error() string {
+	return "AccessControlBadConfirmation()"
+}
+
+// AccessControlUnauthorizedAccount is the custom error AccessControlUnauthorizedAccount(address,bytes32) from the MyAdminToken ABI.
+type AccessControlUnauthorizedAccount struct {
+	Account    common.Address
+	NeededRole [32]uint8
+}
+
+// Error implements the error interface.
+func (e *AccessControlUnauthorizedAccount) Error() string {
+	return fmt.Sprintf("AccessControlUnauthorizedAccount(account: %v, neededRole: %#x)", e.Account, e.NeededRole)
+}
+
+// ECDSAInvalidSignature is the custom error ECDSAInvalidSignature() from the MyTokenPermit ABI.
+type ECDSAInvalidSignature struct{}
+
+// Error implements the error interface.
+func (e *ECDSAInvalidSignature) Error() string {
+	return "ECDSAInvalidSignature()"
+}
+
+// ECDSAInvalidSignatureLength is the custom error ECDSAInvalidSignatureLength(uint256) from the MyTokenPermit ABI.
+type ECDSAInvalidSignatureLength struct {
+	Length *big.Int
+}
+
+// Error implements the error interface.
+func (e *ECDSAInvalidSignatureLength) Error() string {
+	return fmt.Sprintf("ECDSAInvalidSignatureLength(length: %v)", e.Length)
+}
+
+// ECDSAInvalidSignatureS is the custom error ECDSAInvalidSignatureS(bytes32) from the MyTokenPermit ABI.
+type ECDSAInvalidSignatureS struct {
+	S [32]uint8
+}
+
+// Error implements the error interface.
+func (e *ECDSAInvalidSignatureS) Error() string {
+	return fmt.Sprintf("ECDSAInvalidSignatureS(s: %#x)", e.S)
+}
+
+// ERC1155InsufficientBalance is the custom error ERC1155InsufficientBalance(address,uint256,uint256,uint256) from the IERC1155Errors ABI.
+type ERC1155InsufficientBalance struct {
+	Sender  common.Address
+	Balance *big.Int
+	Needed  *big.Int
+	TokenId *big.Int
+}
+
+// Error implements the error interface.
+func (e *ERC1155InsufficientBalance) Error() string {
+	return fmt.Sprintf("ERC1155InsufficientBalance(sender: %v, balance: %v, needed: %v, tokenId: %v)", e.Sender, e.Balance, e.Needed, e.TokenId)
+}
+
+// ERC1155InvalidApprover is the custom error ERC1155InvalidApprover(address) from the IERC1155Errors ABI.
+type ERC1155InvalidApprover struct {
+	Approver common.Address
+}
+
+// Error implements the error interface.
+func (e *ERC1155InvalidApprover) Error() string {
+	return fmt.Sprintf("ERC1155InvalidApprover(approver: %v)", e.Approver)
+}
+
+// ERC1155InvalidArrayLength is the custom error ERC1155InvalidArrayLength(uint256,uint256) from the IERC1155Errors ABI.
+type ERC1155InvalidArrayLength struct {
+	IdsLength    *big.Int
+	ValuesLength *big.Int
+}
+
+// Error implements the error interface.
+func (e *ERC1155InvalidArrayLength) Error() string {
+	return fmt.Sprintf("ERC1155InvalidArrayLength(idsLength: %v, valuesLength: %v)", e.IdsLength, e.ValuesLength)
+}
+
+// ERC1155InvalidOperator is the custom error ERC1155InvalidOperator(address) from the IERC1155Errors ABI.
+type ERC1155InvalidOperator struct {
+	Operator common.Address
+}
+
+// Error implements the error interface.
+func (e *ERC1155InvalidOperator) Error() string {
+	return fmt.Sprintf("ERC1155InvalidOperator(operator: %v)", e.Operator)
+}
+
+// ERC1155InvalidReceiver is the custom error ERC1155InvalidReceiver(address) from the IERC1155Errors ABI.
+type ERC1155InvalidReceiver struct {
+	Receiver common.Address
+}
+
+// Error implements the error interface.
+func (e *ERC1155InvalidReceiver) Error() string {
+	return fmt.Sprintf("ERC1155InvalidReceiver(receiver: %v)", e.Receiver)
+}
+
+// ERC1155InvalidSender is the custom error ERC1155InvalidSender(address) from the IERC1155Errors ABI.
+type ERC1155InvalidSender struct {
+	Sender common.Address
+}
+
+// Error implements the error interface.
+func (e *ERC1155InvalidSender) Error() string {
+	return fmt.Sprintf("ERC1155InvalidSender(sender: %v)", e.Sender)
+}
+
+// ERC1155MissingApprovalForAll is the custom error ERC1155MissingApprovalForAll(address,address) from the IERC1155Errors ABI.
+type ERC1155MissingApprovalForAll struct {
+	Operator common.Address
+	Owner    common.Address
+}
+
+// Error implements the error interface.
+func (e *ERC1155MissingApprovalForAll) Error() string {
+	return fmt.Sprintf("ERC1155MissingApprovalForAll(operator: %v, owner: %v)", e.Operator, e.Owner)
+}
+
+// ERC20ExceededCap is the custom error ERC20ExceededCap(uint256,uint256) from the MyAdminToken ABI.
+type ERC20ExceededCap struct {
+	IncreasedSupply *big.Int
+	Cap             *big.Int
+}
+
+// Error implements the error interface.
+func (e *ERC20ExceededCap) Error() string {
+	return fmt.Sprintf("ERC20ExceededCap(increasedSupply: %v, cap: %v)", e.IncreasedSupply, e.Cap)
+}
+
+// ERC20InsufficientAllowance is the custom error ERC20InsufficientAllowance(address,uint256,uint256) from the IERC20Errors ABI.
+type ERC20InsufficientAllowance struct {
+	Spender   common.Address
+	Allowance *big.Int
+	Needed    *big.Int
+}
+
+// Error implements the error interface.
+func (e *ERC20InsufficientAllowance) Error() string {
+	return fmt.Sprintf("ERC20InsufficientAllowance(spender: %v, allowance: %v, needed: %v)", e.Spender, e.Allowance, e.Needed)
+}
+
+// ERC20InsufficientBalance is the custom error ERC20InsufficientBalance(address,uint256,uint256) from the IERC20Errors ABI.
+type ERC20InsufficientBalance struct {
+	Sender  common.Address
+	Balance *big.Int
+	Needed  *big.Int
+}
+
+// Error implements the error interface.
+func (e *ERC20InsufficientBalance) Error() string {
+	return fmt.Sprintf("ERC20InsufficientBalance(sender: %v, balance: %v, needed: %v)", e.Sender, e.Balance, e.Needed)
+}
+
+// ERC20InvalidApprover is the custom error ERC20InvalidApprover(address) from the IERC20Errors ABI.
+type ERC20InvalidApprover struct {
+	Approver common.Address
+}
+
+// Error implements the error interface.
+func (e *ERC20InvalidApprover) Error() string {
+	return fmt.Sprintf("ERC20InvalidApprover(approver: %v)", e.Approver)
+}
+
+// ERC20InvalidCap is the custom error ERC20InvalidCap(uint256) from the MyAdminToken ABI.
+type ERC20InvalidCap struct {
+	Cap *big.Int
+}
+
+// Error implements the error interface.
+func (e *ERC20InvalidCap) Error() string {
+	return fmt.Sprintf("ERC20InvalidCap(cap: %v)", e.Cap)
+}
+
+// ERC20InvalidReceiver is the custom error ERC20InvalidReceiver(address) from the IERC20Errors ABI.
+type ERC20InvalidReceiver struct {
+	Receiver common.Address
+}
+
+// Error implements the error interface.
+func (e *ERC20InvalidReceiver) Error() string {
+	return fmt.Sprintf("ERC20InvalidReceiver(receiver: %v)", e.Receiver)
+}
+
+// ERC20InvalidSender is the custom error ERC20InvalidSender(address) from the IERC20Errors ABI.
+type ERC20InvalidSender struct {
+	Sender common.Address
+}
+
+// Error implements the error interface.
+func (e *ERC20InvalidSender) Error() string {
+	return fmt.Sprintf("ERC20InvalidSender(sender: %v)", e.Sender)
+}
+
+// ERC20InvalidSpender is the custom error ERC20InvalidSpender(address) from the IERC20Errors ABI.
+type ERC20InvalidSpender struct {
+	Spender common.Address
+}
+
+// Error implements the error interface.
+func (e *ERC20InvalidSpender) Error() string {
+	return fmt.Sprintf("ERC20InvalidSpender(spender: %v)", e.Spender)
+}
+
+// ERC2612ExpiredSignature is the custom error ERC2612ExpiredSignature(uint256) from the MyTokenPermit ABI.
+type ERC2612ExpiredSignature struct {
+	Deadline *big.Int
+}
+
+// Error implements the error interface.
+func (e *ERC2612ExpiredSignature) Error() string {
+	return fmt.Sprintf("ERC2612ExpiredSignature(deadline: %v)", e.Deadline)
+}
+
+// ERC2612InvalidSigner is the custom error ERC2612InvalidSigner(address,address) from the MyTokenPermit ABI.
+type ERC2612InvalidSigner struct {
+	Signer common.Address
+	Owner  common.Address
+}
+
+// Error implements the error interface.
+func (e *ERC2612InvalidSigner) Error() string {
+	return fmt.Sprintf("ERC2612InvalidSigner(signer: %v, owner: %v)", e.Signer, e.Owner)
+}
+
+// ERC721IncorrectOwner is the custom error ERC721IncorrectOwner(address,uint256,address) from the IERC721Errors ABI.
+type ERC721IncorrectOwner struct {
+	Sender  common.Address
+	TokenId *big.Int
+	Owner   common.Address
+}
+
+// Error implements the error interface.
+func (e *ERC721IncorrectOwner) Error() string {
+	return fmt.Sprintf("ERC721IncorrectOwner(sender: %v, tokenId: %v, owner: %v)", e.Sender, e.TokenId, e.Owner)
+}
+
+// ERC721InsufficientApproval is the custom error ERC721InsufficientApproval(address,uint256) from the IERC721Errors ABI.
+type ERC721InsufficientApproval struct {
+	Operator common.Address
+	TokenId  *big.Int
+}
+
+// Error implements the error interface.
+func (e *ERC721InsufficientApproval) Error() string {
+	return fmt.Sprintf("ERC721InsufficientApproval(operator: %v, tokenId: %v)", e.Operator, e.TokenId)
+}
+
+// ERC721InvalidApprover is the custom error ERC721InvalidApprover(address) from the IERC721Errors ABI.
+type ERC721InvalidApprover struct {
+	Approver common.Address
+}
+
+// Error implements the error interface.
+func (e *ERC721InvalidApprover) Error() string {
+	return fmt.Sprintf("ERC721InvalidApprover(approver: %v)", e.Approver)
+}
+
+// ERC721InvalidOperator is the custom error ERC721InvalidOperator(address) from the IERC721Errors ABI.
+type ERC721InvalidOperator struct {
+	Operator common.Address
+}
+
+// Error implements the error interface.
+func (e *ERC721InvalidOperator) Error() string {
+	return fmt.Sprintf("ERC721InvalidOperator(operator: %v)", e.Operator)
+}
+
+// ERC721InvalidOwner is the custom error ERC721InvalidOwner(address) from the IERC721Errors ABI.
+type ERC721InvalidOwner struct {
+	Owner common.Address
+}
+
+// Error implements the error interface.
+func (e *ERC721InvalidOwner) Error() string {
+	return fmt.Sprintf("ERC721InvalidOwner(owner: %v)", e.Owner)
+}
+
+// ERC721InvalidReceiver is the custom error ERC721InvalidReceiver(address) from the IERC721Errors ABI.
+type ERC721InvalidReceiver struct {
+	Receiver common.Address
+}
+
+// Error implements the error interface.
+func (e *ERC721InvalidReceiver) Error() string {
+	return fmt.Sprintf("ERC721InvalidReceiver(receiver: %v)", e.Receiver)
+}
+
+// ERC721InvalidSender is the custom error ERC721InvalidSender(address) from the IERC721Errors ABI.
+type ERC721InvalidSender struct {
+	Sender common.Address
+}
+
+// Error implements the error interface.
+func (e *ERC721InvalidSender) Error() string {
+	return fmt.Sprintf("ERC721InvalidSender(sender: %v)", e.Sender)
+}
+
+// ERC721NonexistentToken is the custom error ERC721NonexistentToken(uint256) from the IERC721Errors ABI.
+type ERC721NonexistentToken struct {
+	TokenId *big.Int
+}
+
+// Error implements the error interface.
+func (e *ERC721NonexistentToken) Error() string {
+	return fmt.Sprintf("ERC721NonexistentToken(tokenId: %v)", e.TokenId)
+}
+
+// EnforcedPause is the custom error EnforcedPause() from the MyAdminToken ABI.
+type EnforcedPause struct{}
+
+// Error implements the error interface.
+func (e *EnforcedPause) Error() string {
+	return "EnforcedPause()"
+}
+
+// ExpectedPause is the custom error ExpectedPause() from the MyAdminToken ABI.
+type ExpectedPause struct{}
+
+// Error implements the error interface.
+func (e *ExpectedPause) Error() string {
+	return "ExpectedPause()"
+}
+
+// InvalidAccountNonce is the custom error InvalidAccountNonce(address,uint256) from the MyTokenPermit ABI.
+type InvalidAccountNonce struct {
+	Account      common.Address
+	CurrentNonce *big.Int
+}
+
+// Error implements the error interface.
+func (e *InvalidAccountNonce) Error() string {
+	return fmt.Sprintf("InvalidAccountNonce(account: %v, currentNonce: %v)", e.Account, e.CurrentNonce)
+}
+
+// InvalidShortString is the custom error InvalidShortString() from the MyTokenPermit ABI.
+type InvalidShortString struct{}
+
+// Error implements the error interface.
+func (e *InvalidShortString) Error() string {
+	return "InvalidShortString()"
+}
+
+// StringTooLong is the custom error StringTooLong(string) from the MyTokenPermit ABI.
+type StringTooLong struct {
+	Str string
+}
+
+// Error implements the error interface.
+func (e *StringTooLong) Error() string {
+	return fmt.Sprintf("StringTooLong(str: %v)", e.Str)
+}
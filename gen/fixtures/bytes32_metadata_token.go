@@ -0,0 +1,760 @@
+// Code generated - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package fixtures
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = errors.New
+	_ = big.NewInt
+	_ = strings.NewReader
+	_ = ethereum.NotFound
+	_ = bind.Bind
+	_ = common.Big1
+	_ = types.BloomLookup
+	_ = event.NewSubscription
+	_ = abi.ConvertType
+)
+
+// Bytes32MetadataTokenMetaData contains all meta data concerning the Bytes32MetadataToken contract.
+var Bytes32MetadataTokenMetaData = &bind.MetaData{
+	ABI: "[{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"supply\",\"type\":\"uint256\"}],\"stateMutability\":\"nonpayable\",\"type\":\"constructor\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"owner\",\"type\":\"address\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"spender\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"value\",\"type\":\"uint256\"}],\"name\":\"Approval\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"from\",\"type\":\"address\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"to\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"value\",\"type\":\"uint256\"}],\"name\":\"Transfer\",\"type\":\"event\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"},{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"name\":\"allowance\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"spender\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"value\",\"type\":\"uint256\"}],\"name\":\"approve\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"name\":\"balanceOf\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"decimals\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"name\",\"outputs\":[{\"internalType\":\"bytes32\",\"name\":\"\",\"type\":\"bytes32\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"symbol\",\"outputs\":[{\"internalType\":\"bytes32\",\"name\":\"\",\"type\":\"bytes32\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"totalSupply\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"to\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"value\",\"type\":\"uint256\"}],\"name\":\"transfer\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"from\",\"type\":\"address\"},{\"internalType\":\"address\",\"name\":\"to\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"value\",\"type\":\"uint256\"}],\"name\":\"transferFrom\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"nonpayable\",\"type\":\"function\"}]",
+	Bin: "0x608060405234801561000f575f80fd5b506040516105e63803806105e683398101604081905261002e9161007f565b335f81815260208181526040808320859055600285905551848152849392917fddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef910160405180910390a35050610096565b5f6020828403121561008f575f80fd5b5051919050565b610543806100a35f395ff3fe608060405234801561000f575f80fd5b5060043610610090575f3560e01c8063313ce56711610063578063313ce567146100f557806370a08231146100fd57806395d89b411461011c578063a9059cbb14610129578063dd62ed3e1461013c575f80fd5b806306fdde0314610094578063095ea7b3146100b657806318160ddd146100d957806323b872dd146100e2575b5f80fd5b6100a36426b0b5b2b960d91b81565b6040519081526020015b60405180910390f35b6100c96100c4366004610428565b610166565b60405190151581526020016100ad565b6100a360025481565b6100c96100f0366004610450565b61017b565b6100a3601281565b6100a361010b366004610489565b5f6020819052908152604090205481565b6100a36226a5a960e91b81565b6100c9610137366004610428565b6101e3565b6100a361014a3660046104a2565b600160209081525f928352604080842090915290825290205481565b5f610171838361023b565b5060015b92915050565b5f610187848484610299565b6101d85760405162461bcd60e51b815260206004820152601e60248201527f64732d746f6b656e2d696e73756666696369656e742d617070726f76616c000060448201526064015b60405180910390fd5b5060015b9392505050565b5f6101ef338484610336565b6101715760405162461bcd60e51b815260206004820152601d60248201527f64732d746f6b656e2d696e73756666696369656e742d62616c616e636500000060448201526064016101cf565b335f8181526001602090815260408083206001600160a01b03871680855290835292819020859055518481529192917f8c5be1e5ebec7d5bd14f71427d1e84f3dd0314c0f7b2291e5b200ac8c7c3b925910160405180910390a35050565b6001600160a01b0383165f9081526001602090815260408083203384529091528120548211806102df57506001600160a01b0384165f9081526020819052604090205482115b156102eb57505f6101dc565b6001600160a01b0384165f9081526001602090815260408083203384529091528120805484929061031d9084906104e7565b9091555061032e9050848484610336565b949350505050565b6001600160a01b0383165f9081526020819052604081205482111561035c57505f6101dc565b6001600160a01b0384165f90815260208190526040812080548492906103839084906104e7565b90915550506001600160a01b0383165f90815260208190526040812080548492906103af9084906104fa565b92505081905550826001600160a01b0316846001600160a01b03167fddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef846040516103fb91815260200190565b60405180910390a35060019392505050565b80356001600160a01b0381168114610423575f80fd5b919050565b5f8060408385031215610439575f80fd5b6104428361040d565b946020939093013593505050565b5f805f60608486031215610462575f80fd5b61046b8461040d565b92506104796020850161040d565b9150604084013590509250925092565b5f60208284031215610499575f80fd5b6101dc8261040d565b5f80604083850312156104b3575f80fd5b6104bc8361040d565b91506104ca6020840161040d565b90509250929050565b634e487b7160e01b5f52601160045260245ffd5b81810381811115610175576101756104d3565b80820180821115610175576101756104d356fea26469706673582212206d9082f2f188dd1729d09e86a09c779e5280c1d58e37bcca91998768eb25b7c664736f6c63430008150033",
+}
+
+// Bytes32MetadataTokenABI is the input ABI used to generate the binding from.
+// Deprecated: Use Bytes32MetadataTokenMetaData.ABI instead.
+var Bytes32MetadataTokenABI = Bytes32MetadataTokenMetaData.ABI
+
+// Bytes32MetadataTokenBin is the compiled bytecode used for deploying new contracts.
+// Deprecated: Use Bytes32MetadataTokenMetaData.Bin instead.
+var Bytes32MetadataTokenBin = Bytes32MetadataTokenMetaData.Bin
+
+// DeployBytes32MetadataToken deploys a new Ethereum contract, binding an instance of Bytes32MetadataToken to it.
+func DeployBytes32MetadataToken(auth *bind.TransactOpts, backend bind.ContractBackend, supply *big.Int) (common.Address, *types.Transaction, *Bytes32MetadataToken, error) {
+	parsed, err := Bytes32MetadataTokenMetaData.GetAbi()
+	if err != nil {
+		return common.Address{}, nil, nil, err
+	}
+	if parsed == nil {
+		return common.Address{}, nil, nil, errors.New("GetABI returned nil")
+	}
+
+	address, tx, contract, err := bind.DeployContract(auth, *parsed, common.FromHex(Bytes32MetadataTokenBin), backend, supply)
+	if err != nil {
+		return common.Address{}, nil, nil, err
+	}
+	return address, tx, &Bytes32MetadataToken{Bytes32MetadataTokenCaller: Bytes32MetadataTokenCaller{contract: contract}, Bytes32MetadataTokenTransactor: Bytes32MetadataTokenTransactor{contract: contract}, Bytes32MetadataTokenFilterer: Bytes32MetadataTokenFilterer{contract: contract}}, nil
+}
+
+// Bytes32MetadataToken is an auto generated Go binding around an Ethereum contract.
+type Bytes32MetadataToken struct {
+	Bytes32MetadataTokenCaller     // Read-only binding to the contract
+	Bytes32MetadataTokenTransactor // Write-only binding to the contract
+	Bytes32MetadataTokenFilterer   // Log filterer for contract events
+}
+
+// Bytes32MetadataTokenCaller is an auto generated read-only Go binding around an Ethereum contract.
+type Bytes32MetadataTokenCaller struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// Bytes32MetadataTokenTransactor is an auto generated write-only Go binding around an Ethereum contract.
+type Bytes32MetadataTokenTransactor struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// Bytes32MetadataTokenFilterer is an auto generated log filtering Go binding around an Ethereum contract events.
+type Bytes32MetadataTokenFilterer struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// Bytes32MetadataTokenSession is an auto generated Go binding around an Ethereum contract,
+// with pre-set call and transact options.
+type Bytes32MetadataTokenSession struct {
+	Contract     *Bytes32MetadataToken // Generic contract binding to set the session for
+	CallOpts     bind.CallOpts         // Call options to use throughout this session
+	TransactOpts bind.TransactOpts     // Transaction auth options to use throughout this session
+}
+
+// Bytes32MetadataTokenCallerSession is an auto generated read-only Go binding around an Ethereum contract,
+// with pre-set call options.
+type Bytes32MetadataTokenCallerSession struct {
+	Contract *Bytes32MetadataTokenCaller // Generic contract caller binding to set the session for
+	CallOpts bind.CallOpts               // Call options to use throughout this session
+}
+
+// Bytes32MetadataTokenTransactorSession is an auto generated write-only Go binding around an Ethereum contract,
+// with pre-set transact options.
+type Bytes32MetadataTokenTransactorSession struct {
+	Contract     *Bytes32MetadataTokenTransactor // Generic contract transactor binding to set the session for
+	TransactOpts bind.TransactOpts               // Transaction auth options to use throughout this session
+}
+
+// Bytes32MetadataTokenRaw is an auto generated low-level Go binding around an Ethereum contract.
+type Bytes32MetadataTokenRaw struct {
+	Contract *Bytes32MetadataToken // Generic contract binding to access the raw methods on
+}
+
+// Bytes32MetadataTokenCallerRaw is an auto generated low-level read-only Go binding around an Ethereum contract.
+type Bytes32MetadataTokenCallerRaw struct {
+	Contract *Bytes32MetadataTokenCaller // Generic read-only contract binding to access the raw methods on
+}
+
+// Bytes32MetadataTokenTransactorRaw is an auto generated low-level write-only Go binding around an Ethereum contract.
+type Bytes32MetadataTokenTransactorRaw struct {
+	Contract *Bytes32MetadataTokenTransactor // Generic write-only contract binding to access the raw methods on
+}
+
+// NewBytes32MetadataToken creates a new instance of Bytes32MetadataToken, bound to a specific deployed contract.
+func NewBytes32MetadataToken(address common.Address, backend bind.ContractBackend) (*Bytes32MetadataToken, error) {
+	contract, err := bindBytes32MetadataToken(address, backend, backend, backend)
+	if err != nil {
+		return nil, err
+	}
+	return &Bytes32MetadataToken{Bytes32MetadataTokenCaller: Bytes32MetadataTokenCaller{contract: contract}, Bytes32MetadataTokenTransactor: Bytes32MetadataTokenTransactor{contract: contract}, Bytes32MetadataTokenFilterer: Bytes32MetadataTokenFilterer{contract: contract}}, nil
+}
+
+// NewBytes32MetadataTokenCaller creates a new read-only instance of Bytes32MetadataToken, bound to a specific deployed contract.
+func NewBytes32MetadataTokenCaller(address common.Address, caller bind.ContractCaller) (*Bytes32MetadataTokenCaller, error) {
+	contract, err := bindBytes32MetadataToken(address, caller, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &Bytes32MetadataTokenCaller{contract: contract}, nil
+}
+
+// NewBytes32MetadataTokenTransactor creates a new write-only instance of Bytes32MetadataToken, bound to a specific deployed contract.
+func NewBytes32MetadataTokenTransactor(address common.Address, transactor bind.ContractTransactor) (*Bytes32MetadataTokenTransactor, error) {
+	contract, err := bindBytes32MetadataToken(address, nil, transactor, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &Bytes32MetadataTokenTransactor{contract: contract}, nil
+}
+
+// NewBytes32MetadataTokenFilterer creates a new log filterer instance of Bytes32MetadataToken, bound to a specific deployed contract.
+func NewBytes32MetadataTokenFilterer(address common.Address, filterer bind.ContractFilterer) (*Bytes32MetadataTokenFilterer, error) {
+	contract, err := bindBytes32MetadataToken(address, nil, nil, filterer)
+	if err != nil {
+		return nil, err
+	}
+	return &Bytes32MetadataTokenFilterer{contract: contract}, nil
+}
+
+// bindBytes32MetadataToken binds a generic wrapper to an already deployed contract.
+func bindBytes32MetadataToken(address common.Address, caller bind.ContractCaller, transactor bind.ContractTransactor, filterer bind.ContractFilterer) (*bind.BoundContract, error) {
+	parsed, err := Bytes32MetadataTokenMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	return bind.NewBoundContract(address, *parsed, caller, transactor, filterer), nil
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_Bytes32MetadataToken *Bytes32MetadataTokenRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _Bytes32MetadataToken.Contract.Bytes32MetadataTokenCaller.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_Bytes32MetadataToken *Bytes32MetadataTokenRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _Bytes32MetadataToken.Contract.Bytes32MetadataTokenTransactor.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_Bytes32MetadataToken *Bytes32MetadataTokenRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _Bytes32MetadataToken.Contract.Bytes32MetadataTokenTransactor.contract.Transact(opts, method, params...)
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_Bytes32MetadataToken *Bytes32MetadataTokenCallerRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _Bytes32MetadataToken.Contract.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_Bytes32MetadataToken *Bytes32MetadataTokenTransactorRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _Bytes32MetadataToken.Contract.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_Bytes32MetadataToken *Bytes32MetadataTokenTransactorRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _Bytes32MetadataToken.Contract.contract.Transact(opts, method, params...)
+}
+
+// Allowance is a free data retrieval call binding the contract method 0xdd62ed3e.
+//
+// Solidity: function allowance(address , address ) view returns(uint256)
+func (_Bytes32MetadataToken *Bytes32MetadataTokenCaller) Allowance(opts *bind.CallOpts, arg0 common.Address, arg1 common.Address) (*big.Int, error) {
+	var out []interface{}
+	err := _Bytes32MetadataToken.contract.Call(opts, &out, "allowance", arg0, arg1)
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// Allowance is a free data retrieval call binding the contract method 0xdd62ed3e.
+//
+// Solidity: function allowance(address , address ) view returns(uint256)
+func (_Bytes32MetadataToken *Bytes32MetadataTokenSession) Allowance(arg0 common.Address, arg1 common.Address) (*big.Int, error) {
+	return _Bytes32MetadataToken.Contract.Allowance(&_Bytes32MetadataToken.CallOpts, arg0, arg1)
+}
+
+// Allowance is a free data retrieval call binding the contract method 0xdd62ed3e.
+//
+// Solidity: function allowance(address , address ) view returns(uint256)
+func (_Bytes32MetadataToken *Bytes32MetadataTokenCallerSession) Allowance(arg0 common.Address, arg1 common.Address) (*big.Int, error) {
+	return _Bytes32MetadataToken.Contract.Allowance(&_Bytes32MetadataToken.CallOpts, arg0, arg1)
+}
+
+// BalanceOf is a free data retrieval call binding the contract method 0x70a08231.
+//
+// Solidity: function balanceOf(address ) view returns(uint256)
+func (_Bytes32MetadataToken *Bytes32MetadataTokenCaller) BalanceOf(opts *bind.CallOpts, arg0 common.Address) (*big.Int, error) {
+	var out []interface{}
+	err := _Bytes32MetadataToken.contract.Call(opts, &out, "balanceOf", arg0)
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// BalanceOf is a free data retrieval call binding the contract method 0x70a08231.
+//
+// Solidity: function balanceOf(address ) view returns(uint256)
+func (_Bytes32MetadataToken *Bytes32MetadataTokenSession) BalanceOf(arg0 common.Address) (*big.Int, error) {
+	return _Bytes32MetadataToken.Contract.BalanceOf(&_Bytes32MetadataToken.CallOpts, arg0)
+}
+
+// BalanceOf is a free data retrieval call binding the contract method 0x70a08231.
+//
+// Solidity: function balanceOf(address ) view returns(uint256)
+func (_Bytes32MetadataToken *Bytes32MetadataTokenCallerSession) BalanceOf(arg0 common.Address) (*big.Int, error) {
+	return _Bytes32MetadataToken.Contract.BalanceOf(&_Bytes32MetadataToken.CallOpts, arg0)
+}
+
+// Decimals is a free data retrieval call binding the contract method 0x313ce567.
+//
+// Solidity: function decimals() view returns(uint256)
+func (_Bytes32MetadataToken *Bytes32MetadataTokenCaller) Decimals(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _Bytes32MetadataToken.contract.Call(opts, &out, "decimals")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// Decimals is a free data retrieval call binding the contract method 0x313ce567.
+//
+// Solidity: function decimals() view returns(uint256)
+func (_Bytes32MetadataToken *Bytes32MetadataTokenSession) Decimals() (*big.Int, error) {
+	return _Bytes32MetadataToken.Contract.Decimals(&_Bytes32MetadataToken.CallOpts)
+}
+
+// Decimals is a free data retrieval call binding the contract method 0x313ce567.
+//
+// Solidity: function decimals() view returns(uint256)
+func (_Bytes32MetadataToken *Bytes32MetadataTokenCallerSession) Decimals() (*big.Int, error) {
+	return _Bytes32MetadataToken.Contract.Decimals(&_Bytes32MetadataToken.CallOpts)
+}
+
+// Name is a free data retrieval call binding the contract method 0x06fdde03.
+//
+// Solidity: function name() view returns(bytes32)
+func (_Bytes32MetadataToken *Bytes32MetadataTokenCaller) Name(opts *bind.CallOpts) ([32]byte, error) {
+	var out []interface{}
+	err := _Bytes32MetadataToken.contract.Call(opts, &out, "name")
+
+	if err != nil {
+		return *new([32]byte), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new([32]byte)).(*[32]byte)
+
+	return out0, err
+
+}
+
+// Name is a free data retrieval call binding the contract method 0x06fdde03.
+//
+// Solidity: function name() view returns(bytes32)
+func (_Bytes32MetadataToken *Bytes32MetadataTokenSession) Name() ([32]byte, error) {
+	return _Bytes32MetadataToken.Contract.Name(&_Bytes32MetadataToken.CallOpts)
+}
+
+// Name is a free data retrieval call binding the contract method 0x06fdde03.
+//
+// Solidity: function name() view returns(bytes32)
+func (_Bytes32MetadataToken *Bytes32MetadataTokenCallerSession) Name() ([32]byte, error) {
+	return _Bytes32MetadataToken.Contract.Name(&_Bytes32MetadataToken.CallOpts)
+}
+
+// Symbol is a free data retrieval call binding the contract method 0x95d89b41.
+//
+// Solidity: function symbol() view returns(bytes32)
+func (_Bytes32MetadataToken *Bytes32MetadataTokenCaller) Symbol(opts *bind.CallOpts) ([32]byte, error) {
+	var out []interface{}
+	err := _Bytes32MetadataToken.contract.Call(opts, &out, "symbol")
+
+	if err != nil {
+		return *new([32]byte), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new([32]byte)).(*[32]byte)
+
+	return out0, err
+
+}
+
+// Symbol is a free data retrieval call binding the contract method 0x95d89b41.
+//
+// Solidity: function symbol() view returns(bytes32)
+func (_Bytes32MetadataToken *Bytes32MetadataTokenSession) Symbol() ([32]byte, error) {
+	return _Bytes32MetadataToken.Contract.Symbol(&_Bytes32MetadataToken.CallOpts)
+}
+
+// Symbol is a free data retrieval call binding the contract method 0x95d89b41.
+//
+// Solidity: function symbol() view returns(bytes32)
+func (_Bytes32MetadataToken *Bytes32MetadataTokenCallerSession) Symbol() ([32]byte, error) {
+	return _Bytes32MetadataToken.Contract.Symbol(&_Bytes32MetadataToken.CallOpts)
+}
+
+// TotalSupply is a free data retrieval call binding the contract method 0x18160ddd.
+//
+// Solidity: function totalSupply() view returns(uint256)
+func (_Bytes32MetadataToken *Bytes32MetadataTokenCaller) TotalSupply(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _Bytes32MetadataToken.contract.Call(opts, &out, "totalSupply")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// TotalSupply is a free data retrieval call binding the contract method 0x18160ddd.
+//
+// Solidity: function totalSupply() view returns(uint256)
+func (_Bytes32MetadataToken *Bytes32MetadataTokenSession) TotalSupply() (*big.Int, error) {
+	return _Bytes32MetadataToken.Contract.TotalSupply(&_Bytes32MetadataToken.CallOpts)
+}
+
+// TotalSupply is a free data retrieval call binding the contract method 0x18160ddd.
+//
+// Solidity: function totalSupply() view returns(uint256)
+func (_Bytes32MetadataToken *Bytes32MetadataTokenCallerSession) TotalSupply() (*big.Int, error) {
+	return _Bytes32MetadataToken.Contract.TotalSupply(&_Bytes32MetadataToken.CallOpts)
+}
+
+// Approve is a paid mutator transaction binding the contract method 0x095ea7b3.
+//
+// Solidity: function approve(address spender, uint256 value) returns(bool)
+func (_Bytes32MetadataToken *Bytes32MetadataTokenTransactor) Approve(opts *bind.TransactOpts, spender common.Address, value *big.Int) (*types.Transaction, error) {
+	return _Bytes32MetadataToken.contract.Transact(opts, "approve", spender, value)
+}
+
+// Approve is a paid mutator transaction binding the contract method 0x095ea7b3.
+//
+// Solidity: function approve(address spender, uint256 value) returns(bool)
+func (_Bytes32MetadataToken *Bytes32MetadataTokenSession) Approve(spender common.Address, value *big.Int) (*types.Transaction, error) {
+	return _Bytes32MetadataToken.Contract.Approve(&_Bytes32MetadataToken.TransactOpts, spender, value)
+}
+
+// Approve is a paid mutator transaction binding the contract method 0x095ea7b3.
+//
+// Solidity: function approve(address spender, uint256 value) returns(bool)
+func (_Bytes32MetadataToken *Bytes32MetadataTokenTransactorSession) Approve(spender common.Address, value *big.Int) (*types.Transaction, error) {
+	return _Bytes32MetadataToken.Contract.Approve(&_Bytes32MetadataToken.TransactOpts, spender, value)
+}
+
+// Transfer is a paid mutator transaction binding the contract method 0xa9059cbb.
+//
+// Solidity: function transfer(address to, uint256 value) returns(bool)
+func (_Bytes32MetadataToken *Bytes32MetadataTokenTransactor) Transfer(opts *bind.TransactOpts, to common.Address, value *big.Int) (*types.Transaction, error) {
+	return _Bytes32MetadataToken.contract.Transact(opts, "transfer", to, value)
+}
+
+// Transfer is a paid mutator transaction binding the contract method 0xa9059cbb.
+//
+// Solidity: function transfer(address to, uint256 value) returns(bool)
+func (_Bytes32MetadataToken *Bytes32MetadataTokenSession) Transfer(to common.Address, value *big.Int) (*types.Transaction, error) {
+	return _Bytes32MetadataToken.Contract.Transfer(&_Bytes32MetadataToken.TransactOpts, to, value)
+}
+
+// Transfer is a paid mutator transaction binding the contract method 0xa9059cbb.
+//
+// Solidity: function transfer(address to, uint256 value) returns(bool)
+func (_Bytes32MetadataToken *Bytes32MetadataTokenTransactorSession) Transfer(to common.Address, value *big.Int) (*types.Transaction, error) {
+	return _Bytes32MetadataToken.Contract.Transfer(&_Bytes32MetadataToken.TransactOpts, to, value)
+}
+
+// TransferFrom is a paid mutator transaction binding the contract method 0x23b872dd.
+//
+// Solidity: function transferFrom(address from, address to, uint256 value) returns(bool)
+func (_Bytes32MetadataToken *Bytes32MetadataTokenTransactor) TransferFrom(opts *bind.TransactOpts, from common.Address, to common.Address, value *big.Int) (*types.Transaction, error) {
+	return _Bytes32MetadataToken.contract.Transact(opts, "transferFrom", from, to, value)
+}
+
+// TransferFrom is a paid mutator transaction binding the contract method 0x23b872dd.
+//
+// Solidity: function transferFrom(address from, address to, uint256 value) returns(bool)
+func (_Bytes32MetadataToken *Bytes32MetadataTokenSession) TransferFrom(from common.Address, to common.Address, value *big.Int) (*types.Transaction, error) {
+	return _Bytes32MetadataToken.Contract.TransferFrom(&_Bytes32MetadataToken.TransactOpts, from, to, value)
+}
+
+// TransferFrom is a paid mutator transaction binding the contract method 0x23b872dd.
+//
+// Solidity: function transferFrom(address from, address to, uint256 value) returns(bool)
+func (_Bytes32MetadataToken *Bytes32MetadataTokenTransactorSession) TransferFrom(from common.Address, to common.Address, value *big.Int) (*types.Transaction, error) {
+	return _Bytes32MetadataToken.Contract.TransferFrom(&_Bytes32MetadataToken.TransactOpts, from, to, value)
+}
+
+// Bytes32MetadataTokenApprovalIterator is returned from FilterApproval and is used to iterate over the raw logs and unpacked data for Approval events raised by the Bytes32MetadataToken contract.
+type Bytes32MetadataTokenApprovalIterator struct {
+	Event *Bytes32MetadataTokenApproval // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *Bytes32MetadataTokenApprovalIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(Bytes32MetadataTokenApproval)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(Bytes32MetadataTokenApproval)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *Bytes32MetadataTokenApprovalIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *Bytes32MetadataTokenApprovalIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// Bytes32MetadataTokenApproval represents a Approval event raised by the Bytes32MetadataToken contract.
+type Bytes32MetadataTokenApproval struct {
+	Owner   common.Address
+	Spender common.Address
+	Value   *big.Int
+	Raw     types.Log // Blockchain specific contextual infos
+}
+
+// FilterApproval is a free log retrieval operation binding the contract event 0x8c5be1e5ebec7d5bd14f71427d1e84f3dd0314c0f7b2291e5b200ac8c7c3b925.
+//
+// Solidity: event Approval(address indexed owner, address indexed spender, uint256 value)
+func (_Bytes32MetadataToken *Bytes32MetadataTokenFilterer) FilterApproval(opts *bind.FilterOpts, owner []common.Address, spender []common.Address) (*Bytes32MetadataTokenApprovalIterator, error) {
+
+	var ownerRule []interface{}
+	for _, ownerItem := range owner {
+		ownerRule = append(ownerRule, ownerItem)
+	}
+	var spenderRule []interface{}
+	for _, spenderItem := range spender {
+		spenderRule = append(spenderRule, spenderItem)
+	}
+
+	logs, sub, err := _Bytes32MetadataToken.contract.FilterLogs(opts, "Approval", ownerRule, spenderRule)
+	if err != nil {
+		return nil, err
+	}
+	return &Bytes32MetadataTokenApprovalIterator{contract: _Bytes32MetadataToken.contract, event: "Approval", logs: logs, sub: sub}, nil
+}
+
+// WatchApproval is a free log subscription operation binding the contract event 0x8c5be1e5ebec7d5bd14f71427d1e84f3dd0314c0f7b2291e5b200ac8c7c3b925.
+//
+// Solidity: event Approval(address indexed owner, address indexed spender, uint256 value)
+func (_Bytes32MetadataToken *Bytes32MetadataTokenFilterer) WatchApproval(opts *bind.WatchOpts, sink chan<- *Bytes32MetadataTokenApproval, owner []common.Address, spender []common.Address) (event.Subscription, error) {
+
+	var ownerRule []interface{}
+	for _, ownerItem := range owner {
+		ownerRule = append(ownerRule, ownerItem)
+	}
+	var spenderRule []interface{}
+	for _, spenderItem := range spender {
+		spenderRule = append(spenderRule, spenderItem)
+	}
+
+	logs, sub, err := _Bytes32MetadataToken.contract.WatchLogs(opts, "Approval", ownerRule, spenderRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(Bytes32MetadataTokenApproval)
+				if err := _Bytes32MetadataToken.contract.UnpackLog(event, "Approval", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseApproval is a log parse operation binding the contract event 0x8c5be1e5ebec7d5bd14f71427d1e84f3dd0314c0f7b2291e5b200ac8c7c3b925.
+//
+// Solidity: event Approval(address indexed owner, address indexed spender, uint256 value)
+func (_Bytes32MetadataToken *Bytes32MetadataTokenFilterer) ParseApproval(log types.Log) (*Bytes32MetadataTokenApproval, error) {
+	event := new(Bytes32MetadataTokenApproval)
+	if err := _Bytes32MetadataToken.contract.UnpackLog(event, "Approval", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// Bytes32MetadataTokenTransferIterator is returned from FilterTransfer and is used to iterate over the raw logs and unpacked data for Transfer events raised by the Bytes32MetadataToken contract.
+type Bytes32MetadataTokenTransferIterator struct {
+	Event *Bytes32MetadataTokenTransfer // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *Bytes32MetadataTokenTransferIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(Bytes32MetadataTokenTransfer)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(Bytes32MetadataTokenTransfer)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *Bytes32MetadataTokenTransferIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *Bytes32MetadataTokenTransferIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// Bytes32MetadataTokenTransfer represents a Transfer event raised by the Bytes32MetadataToken contract.
+type Bytes32MetadataTokenTransfer struct {
+	From  common.Address
+	To    common.Address
+	Value *big.Int
+	Raw   types.Log // Blockchain specific contextual infos
+}
+
+// FilterTransfer is a free log retrieval operation binding the contract event 0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef.
+//
+// Solidity: event Transfer(address indexed from, address indexed to, uint256 value)
+func (_Bytes32MetadataToken *Bytes32MetadataTokenFilterer) FilterTransfer(opts *bind.FilterOpts, from []common.Address, to []common.Address) (*Bytes32MetadataTokenTransferIterator, error) {
+
+	var fromRule []interface{}
+	for _, fromItem := range from {
+		fromRule = append(fromRule, fromItem)
+	}
+	var toRule []interface{}
+	for _, toItem := range to {
+		toRule = append(toRule, toItem)
+	}
+
+	logs, sub, err := _Bytes32MetadataToken.contract.FilterLogs(opts, "Transfer", fromRule, toRule)
+	if err != nil {
+		return nil, err
+	}
+	return &Bytes32MetadataTokenTransferIterator{contract: _Bytes32MetadataToken.contract, event: "Transfer", logs: logs, sub: sub}, nil
+}
+
+// WatchTransfer is a free log subscription operation binding the contract event 0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef.
+//
+// Solidity: event Transfer(address indexed from, address indexed to, uint256 value)
+func (_Bytes32MetadataToken *Bytes32MetadataTokenFilterer) WatchTransfer(opts *bind.WatchOpts, sink chan<- *Bytes32MetadataTokenTransfer, from []common.Address, to []common.Address) (event.Subscription, error) {
+
+	var fromRule []interface{}
+	for _, fromItem := range from {
+		fromRule = append(fromRule, fromItem)
+	}
+	var toRule []interface{}
+	for _, toItem := range to {
+		toRule = append(toRule, toItem)
+	}
+
+	logs, sub, err := _Bytes32MetadataToken.contract.WatchLogs(opts, "Transfer", fromRule, toRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(Bytes32MetadataTokenTransfer)
+				if err := _Bytes32MetadataToken.contract.UnpackLog(event, "Transfer", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseTransfer is a log parse operation binding the contract event 0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef.
+//
+// Solidity: event Transfer(address indexed from, address indexed to, uint256 value)
+func (_Bytes32MetadataToken *Bytes32MetadataTokenFilterer) ParseTransfer(log types.Log) (*Bytes32MetadataTokenTransfer, error) {
+	event := new(Bytes32MetadataTokenTransfer)
+	if err := _Bytes32MetadataToken.contract.UnpackLog(event, "Transfer", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
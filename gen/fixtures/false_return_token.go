@@ -0,0 +1,760 @@
+// Code generated - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package fixtures
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = errors.New
+	_ = big.NewInt
+	_ = strings.NewReader
+	_ = ethereum.NotFound
+	_ = bind.Bind
+	_ = common.Big1
+	_ = types.BloomLookup
+	_ = event.NewSubscription
+	_ = abi.ConvertType
+)
+
+// FalseReturnTokenMetaData contains all meta data concerning the FalseReturnToken contract.
+var FalseReturnTokenMetaData = &bind.MetaData{
+	ABI: "[{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"supply\",\"type\":\"uint256\"}],\"stateMutability\":\"nonpayable\",\"type\":\"constructor\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"owner\",\"type\":\"address\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"spender\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"value\",\"type\":\"uint256\"}],\"name\":\"Approval\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"from\",\"type\":\"address\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"to\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"value\",\"type\":\"uint256\"}],\"name\":\"Transfer\",\"type\":\"event\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"},{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"name\":\"allowance\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"spender\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"value\",\"type\":\"uint256\"}],\"name\":\"approve\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"name\":\"balanceOf\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"decimals\",\"outputs\":[{\"internalType\":\"uint8\",\"name\":\"\",\"type\":\"uint8\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"name\",\"outputs\":[{\"internalType\":\"string\",\"name\":\"\",\"type\":\"string\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"symbol\",\"outputs\":[{\"internalType\":\"string\",\"name\":\"\",\"type\":\"string\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"totalSupply\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"to\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"value\",\"type\":\"uint256\"}],\"name\":\"transfer\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"from\",\"type\":\"address\"},{\"internalType\":\"address\",\"name\":\"to\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"value\",\"type\":\"uint256\"}],\"name\":\"transferFrom\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"nonpayable\",\"type\":\"function\"}]",
+	Bin: "0x608060405234801561000f575f80fd5b506040516105e13803806105e183398101604081905261002e9161007f565b335f81815260208181526040808320859055600285905551848152849392917fddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef910160405180910390a35050610096565b5f6020828403121561008f575f80fd5b5051919050565b61053e806100a35f395ff3fe608060405234801561000f575f80fd5b5060043610610090575f3560e01c8063313ce56711610063578063313ce5671461012857806370a082311461014257806395d89b4114610161578063a9059cbb14610183578063dd62ed3e14610196575f80fd5b806306fdde0314610094578063095ea7b3146100db57806318160ddd146100fe57806323b872dd14610115575b5f80fd5b6100c5604051806040016040528060128152602001712330b639b2902932ba3ab937102a37b5b2b760711b81525081565b6040516100d291906103bd565b60405180910390f35b6100ee6100e9366004610423565b6101c0565b60405190151581526020016100d2565b61010760025481565b6040519081526020016100d2565b6100ee61012336600461044b565b6101d5565b610130601281565b60405160ff90911681526020016100d2565b610107610150366004610484565b5f6020819052908152604090205481565b6100c56040518060400160405280600381526020016211949560ea1b81525081565b6100ee610191366004610423565b6101eb565b6101076101a436600461049d565b600160209081525f928352604080842090915290825290205481565b5f6101cb83836101f7565b5060015b92915050565b5f6101e1848484610255565b90505b9392505050565b5f6101e43384846102e6565b335f8181526001602090815260408083206001600160a01b03871680855290835292819020859055518481529192917f8c5be1e5ebec7d5bd14f71427d1e84f3dd0314c0f7b2291e5b200ac8c7c3b925910160405180910390a35050565b6001600160a01b0383165f90815260016020908152604080832033845290915281205482118061029b57506001600160a01b0384165f9081526020819052604090205482115b156102a757505f6101e4565b6001600160a01b0384165f908152600160209081526040808320338452909152812080548492906102d99084906104e2565b909155506101e190508484845b6001600160a01b0383165f9081526020819052604081205482111561030c57505f6101e4565b6001600160a01b0384165f90815260208190526040812080548492906103339084906104e2565b90915550506001600160a01b0383165f908152602081905260408120805484929061035f9084906104f5565b92505081905550826001600160a01b0316846001600160a01b03167fddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef846040516103ab91815260200190565b60405180910390a35060019392505050565b5f6020808352835180828501525f5b818110156103e8578581018301518582016040015282016103cc565b505f604082860101526040601f19601f8301168501019250505092915050565b80356001600160a01b038116811461041e575f80fd5b919050565b5f8060408385031215610434575f80fd5b61043d83610408565b946020939093013593505050565b5f805f6060848603121561045d575f80fd5b61046684610408565b925061047460208501610408565b9150604084013590509250925092565b5f60208284031215610494575f80fd5b6101e482610408565b5f80604083850312156104ae575f80fd5b6104b783610408565b91506104c560208401610408565b90509250929050565b634e487b7160e01b5f52601160045260245ffd5b818103818111156101cf576101cf6104ce565b808201808211156101cf576101cf6104ce56fea2646970667358221220c58aaf08696d53586856d9b18eecca1e77a3118156e2a4edec49170d706e327364736f6c63430008150033",
+}
+
+// FalseReturnTokenABI is the input ABI used to generate the binding from.
+// Deprecated: Use FalseReturnTokenMetaData.ABI instead.
+var FalseReturnTokenABI = FalseReturnTokenMetaData.ABI
+
+// FalseReturnTokenBin is the compiled bytecode used for deploying new contracts.
+// Deprecated: Use FalseReturnTokenMetaData.Bin instead.
+var FalseReturnTokenBin = FalseReturnTokenMetaData.Bin
+
+// DeployFalseReturnToken deploys a new Ethereum contract, binding an instance of FalseReturnToken to it.
+func DeployFalseReturnToken(auth *bind.TransactOpts, backend bind.ContractBackend, supply *big.Int) (common.Address, *types.Transaction, *FalseReturnToken, error) {
+	parsed, err := FalseReturnTokenMetaData.GetAbi()
+	if err != nil {
+		return common.Address{}, nil, nil, err
+	}
+	if parsed == nil {
+		return common.Address{}, nil, nil, errors.New("GetABI returned nil")
+	}
+
+	address, tx, contract, err := bind.DeployContract(auth, *parsed, common.FromHex(FalseReturnTokenBin), backend, supply)
+	if err != nil {
+		return common.Address{}, nil, nil, err
+	}
+	return address, tx, &FalseReturnToken{FalseReturnTokenCaller: FalseReturnTokenCaller{contract: contract}, FalseReturnTokenTransactor: FalseReturnTokenTransactor{contract: contract}, FalseReturnTokenFilterer: FalseReturnTokenFilterer{contract: contract}}, nil
+}
+
+// FalseReturnToken is an auto generated Go binding around an Ethereum contract.
+type FalseReturnToken struct {
+	FalseReturnTokenCaller     // Read-only binding to the contract
+	FalseReturnTokenTransactor // Write-only binding to the contract
+	FalseReturnTokenFilterer   // Log filterer for contract events
+}
+
+// FalseReturnTokenCaller is an auto generated read-only Go binding around an Ethereum contract.
+type FalseReturnTokenCaller struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// FalseReturnTokenTransactor is an auto generated write-only Go binding around an Ethereum contract.
+type FalseReturnTokenTransactor struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// FalseReturnTokenFilterer is an auto generated log filtering Go binding around an Ethereum contract events.
+type FalseReturnTokenFilterer struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// FalseReturnTokenSession is an auto generated Go binding around an Ethereum contract,
+// with pre-set call and transact options.
+type FalseReturnTokenSession struct {
+	Contract     *FalseReturnToken // Generic contract binding to set the session for
+	CallOpts     bind.CallOpts     // Call options to use throughout this session
+	TransactOpts bind.TransactOpts // Transaction auth options to use throughout this session
+}
+
+// FalseReturnTokenCallerSession is an auto generated read-only Go binding around an Ethereum contract,
+// with pre-set call options.
+type FalseReturnTokenCallerSession struct {
+	Contract *FalseReturnTokenCaller // Generic contract caller binding to set the session for
+	CallOpts bind.CallOpts           // Call options to use throughout this session
+}
+
+// FalseReturnTokenTransactorSession is an auto generated write-only Go binding around an Ethereum contract,
+// with pre-set transact options.
+type FalseReturnTokenTransactorSession struct {
+	Contract     *FalseReturnTokenTransactor // Generic contract transactor binding to set the session for
+	TransactOpts bind.TransactOpts           // Transaction auth options to use throughout this session
+}
+
+// FalseReturnTokenRaw is an auto generated low-level Go binding around an Ethereum contract.
+type FalseReturnTokenRaw struct {
+	Contract *FalseReturnToken // Generic contract binding to access the raw methods on
+}
+
+// FalseReturnTokenCallerRaw is an auto generated low-level read-only Go binding around an Ethereum contract.
+type FalseReturnTokenCallerRaw struct {
+	Contract *FalseReturnTokenCaller // Generic read-only contract binding to access the raw methods on
+}
+
+// FalseReturnTokenTransactorRaw is an auto generated low-level write-only Go binding around an Ethereum contract.
+type FalseReturnTokenTransactorRaw struct {
+	Contract *FalseReturnTokenTransactor // Generic write-only contract binding to access the raw methods on
+}
+
+// NewFalseReturnToken creates a new instance of FalseReturnToken, bound to a specific deployed contract.
+func NewFalseReturnToken(address common.Address, backend bind.ContractBackend) (*FalseReturnToken, error) {
+	contract, err := bindFalseReturnToken(address, backend, backend, backend)
+	if err != nil {
+		return nil, err
+	}
+	return &FalseReturnToken{FalseReturnTokenCaller: FalseReturnTokenCaller{contract: contract}, FalseReturnTokenTransactor: FalseReturnTokenTransactor{contract: contract}, FalseReturnTokenFilterer: FalseReturnTokenFilterer{contract: contract}}, nil
+}
+
+// NewFalseReturnTokenCaller creates a new read-only instance of FalseReturnToken, bound to a specific deployed contract.
+func NewFalseReturnTokenCaller(address common.Address, caller bind.ContractCaller) (*FalseReturnTokenCaller, error) {
+	contract, err := bindFalseReturnToken(address, caller, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &FalseReturnTokenCaller{contract: contract}, nil
+}
+
+// NewFalseReturnTokenTransactor creates a new write-only instance of FalseReturnToken, bound to a specific deployed contract.
+func NewFalseReturnTokenTransactor(address common.Address, transactor bind.ContractTransactor) (*FalseReturnTokenTransactor, error) {
+	contract, err := bindFalseReturnToken(address, nil, transactor, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &FalseReturnTokenTransactor{contract: contract}, nil
+}
+
+// NewFalseReturnTokenFilterer creates a new log filterer instance of FalseReturnToken, bound to a specific deployed contract.
+func NewFalseReturnTokenFilterer(address common.Address, filterer bind.ContractFilterer) (*FalseReturnTokenFilterer, error) {
+	contract, err := bindFalseReturnToken(address, nil, nil, filterer)
+	if err != nil {
+		return nil, err
+	}
+	return &FalseReturnTokenFilterer{contract: contract}, nil
+}
+
+// bindFalseReturnToken binds a generic wrapper to an already deployed contract.
+func bindFalseReturnToken(address common.Address, caller bind.ContractCaller, transactor bind.ContractTransactor, filterer bind.ContractFilterer) (*bind.BoundContract, error) {
+	parsed, err := FalseReturnTokenMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	return bind.NewBoundContract(address, *parsed, caller, transactor, filterer), nil
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_FalseReturnToken *FalseReturnTokenRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _FalseReturnToken.Contract.FalseReturnTokenCaller.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_FalseReturnToken *FalseReturnTokenRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _FalseReturnToken.Contract.FalseReturnTokenTransactor.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_FalseReturnToken *FalseReturnTokenRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _FalseReturnToken.Contract.FalseReturnTokenTransactor.contract.Transact(opts, method, params...)
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_FalseReturnToken *FalseReturnTokenCallerRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _FalseReturnToken.Contract.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_FalseReturnToken *FalseReturnTokenTransactorRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _FalseReturnToken.Contract.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_FalseReturnToken *FalseReturnTokenTransactorRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _FalseReturnToken.Contract.contract.Transact(opts, method, params...)
+}
+
+// Allowance is a free data retrieval call binding the contract method 0xdd62ed3e.
+//
+// Solidity: function allowance(address , address ) view returns(uint256)
+func (_FalseReturnToken *FalseReturnTokenCaller) Allowance(opts *bind.CallOpts, arg0 common.Address, arg1 common.Address) (*big.Int, error) {
+	var out []interface{}
+	err := _FalseReturnToken.contract.Call(opts, &out, "allowance", arg0, arg1)
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// Allowance is a free data retrieval call binding the contract method 0xdd62ed3e.
+//
+// Solidity: function allowance(address , address ) view returns(uint256)
+func (_FalseReturnToken *FalseReturnTokenSession) Allowance(arg0 common.Address, arg1 common.Address) (*big.Int, error) {
+	return _FalseReturnToken.Contract.Allowance(&_FalseReturnToken.CallOpts, arg0, arg1)
+}
+
+// Allowance is a free data retrieval call binding the contract method 0xdd62ed3e.
+//
+// Solidity: function allowance(address , address ) view returns(uint256)
+func (_FalseReturnToken *FalseReturnTokenCallerSession) Allowance(arg0 common.Address, arg1 common.Address) (*big.Int, error) {
+	return _FalseReturnToken.Contract.Allowance(&_FalseReturnToken.CallOpts, arg0, arg1)
+}
+
+// BalanceOf is a free data retrieval call binding the contract method 0x70a08231.
+//
+// Solidity: function balanceOf(address ) view returns(uint256)
+func (_FalseReturnToken *FalseReturnTokenCaller) BalanceOf(opts *bind.CallOpts, arg0 common.Address) (*big.Int, error) {
+	var out []interface{}
+	err := _FalseReturnToken.contract.Call(opts, &out, "balanceOf", arg0)
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// BalanceOf is a free data retrieval call binding the contract method 0x70a08231.
+//
+// Solidity: function balanceOf(address ) view returns(uint256)
+func (_FalseReturnToken *FalseReturnTokenSession) BalanceOf(arg0 common.Address) (*big.Int, error) {
+	return _FalseReturnToken.Contract.BalanceOf(&_FalseReturnToken.CallOpts, arg0)
+}
+
+// BalanceOf is a free data retrieval call binding the contract method 0x70a08231.
+//
+// Solidity: function balanceOf(address ) view returns(uint256)
+func (_FalseReturnToken *FalseReturnTokenCallerSession) BalanceOf(arg0 common.Address) (*big.Int, error) {
+	return _FalseReturnToken.Contract.BalanceOf(&_FalseReturnToken.CallOpts, arg0)
+}
+
+// Decimals is a free data retrieval call binding the contract method 0x313ce567.
+//
+// Solidity: function decimals() view returns(uint8)
+func (_FalseReturnToken *FalseReturnTokenCaller) Decimals(opts *bind.CallOpts) (uint8, error) {
+	var out []interface{}
+	err := _FalseReturnToken.contract.Call(opts, &out, "decimals")
+
+	if err != nil {
+		return *new(uint8), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(uint8)).(*uint8)
+
+	return out0, err
+
+}
+
+// Decimals is a free data retrieval call binding the contract method 0x313ce567.
+//
+// Solidity: function decimals() view returns(uint8)
+func (_FalseReturnToken *FalseReturnTokenSession) Decimals() (uint8, error) {
+	return _FalseReturnToken.Contract.Decimals(&_FalseReturnToken.CallOpts)
+}
+
+// Decimals is a free data retrieval call binding the contract method 0x313ce567.
+//
+// Solidity: function decimals() view returns(uint8)
+func (_FalseReturnToken *FalseReturnTokenCallerSession) Decimals() (uint8, error) {
+	return _FalseReturnToken.Contract.Decimals(&_FalseReturnToken.CallOpts)
+}
+
+// Name is a free data retrieval call binding the contract method 0x06fdde03.
+//
+// Solidity: function name() view returns(string)
+func (_FalseReturnToken *FalseReturnTokenCaller) Name(opts *bind.CallOpts) (string, error) {
+	var out []interface{}
+	err := _FalseReturnToken.contract.Call(opts, &out, "name")
+
+	if err != nil {
+		return *new(string), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(string)).(*string)
+
+	return out0, err
+
+}
+
+// Name is a free data retrieval call binding the contract method 0x06fdde03.
+//
+// Solidity: function name() view returns(string)
+func (_FalseReturnToken *FalseReturnTokenSession) Name() (string, error) {
+	return _FalseReturnToken.Contract.Name(&_FalseReturnToken.CallOpts)
+}
+
+// Name is a free data retrieval call binding the contract method 0x06fdde03.
+//
+// Solidity: function name() view returns(string)
+func (_FalseReturnToken *FalseReturnTokenCallerSession) Name() (string, error) {
+	return _FalseReturnToken.Contract.Name(&_FalseReturnToken.CallOpts)
+}
+
+// Symbol is a free data retrieval call binding the contract method 0x95d89b41.
+//
+// Solidity: function symbol() view returns(string)
+func (_FalseReturnToken *FalseReturnTokenCaller) Symbol(opts *bind.CallOpts) (string, error) {
+	var out []interface{}
+	err := _FalseReturnToken.contract.Call(opts, &out, "symbol")
+
+	if err != nil {
+		return *new(string), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(string)).(*string)
+
+	return out0, err
+
+}
+
+// Symbol is a free data retrieval call binding the contract method 0x95d89b41.
+//
+// Solidity: function symbol() view returns(string)
+func (_FalseReturnToken *FalseReturnTokenSession) Symbol() (string, error) {
+	return _FalseReturnToken.Contract.Symbol(&_FalseReturnToken.CallOpts)
+}
+
+// Symbol is a free data retrieval call binding the contract method 0x95d89b41.
+//
+// Solidity: function symbol() view returns(string)
+func (_FalseReturnToken *FalseReturnTokenCallerSession) Symbol() (string, error) {
+	return _FalseReturnToken.Contract.Symbol(&_FalseReturnToken.CallOpts)
+}
+
+// TotalSupply is a free data retrieval call binding the contract method 0x18160ddd.
+//
+// Solidity: function totalSupply() view returns(uint256)
+func (_FalseReturnToken *FalseReturnTokenCaller) TotalSupply(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _FalseReturnToken.contract.Call(opts, &out, "totalSupply")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// TotalSupply is a free data retrieval call binding the contract method 0x18160ddd.
+//
+// Solidity: function totalSupply() view returns(uint256)
+func (_FalseReturnToken *FalseReturnTokenSession) TotalSupply() (*big.Int, error) {
+	return _FalseReturnToken.Contract.TotalSupply(&_FalseReturnToken.CallOpts)
+}
+
+// TotalSupply is a free data retrieval call binding the contract method 0x18160ddd.
+//
+// Solidity: function totalSupply() view returns(uint256)
+func (_FalseReturnToken *FalseReturnTokenCallerSession) TotalSupply() (*big.Int, error) {
+	return _FalseReturnToken.Contract.TotalSupply(&_FalseReturnToken.CallOpts)
+}
+
+// Approve is a paid mutator transaction binding the contract method 0x095ea7b3.
+//
+// Solidity: function approve(address spender, uint256 value) returns(bool)
+func (_FalseReturnToken *FalseReturnTokenTransactor) Approve(opts *bind.TransactOpts, spender common.Address, value *big.Int) (*types.Transaction, error) {
+	return _FalseReturnToken.contract.Transact(opts, "approve", spender, value)
+}
+
+// Approve is a paid mutator transaction binding the contract method 0x095ea7b3.
+//
+// Solidity: function approve(address spender, uint256 value) returns(bool)
+func (_FalseReturnToken *FalseReturnTokenSession) Approve(spender common.Address, value *big.Int) (*types.Transaction, error) {
+	return _FalseReturnToken.Contract.Approve(&_FalseReturnToken.TransactOpts, spender, value)
+}
+
+// Approve is a paid mutator transaction binding the contract method 0x095ea7b3.
+//
+// Solidity: function approve(address spender, uint256 value) returns(bool)
+func (_FalseReturnToken *FalseReturnTokenTransactorSession) Approve(spender common.Address, value *big.Int) (*types.Transaction, error) {
+	return _FalseReturnToken.Contract.Approve(&_FalseReturnToken.TransactOpts, spender, value)
+}
+
+// Transfer is a paid mutator transaction binding the contract method 0xa9059cbb.
+//
+// Solidity: function transfer(address to, uint256 value) returns(bool)
+func (_FalseReturnToken *FalseReturnTokenTransactor) Transfer(opts *bind.TransactOpts, to common.Address, value *big.Int) (*types.Transaction, error) {
+	return _FalseReturnToken.contract.Transact(opts, "transfer", to, value)
+}
+
+// Transfer is a paid mutator transaction binding the contract method 0xa9059cbb.
+//
+// Solidity: function transfer(address to, uint256 value) returns(bool)
+func (_FalseReturnToken *FalseReturnTokenSession) Transfer(to common.Address, value *big.Int) (*types.Transaction, error) {
+	return _FalseReturnToken.Contract.Transfer(&_FalseReturnToken.TransactOpts, to, value)
+}
+
+// Transfer is a paid mutator transaction binding the contract method 0xa9059cbb.
+//
+// Solidity: function transfer(address to, uint256 value) returns(bool)
+func (_FalseReturnToken *FalseReturnTokenTransactorSession) Transfer(to common.Address, value *big.Int) (*types.Transaction, error) {
+	return _FalseReturnToken.Contract.Transfer(&_FalseReturnToken.TransactOpts, to, value)
+}
+
+// TransferFrom is a paid mutator transaction binding the contract method 0x23b872dd.
+//
+// Solidity: function transferFrom(address from, address to, uint256 value) returns(bool)
+func (_FalseReturnToken *FalseReturnTokenTransactor) TransferFrom(opts *bind.TransactOpts, from common.Address, to common.Address, value *big.Int) (*types.Transaction, error) {
+	return _FalseReturnToken.contract.Transact(opts, "transferFrom", from, to, value)
+}
+
+// TransferFrom is a paid mutator transaction binding the contract method 0x23b872dd.
+//
+// Solidity: function transferFrom(address from, address to, uint256 value) returns(bool)
+func (_FalseReturnToken *FalseReturnTokenSession) TransferFrom(from common.Address, to common.Address, value *big.Int) (*types.Transaction, error) {
+	return _FalseReturnToken.Contract.TransferFrom(&_FalseReturnToken.TransactOpts, from, to, value)
+}
+
+// TransferFrom is a paid mutator transaction binding the contract method 0x23b872dd.
+//
+// Solidity: function transferFrom(address from, address to, uint256 value) returns(bool)
+func (_FalseReturnToken *FalseReturnTokenTransactorSession) TransferFrom(from common.Address, to common.Address, value *big.Int) (*types.Transaction, error) {
+	return _FalseReturnToken.Contract.TransferFrom(&_FalseReturnToken.TransactOpts, from, to, value)
+}
+
+// FalseReturnTokenApprovalIterator is returned from FilterApproval and is used to iterate over the raw logs and unpacked data for Approval events raised by the FalseReturnToken contract.
+type FalseReturnTokenApprovalIterator struct {
+	Event *FalseReturnTokenApproval // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *FalseReturnTokenApprovalIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(FalseReturnTokenApproval)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(FalseReturnTokenApproval)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *FalseReturnTokenApprovalIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *FalseReturnTokenApprovalIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// FalseReturnTokenApproval represents a Approval event raised by the FalseReturnToken contract.
+type FalseReturnTokenApproval struct {
+	Owner   common.Address
+	Spender common.Address
+	Value   *big.Int
+	Raw     types.Log // Blockchain specific contextual infos
+}
+
+// FilterApproval is a free log retrieval operation binding the contract event 0x8c5be1e5ebec7d5bd14f71427d1e84f3dd0314c0f7b2291e5b200ac8c7c3b925.
+//
+// Solidity: event Approval(address indexed owner, address indexed spender, uint256 value)
+func (_FalseReturnToken *FalseReturnTokenFilterer) FilterApproval(opts *bind.FilterOpts, owner []common.Address, spender []common.Address) (*FalseReturnTokenApprovalIterator, error) {
+
+	var ownerRule []interface{}
+	for _, ownerItem := range owner {
+		ownerRule = append(ownerRule, ownerItem)
+	}
+	var spenderRule []interface{}
+	for _, spenderItem := range spender {
+		spenderRule = append(spenderRule, spenderItem)
+	}
+
+	logs, sub, err := _FalseReturnToken.contract.FilterLogs(opts, "Approval", ownerRule, spenderRule)
+	if err != nil {
+		return nil, err
+	}
+	return &FalseReturnTokenApprovalIterator{contract: _FalseReturnToken.contract, event: "Approval", logs: logs, sub: sub}, nil
+}
+
+// WatchApproval is a free log subscription operation binding the contract event 0x8c5be1e5ebec7d5bd14f71427d1e84f3dd0314c0f7b2291e5b200ac8c7c3b925.
+//
+// Solidity: event Approval(address indexed owner, address indexed spender, uint256 value)
+func (_FalseReturnToken *FalseReturnTokenFilterer) WatchApproval(opts *bind.WatchOpts, sink chan<- *FalseReturnTokenApproval, owner []common.Address, spender []common.Address) (event.Subscription, error) {
+
+	var ownerRule []interface{}
+	for _, ownerItem := range owner {
+		ownerRule = append(ownerRule, ownerItem)
+	}
+	var spenderRule []interface{}
+	for _, spenderItem := range spender {
+		spenderRule = append(spenderRule, spenderItem)
+	}
+
+	logs, sub, err := _FalseReturnToken.contract.WatchLogs(opts, "Approval", ownerRule, spenderRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(FalseReturnTokenApproval)
+				if err := _FalseReturnToken.contract.UnpackLog(event, "Approval", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseApproval is a log parse operation binding the contract event 0x8c5be1e5ebec7d5bd14f71427d1e84f3dd0314c0f7b2291e5b200ac8c7c3b925.
+//
+// Solidity: event Approval(address indexed owner, address indexed spender, uint256 value)
+func (_FalseReturnToken *FalseReturnTokenFilterer) ParseApproval(log types.Log) (*FalseReturnTokenApproval, error) {
+	event := new(FalseReturnTokenApproval)
+	if err := _FalseReturnToken.contract.UnpackLog(event, "Approval", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// FalseReturnTokenTransferIterator is returned from FilterTransfer and is used to iterate over the raw logs and unpacked data for Transfer events raised by the FalseReturnToken contract.
+type FalseReturnTokenTransferIterator struct {
+	Event *FalseReturnTokenTransfer // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *FalseReturnTokenTransferIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(FalseReturnTokenTransfer)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(FalseReturnTokenTransfer)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *FalseReturnTokenTransferIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *FalseReturnTokenTransferIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// FalseReturnTokenTransfer represents a Transfer event raised by the FalseReturnToken contract.
+type FalseReturnTokenTransfer struct {
+	From  common.Address
+	To    common.Address
+	Value *big.Int
+	Raw   types.Log // Blockchain specific contextual infos
+}
+
+// FilterTransfer is a free log retrieval operation binding the contract event 0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef.
+//
+// Solidity: event Transfer(address indexed from, address indexed to, uint256 value)
+func (_FalseReturnToken *FalseReturnTokenFilterer) FilterTransfer(opts *bind.FilterOpts, from []common.Address, to []common.Address) (*FalseReturnTokenTransferIterator, error) {
+
+	var fromRule []interface{}
+	for _, fromItem := range from {
+		fromRule = append(fromRule, fromItem)
+	}
+	var toRule []interface{}
+	for _, toItem := range to {
+		toRule = append(toRule, toItem)
+	}
+
+	logs, sub, err := _FalseReturnToken.contract.FilterLogs(opts, "Transfer", fromRule, toRule)
+	if err != nil {
+		return nil, err
+	}
+	return &FalseReturnTokenTransferIterator{contract: _FalseReturnToken.contract, event: "Transfer", logs: logs, sub: sub}, nil
+}
+
+// WatchTransfer is a free log subscription operation binding the contract event 0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef.
+//
+// Solidity: event Transfer(address indexed from, address indexed to, uint256 value)
+func (_FalseReturnToken *FalseReturnTokenFilterer) WatchTransfer(opts *bind.WatchOpts, sink chan<- *FalseReturnTokenTransfer, from []common.Address, to []common.Address) (event.Subscription, error) {
+
+	var fromRule []interface{}
+	for _, fromItem := range from {
+		fromRule = append(fromRule, fromItem)
+	}
+	var toRule []interface{}
+	for _, toItem := range to {
+		toRule = append(toRule, toItem)
+	}
+
+	logs, sub, err := _FalseReturnToken.contract.WatchLogs(opts, "Transfer", fromRule, toRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(FalseReturnTokenTransfer)
+				if err := _FalseReturnToken.contract.UnpackLog(event, "Transfer", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseTransfer is a log parse operation binding the contract event 0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef.
+//
+// Solidity: event Transfer(address indexed from, address indexed to, uint256 value)
+func (_FalseReturnToken *FalseReturnTokenFilterer) ParseTransfer(log types.Log) (*FalseReturnTokenTransfer, error) {
+	event := new(FalseReturnTokenTransfer)
+	if err := _FalseReturnToken.contract.UnpackLog(event, "Transfer", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
@@ -0,0 +1,13 @@
+// Package fixtures holds the bindings of the test-only tokens in
+// contracts/fixtures/, which mimic the quirks of deployed ERC-20 tokens.
+//
+// They are compiled apart from the contracts of package token, with the
+// settings pinned in contracts/fixtures/compile.json, into build/fixtures/:
+//
+//	go generate ./gen/fixtures
+//
+// Run go run ./cmd/compile -config contracts/fixtures/compile.json -check to
+// verify that they are up to date.
+package fixtures
+
+//go:generate go run ../../cmd/compile -root ../.. -config contracts/fixtures/compile.json
@@ -0,0 +1,667 @@
+// Code generated - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package fixtures
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = errors.New
+	_ = big.NewInt
+	_ = strings.NewReader
+	_ = ethereum.NotFound
+	_ = bind.Bind
+	_ = common.Big1
+	_ = types.BloomLookup
+	_ = event.NewSubscription
+	_ = abi.ConvertType
+)
+
+// NoMetadataTokenMetaData contains all meta data concerning the NoMetadataToken contract.
+var NoMetadataTokenMetaData = &bind.MetaData{
+	ABI: "[{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"supply\",\"type\":\"uint256\"}],\"stateMutability\":\"nonpayable\",\"type\":\"constructor\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"owner\",\"type\":\"address\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"spender\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"value\",\"type\":\"uint256\"}],\"name\":\"Approval\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"from\",\"type\":\"address\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"to\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"value\",\"type\":\"uint256\"}],\"name\":\"Transfer\",\"type\":\"event\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"},{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"name\":\"allowance\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"spender\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"value\",\"type\":\"uint256\"}],\"name\":\"approve\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"name\":\"balanceOf\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"totalSupply\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"to\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"value\",\"type\":\"uint256\"}],\"name\":\"transfer\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"from\",\"type\":\"address\"},{\"internalType\":\"address\",\"name\":\"to\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"value\",\"type\":\"uint256\"}],\"name\":\"transferFrom\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"nonpayable\",\"type\":\"function\"}]",
+	Bin: "0x608060405234801561000f575f80fd5b5060405161058238038061058283398101604081905261002e9161007f565b335f81815260208181526040808320859055600285905551848152849392917fddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef910160405180910390a35050610096565b5f6020828403121561008f575f80fd5b5051919050565b6104df806100a35f395ff3fe608060405234801561000f575f80fd5b5060043610610060575f3560e01c8063095ea7b31461006457806318160ddd1461008c57806323b872dd146100a357806370a08231146100b6578063a9059cbb146100d5578063dd62ed3e146100e8575b5f80fd5b6100776100723660046103c4565b610112565b60405190151581526020015b60405180910390f35b61009560025481565b604051908152602001610083565b6100776100b13660046103ec565b610127565b6100956100c4366004610425565b5f6020819052908152604090205481565b6100776100e33660046103c4565b610188565b6100956100f636600461043e565b600160209081525f928352604080842090915290825290205481565b5f61011d83836101d7565b5060015b92915050565b5f610133848484610235565b61017d5760405162461bcd60e51b8152602060048201526016602482015275696e73756666696369656e7420616c6c6f77616e636560501b60448201526064015b60405180910390fd5b5060015b9392505050565b5f6101943384846102d2565b61011d5760405162461bcd60e51b8152602060048201526014602482015273696e73756666696369656e742062616c616e636560601b6044820152606401610174565b335f8181526001602090815260408083206001600160a01b03871680855290835292819020859055518481529192917f8c5be1e5ebec7d5bd14f71427d1e84f3dd0314c0f7b2291e5b200ac8c7c3b925910160405180910390a35050565b6001600160a01b0383165f90815260016020908152604080832033845290915281205482118061027b57506001600160a01b0384165f9081526020819052604090205482115b1561028757505f610181565b6001600160a01b0384165f908152600160209081526040808320338452909152812080548492906102b9908490610483565b909155506102ca90508484846102d2565b949350505050565b6001600160a01b0383165f908152602081905260408120548211156102f857505f610181565b6001600160a01b0384165f908152602081905260408120805484929061031f908490610483565b90915550506001600160a01b0383165f908152602081905260408120805484929061034b908490610496565b92505081905550826001600160a01b0316846001600160a01b03167fddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef8460405161039791815260200190565b60405180910390a35060019392505050565b80356001600160a01b03811681146103bf575f80fd5b919050565b5f80604083850312156103d5575f80fd5b6103de836103a9565b946020939093013593505050565b5f805f606084860312156103fe575f80fd5b610407846103a9565b9250610415602085016103a9565b9150604084013590509250925092565b5f60208284031215610435575f80fd5b610181826103a9565b5f806040838503121561044f575f80fd5b610458836103a9565b9150610466602084016103a9565b90509250929050565b634e487b7160e01b5f52601160045260245ffd5b818103818111156101215761012161046f565b808201808211156101215761012161046f56fea2646970667358221220e499fe15eb1e23aa3e804cf29d6db2f26c40852ebb7f5e60dcc3f3c24c64f5a264736f6c63430008150033",
+}
+
+// NoMetadataTokenABI is the input ABI used to generate the binding from.
+// Deprecated: Use NoMetadataTokenMetaData.ABI instead.
+var NoMetadataTokenABI = NoMetadataTokenMetaData.ABI
+
+// NoMetadataTokenBin is the compiled bytecode used for deploying new contracts.
+// Deprecated: Use NoMetadataTokenMetaData.Bin instead.
+var NoMetadataTokenBin = NoMetadataTokenMetaData.Bin
+
+// DeployNoMetadataToken deploys a new Ethereum contract, binding an instance of NoMetadataToken to it.
+func DeployNoMetadataToken(auth *bind.TransactOpts, backend bind.ContractBackend, supply *big.Int) (common.Address, *types.Transaction, *NoMetadataToken, error) {
+	parsed, err := NoMetadataTokenMetaData.GetAbi()
+	if err != nil {
+		return common.Address{}, nil, nil, err
+	}
+	if parsed == nil {
+		return common.Address{}, nil, nil, errors.New("GetABI returned nil")
+	}
+
+	address, tx, contract, err := bind.DeployContract(auth, *parsed, common.FromHex(NoMetadataTokenBin), backend, supply)
+	if err != nil {
+		return common.Address{}, nil, nil, err
+	}
+	return address, tx, &NoMetadataToken{NoMetadataTokenCaller: NoMetadataTokenCaller{contract: contract}, NoMetadataTokenTransactor: NoMetadataTokenTransactor{contract: contract}, NoMetadataTokenFilterer: NoMetadataTokenFilterer{contract: contract}}, nil
+}
+
+// NoMetadataToken is an auto generated Go binding around an Ethereum contract.
+type NoMetadataToken struct {
+	NoMetadataTokenCaller     // Read-only binding to the contract
+	NoMetadataTokenTransactor // Write-only binding to the contract
+	NoMetadataTokenFilterer   // Log filterer for contract events
+}
+
+// NoMetadataTokenCaller is an auto generated read-only Go binding around an Ethereum contract.
+type NoMetadataTokenCaller struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// NoMetadataTokenTransactor is an auto generated write-only Go binding around an Ethereum contract.
+type NoMetadataTokenTransactor struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// NoMetadataTokenFilterer is an auto generated log filtering Go binding around an Ethereum contract events.
+type NoMetadataTokenFilterer struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// NoMetadataTokenSession is an auto generated Go binding around an Ethereum contract,
+// with pre-set call and transact options.
+type NoMetadataTokenSession struct {
+	Contract     *NoMetadataToken  // Generic contract binding to set the session for
+	CallOpts     bind.CallOpts     // Call options to use throughout this session
+	TransactOpts bind.TransactOpts // Transaction auth options to use throughout this session
+}
+
+// NoMetadataTokenCallerSession is an auto generated read-only Go binding around an Ethereum contract,
+// with pre-set call options.
+type NoMetadataTokenCallerSession struct {
+	Contract *NoMetadataTokenCaller // Generic contract caller binding to set the session for
+	CallOpts bind.CallOpts          // Call options to use throughout this session
+}
+
+// NoMetadataTokenTransactorSession is an auto generated write-only Go binding around an Ethereum contract,
+// with pre-set transact options.
+type NoMetadataTokenTransactorSession struct {
+	Contract     *NoMetadataTokenTransactor // Generic contract transactor binding to set the session for
+	TransactOpts bind.TransactOpts          // Transaction auth options to use throughout this session
+}
+
+// NoMetadataTokenRaw is an auto generated low-level Go binding around an Ethereum contract.
+type NoMetadataTokenRaw struct {
+	Contract *NoMetadataToken // Generic contract binding to access the raw methods on
+}
+
+// NoMetadataTokenCallerRaw is an auto generated low-level read-only Go binding around an Ethereum contract.
+type NoMetadataTokenCallerRaw struct {
+	Contract *NoMetadataTokenCaller // Generic read-only contract binding to access the raw methods on
+}
+
+// NoMetadataTokenTransactorRaw is an auto generated low-level write-only Go binding around an Ethereum contract.
+type NoMetadataTokenTransactorRaw struct {
+	Contract *NoMetadataTokenTransactor // Generic write-only contract binding to access the raw methods on
+}
+
+// NewNoMetadataToken creates a new instance of NoMetadataToken, bound to a specific deployed contract.
+func NewNoMetadataToken(address common.Address, backend bind.ContractBackend) (*NoMetadataToken, error) {
+	contract, err := bindNoMetadataToken(address, backend, backend, backend)
+	if err != nil {
+		return nil, err
+	}
+	return &NoMetadataToken{NoMetadataTokenCaller: NoMetadataTokenCaller{contract: contract}, NoMetadataTokenTransactor: NoMetadataTokenTransactor{contract: contract}, NoMetadataTokenFilterer: NoMetadataTokenFilterer{contract: contract}}, nil
+}
+
+// NewNoMetadataTokenCaller creates a new read-only instance of NoMetadataToken, bound to a specific deployed contract.
+func NewNoMetadataTokenCaller(address common.Address, caller bind.ContractCaller) (*NoMetadataTokenCaller, error) {
+	contract, err := bindNoMetadataToken(address, caller, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &NoMetadataTokenCaller{contract: contract}, nil
+}
+
+// NewNoMetadataTokenTransactor creates a new write-only instance of NoMetadataToken, bound to a specific deployed contract.
+func NewNoMetadataTokenTransactor(address common.Address, transactor bind.ContractTransactor) (*NoMetadataTokenTransactor, error) {
+	contract, err := bindNoMetadataToken(address, nil, transactor, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &NoMetadataTokenTransactor{contract: contract}, nil
+}
+
+// NewNoMetadataTokenFilterer creates a new log filterer instance of NoMetadataToken, bound to a specific deployed contract.
+func NewNoMetadataTokenFilterer(address common.Address, filterer bind.ContractFilterer) (*NoMetadataTokenFilterer, error) {
+	contract, err := bindNoMetadataToken(address, nil, nil, filterer)
+	if err != nil {
+		return nil, err
+	}
+	return &NoMetadataTokenFilterer{contract: contract}, nil
+}
+
+// bindNoMetadataToken binds a generic wrapper to an already deployed contract.
+func bindNoMetadataToken(address common.Address, caller bind.ContractCaller, transactor bind.ContractTransactor, filterer bind.ContractFilterer) (*bind.BoundContract, error) {
+	parsed, err := NoMetadataTokenMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	return bind.NewBoundContract(address, *parsed, caller, transactor, filterer), nil
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_NoMetadataToken *NoMetadataTokenRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _NoMetadataToken.Contract.NoMetadataTokenCaller.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_NoMetadataToken *NoMetadataTokenRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _NoMetadataToken.Contract.NoMetadataTokenTransactor.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_NoMetadataToken *NoMetadataTokenRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _NoMetadataToken.Contract.NoMetadataTokenTransactor.contract.Transact(opts, method, params...)
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_NoMetadataToken *NoMetadataTokenCallerRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _NoMetadataToken.Contract.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_NoMetadataToken *NoMetadataTokenTransactorRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _NoMetadataToken.Contract.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_NoMetadataToken *NoMetadataTokenTransactorRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _NoMetadataToken.Contract.contract.Transact(opts, method, params...)
+}
+
+// Allowance is a free data retrieval call binding the contract method 0xdd62ed3e.
+//
+// Solidity: function allowance(address , address ) view returns(uint256)
+func (_NoMetadataToken *NoMetadataTokenCaller) Allowance(opts *bind.CallOpts, arg0 common.Address, arg1 common.Address) (*big.Int, error) {
+	var out []interface{}
+	err := _NoMetadataToken.contract.Call(opts, &out, "allowance", arg0, arg1)
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// Allowance is a free data retrieval call binding the contract method 0xdd62ed3e.
+//
+// Solidity: function allowance(address , address ) view returns(uint256)
+func (_NoMetadataToken *NoMetadataTokenSession) Allowance(arg0 common.Address, arg1 common.Address) (*big.Int, error) {
+	return _NoMetadataToken.Contract.Allowance(&_NoMetadataToken.CallOpts, arg0, arg1)
+}
+
+// Allowance is a free data retrieval call binding the contract method 0xdd62ed3e.
+//
+// Solidity: function allowance(address , address ) view returns(uint256)
+func (_NoMetadataToken *NoMetadataTokenCallerSession) Allowance(arg0 common.Address, arg1 common.Address) (*big.Int, error) {
+	return _NoMetadataToken.Contract.Allowance(&_NoMetadataToken.CallOpts, arg0, arg1)
+}
+
+// BalanceOf is a free data retrieval call binding the contract method 0x70a08231.
+//
+// Solidity: function balanceOf(address ) view returns(uint256)
+func (_NoMetadataToken *NoMetadataTokenCaller) BalanceOf(opts *bind.CallOpts, arg0 common.Address) (*big.Int, error) {
+	var out []interface{}
+	err := _NoMetadataToken.contract.Call(opts, &out, "balanceOf", arg0)
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// BalanceOf is a free data retrieval call binding the contract method 0x70a08231.
+//
+// Solidity: function balanceOf(address ) view returns(uint256)
+func (_NoMetadataToken *NoMetadataTokenSession) BalanceOf(arg0 common.Address) (*big.Int, error) {
+	return _NoMetadataToken.Contract.BalanceOf(&_NoMetadataToken.CallOpts, arg0)
+}
+
+// BalanceOf is a free data retrieval call binding the contract method 0x70a08231.
+//
+// Solidity: function balanceOf(address ) view returns(uint256)
+func (_NoMetadataToken *NoMetadataTokenCallerSession) BalanceOf(arg0 common.Address) (*big.Int, error) {
+	return _NoMetadataToken.Contract.BalanceOf(&_NoMetadataToken.CallOpts, arg0)
+}
+
+// TotalSupply is a free data retrieval call binding the contract method 0x18160ddd.
+//
+// Solidity: function totalSupply() view returns(uint256)
+func (_NoMetadataToken *NoMetadataTokenCaller) TotalSupply(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _NoMetadataToken.contract.Call(opts, &out, "totalSupply")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// TotalSupply is a free data retrieval call binding the contract method 0x18160ddd.
+//
+// Solidity: function totalSupply() view returns(uint256)
+func (_NoMetadataToken *NoMetadataTokenSession) TotalSupply() (*big.Int, error) {
+	return _NoMetadataToken.Contract.TotalSupply(&_NoMetadataToken.CallOpts)
+}
+
+// TotalSupply is a free data retrieval call binding the contract method 0x18160ddd.
+//
+// Solidity: function totalSupply() view returns(uint256)
+func (_NoMetadataToken *NoMetadataTokenCallerSession) TotalSupply() (*big.Int, error) {
+	return _NoMetadataToken.Contract.TotalSupply(&_NoMetadataToken.CallOpts)
+}
+
+// Approve is a paid mutator transaction binding the contract method 0x095ea7b3.
+//
+// Solidity: function approve(address spender, uint256 value) returns(bool)
+func (_NoMetadataToken *NoMetadataTokenTransactor) Approve(opts *bind.TransactOpts, spender common.Address, value *big.Int) (*types.Transaction, error) {
+	return _NoMetadataToken.contract.Transact(opts, "approve", spender, value)
+}
+
+// Approve is a paid mutator transaction binding the contract method 0x095ea7b3.
+//
+// Solidity: function approve(address spender, uint256 value) returns(bool)
+func (_NoMetadataToken *NoMetadataTokenSession) Approve(spender common.Address, value *big.Int) (*types.Transaction, error) {
+	return _NoMetadataToken.Contract.Approve(&_NoMetadataToken.TransactOpts, spender, value)
+}
+
+// Approve is a paid mutator transaction binding the contract method 0x095ea7b3.
+//
+// Solidity: function approve(address spender, uint256 value) returns(bool)
+func (_NoMetadataToken *NoMetadataTokenTransactorSession) Approve(spender common.Address, value *big.Int) (*types.Transaction, error) {
+	return _NoMetadataToken.Contract.Approve(&_NoMetadataToken.TransactOpts, spender, value)
+}
+
+// Transfer is a paid mutator transaction binding the contract method 0xa9059cbb.
+//
+// Solidity: function transfer(address to, uint256 value) returns(bool)
+func (_NoMetadataToken *NoMetadataTokenTransactor) Transfer(opts *bind.TransactOpts, to common.Address, value *big.Int) (*types.Transaction, error) {
+	return _NoMetadataToken.contract.Transact(opts, "transfer", to, value)
+}
+
+// Transfer is a paid mutator transaction binding the contract method 0xa9059cbb.
+//
+// Solidity: function transfer(address to, uint256 value) returns(bool)
+func (_NoMetadataToken *NoMetadataTokenSession) Transfer(to common.Address, value *big.Int) (*types.Transaction, error) {
+	return _NoMetadataToken.Contract.Transfer(&_NoMetadataToken.TransactOpts, to, value)
+}
+
+// Transfer is a paid mutator transaction binding the contract method 0xa9059cbb.
+//
+// Solidity: function transfer(address to, uint256 value) returns(bool)
+func (_NoMetadataToken *NoMetadataTokenTransactorSession) Transfer(to common.Address, value *big.Int) (*types.Transaction, error) {
+	return _NoMetadataToken.Contract.Transfer(&_NoMetadataToken.TransactOpts, to, value)
+}
+
+// TransferFrom is a paid mutator transaction binding the contract method 0x23b872dd.
+//
+// Solidity: function transferFrom(address from, address to, uint256 value) returns(bool)
+func (_NoMetadataToken *NoMetadataTokenTransactor) TransferFrom(opts *bind.TransactOpts, from common.Address, to common.Address, value *big.Int) (*types.Transaction, error) {
+	return _NoMetadataToken.contract.Transact(opts, "transferFrom", from, to, value)
+}
+
+// TransferFrom is a paid mutator transaction binding the contract method 0x23b872dd.
+//
+// Solidity: function transferFrom(address from, address to, uint256 value) returns(bool)
+func (_NoMetadataToken *NoMetadataTokenSession) TransferFrom(from common.Address, to common.Address, value *big.Int) (*types.Transaction, error) {
+	return _NoMetadataToken.Contract.TransferFrom(&_NoMetadataToken.TransactOpts, from, to, value)
+}
+
+// TransferFrom is a paid mutator transaction binding the contract method 0x23b872dd.
+//
+// Solidity: function transferFrom(address from, address to, uint256 value) returns(bool)
+func (_NoMetadataToken *NoMetadataTokenTransactorSession) TransferFrom(from common.Address, to common.Address, value *big.Int) (*types.Transaction, error) {
+	return _NoMetadataToken.Contract.TransferFrom(&_NoMetadataToken.TransactOpts, from, to, value)
+}
+
+// NoMetadataTokenApprovalIterator is returned from FilterApproval and is used to iterate over the raw logs and unpacked data for Approval events raised by the NoMetadataToken contract.
+type NoMetadataTokenApprovalIterator struct {
+	Event *NoMetadataTokenApproval // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *NoMetadataTokenApprovalIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(NoMetadataTokenApproval)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(NoMetadataTokenApproval)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *NoMetadataTokenApprovalIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *NoMetadataTokenApprovalIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// NoMetadataTokenApproval represents a Approval event raised by the NoMetadataToken contract.
+type NoMetadataTokenApproval struct {
+	Owner   common.Address
+	Spender common.Address
+	Value   *big.Int
+	Raw     types.Log // Blockchain specific contextual infos
+}
+
+// FilterApproval is a free log retrieval operation binding the contract event 0x8c5be1e5ebec7d5bd14f71427d1e84f3dd0314c0f7b2291e5b200ac8c7c3b925.
+//
+// Solidity: event Approval(address indexed owner, address indexed spender, uint256 value)
+func (_NoMetadataToken *NoMetadataTokenFilterer) FilterApproval(opts *bind.FilterOpts, owner []common.Address, spender []common.Address) (*NoMetadataTokenApprovalIterator, error) {
+
+	var ownerRule []interface{}
+	for _, ownerItem := range owner {
+		ownerRule = append(ownerRule, ownerItem)
+	}
+	var spenderRule []interface{}
+	for _, spenderItem := range spender {
+		spenderRule = append(spenderRule, spenderItem)
+	}
+
+	logs, sub, err := _NoMetadataToken.contract.FilterLogs(opts, "Approval", ownerRule, spenderRule)
+	if err != nil {
+		return nil, err
+	}
+	return &NoMetadataTokenApprovalIterator{contract: _NoMetadataToken.contract, event: "Approval", logs: logs, sub: sub}, nil
+}
+
+// WatchApproval is a free log subscription operation binding the contract event 0x8c5be1e5ebec7d5bd14f71427d1e84f3dd0314c0f7b2291e5b200ac8c7c3b925.
+//
+// Solidity: event Approval(address indexed owner, address indexed spender, uint256 value)
+func (_NoMetadataToken *NoMetadataTokenFilterer) WatchApproval(opts *bind.WatchOpts, sink chan<- *NoMetadataTokenApproval, owner []common.Address, spender []common.Address) (event.Subscription, error) {
+
+	var ownerRule []interface{}
+	for _, ownerItem := range owner {
+		ownerRule = append(ownerRule, ownerItem)
+	}
+	var spenderRule []interface{}
+	for _, spenderItem := range spender {
+		spenderRule = append(spenderRule, spenderItem)
+	}
+
+	logs, sub, err := _NoMetadataToken.contract.WatchLogs(opts, "Approval", ownerRule, spenderRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(NoMetadataTokenApproval)
+				if err := _NoMetadataToken.contract.UnpackLog(event, "Approval", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseApproval is a log parse operation binding the contract event 0x8c5be1e5ebec7d5bd14f71427d1e84f3dd0314c0f7b2291e5b200ac8c7c3b925.
+//
+// Solidity: event Approval(address indexed owner, address indexed spender, uint256 value)
+func (_NoMetadataToken *NoMetadataTokenFilterer) ParseApproval(log types.Log) (*NoMetadataTokenApproval, error) {
+	event := new(NoMetadataTokenApproval)
+	if err := _NoMetadataToken.contract.UnpackLog(event, "Approval", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// NoMetadataTokenTransferIterator is returned from FilterTransfer and is used to iterate over the raw logs and unpacked data for Transfer events raised by the NoMetadataToken contract.
+type NoMetadataTokenTransferIterator struct {
+	Event *NoMetadataTokenTransfer // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *NoMetadataTokenTransferIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(NoMetadataTokenTransfer)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(NoMetadataTokenTransfer)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *NoMetadataTokenTransferIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *NoMetadataTokenTransferIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// NoMetadataTokenTransfer represents a Transfer event raised by the NoMetadataToken contract.
+type NoMetadataTokenTransfer struct {
+	From  common.Address
+	To    common.Address
+	Value *big.Int
+	Raw   types.Log // Blockchain specific contextual infos
+}
+
+// FilterTransfer is a free log retrieval operation binding the contract event 0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef.
+//
+// Solidity: event Transfer(address indexed from, address indexed to, uint256 value)
+func (_NoMetadataToken *NoMetadataTokenFilterer) FilterTransfer(opts *bind.FilterOpts, from []common.Address, to []common.Address) (*NoMetadataTokenTransferIterator, error) {
+
+	var fromRule []interface{}
+	for _, fromItem := range from {
+		fromRule = append(fromRule, fromItem)
+	}
+	var toRule []interface{}
+	for _, toItem := range to {
+		toRule = append(toRule, toItem)
+	}
+
+	logs, sub, err := _NoMetadataToken.contract.FilterLogs(opts, "Transfer", fromRule, toRule)
+	if err != nil {
+		return nil, err
+	}
+	return &NoMetadataTokenTransferIterator{contract: _NoMetadataToken.contract, event: "Transfer", logs: logs, sub: sub}, nil
+}
+
+// WatchTransfer is a free log subscription operation binding the contract event 0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef.
+//
+// Solidity: event Transfer(address indexed from, address indexed to, uint256 value)
+func (_NoMetadataToken *NoMetadataTokenFilterer) WatchTransfer(opts *bind.WatchOpts, sink chan<- *NoMetadataTokenTransfer, from []common.Address, to []common.Address) (event.Subscription, error) {
+
+	var fromRule []interface{}
+	for _, fromItem := range from {
+		fromRule = append(fromRule, fromItem)
+	}
+	var toRule []interface{}
+	for _, toItem := range to {
+		toRule = append(toRule, toItem)
+	}
+
+	logs, sub, err := _NoMetadataToken.contract.WatchLogs(opts, "Transfer", fromRule, toRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(NoMetadataTokenTransfer)
+				if err := _NoMetadataToken.contract.UnpackLog(event, "Transfer", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseTransfer is a log parse operation binding the contract event 0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef.
+//
+// Solidity: event Transfer(address indexed from, address indexed to, uint256 value)
+func (_NoMetadataToken *NoMetadataTokenFilterer) ParseTransfer(log types.Log) (*NoMetadataTokenTransfer, error) {
+	event := new(NoMetadataTokenTransfer)
+	if err := _NoMetadataToken.contract.UnpackLog(event, "Transfer", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
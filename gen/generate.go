@@ -0,0 +1,11 @@
+// Package token holds the abigen bindings of the contracts in contracts/.
+//
+// The bindings and build/ artifacts are generated with the solc version and
+// settings pinned in contracts/compile.json:
+//
+//	go generate ./gen
+//
+// Run go run ./cmd/compile -check to verify that they are up to date.
+package token
+
+//go:generate go run ../cmd/compile -root ..
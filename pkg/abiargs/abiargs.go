@@ -0,0 +1,137 @@
+// Package abiargs loads contract ABIs and converts command line strings into
+// ABI values and back, so any contract can be called without a binding:
+//
+//	parsed, _ := abiargs.Load("build", "MyToken")
+//	method, _ := abiargs.Method(parsed, "transfer")
+//	args, _ := abiargs.ParseArgs(method.Inputs, []string{"0x7099...79C8", "1.5ether"})
+//	data, _ := parsed.Pack(method.Name, args...)
+package abiargs
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// Load reads an ABI from a file path, or from <build>/<name>.abi for a
+// contract name such as MyToken.
+func Load(build, nameOrPath string) (*abi.ABI, error) {
+	path := nameOrPath
+	if _, err := os.Stat(path); err != nil {
+		if strings.ContainsAny(nameOrPath, `/\`) || filepath.Ext(nameOrPath) != "" {
+			return nil, err
+		}
+
+		path = filepath.Join(build, nameOrPath+".abi")
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("no ABI for %q: %w", nameOrPath, err)
+	}
+
+	defer f.Close()
+
+	parsed, err := abi.JSON(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	return &parsed, nil
+}
+
+// Method finds a method by name, by signature such as
+// "transfer(address,uint256)" for overloaded methods, or by 4-byte selector.
+func Method(parsed *abi.ABI, name string) (abi.Method, error) {
+	if strings.HasPrefix(name, "0x") {
+		selector, err := hexutil.Decode(name)
+		if err != nil || len(selector) != 4 {
+			return abi.Method{}, fmt.Errorf("invalid selector %q", name)
+		}
+
+		method, err := parsed.MethodById(selector)
+		if err != nil {
+			return abi.Method{}, err
+		}
+
+		return *method, nil
+	}
+
+	if strings.Contains(name, "(") {
+		sig := strings.ReplaceAll(name, " ", "")
+		for _, method := range parsed.Methods {
+			if method.Sig == sig {
+				return method, nil
+			}
+		}
+
+		return abi.Method{}, fmt.Errorf("no method %s", name)
+	}
+
+	var candidates []string
+	for _, method := range parsed.Methods {
+		if method.RawName == name {
+			candidates = append(candidates, method.Sig)
+		}
+	}
+
+	switch len(candidates) {
+	case 0:
+		return abi.Method{}, fmt.Errorf("no method %s", name)
+	case 1:
+		for _, method := range parsed.Methods {
+			if method.Sig == candidates[0] {
+				return method, nil
+			}
+		}
+	}
+
+	sort.Strings(candidates)
+
+	return abi.Method{}, fmt.Errorf("%s is overloaded, use one of %s", name, strings.Join(candidates, ", "))
+}
+
+// ParseArgs parses one string per input.
+func ParseArgs(inputs abi.Arguments, args []string) ([]any, error) {
+	if len(args) != len(inputs) {
+		return nil, fmt.Errorf("got %d arguments, want %d (%s)", len(args), len(inputs), signature(inputs))
+	}
+
+	values := make([]any, len(args))
+
+	var errs []error
+	for i, input := range inputs {
+		v, err := Parse(input.Type, args[i])
+		if err != nil {
+			name := input.Name
+			if name == "" {
+				name = fmt.Sprintf("#%d", i)
+			}
+
+			errs = append(errs, fmt.Errorf("argument %s (%s): %w", name, input.Type, err))
+			continue
+		}
+
+		values[i] = v
+	}
+
+	return values, errors.Join(errs...)
+}
+
+func signature(args abi.Arguments) string {
+	types := make([]string, len(args))
+	for i, arg := range args {
+		types[i] = arg.Type.String()
+		if arg.Name != "" {
+			types[i] += " " + arg.Name
+		}
+	}
+
+	return strings.Join(types, ", ")
+}
@@ -0,0 +1,194 @@
+package abiargs
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+const testABI = `[
+	{"type":"function","name":"transfer","inputs":[{"name":"to","type":"address"},{"name":"value","type":"uint256"}],"outputs":[{"name":"","type":"bool"}]},
+	{"type":"function","name":"safeTransferFrom","inputs":[{"name":"from","type":"address"},{"name":"to","type":"address"},{"name":"id","type":"uint256"}],"outputs":[]},
+	{"type":"function","name":"safeTransferFrom","inputs":[{"name":"from","type":"address"},{"name":"to","type":"address"},{"name":"id","type":"uint256"},{"name":"data","type":"bytes"}],"outputs":[]},
+	{"type":"function","name":"submit","inputs":[{"name":"order","type":"tuple","components":[{"name":"maker","type":"address"},{"name":"amounts","type":"uint128[]"},{"name":"salt","type":"bytes32"}]}],"outputs":[]},
+	{"type":"error","name":"ERC20InsufficientBalance","inputs":[{"name":"sender","type":"address"},{"name":"balance","type":"uint256"},{"name":"needed","type":"uint256"}]},
+	{"type":"event","name":"Transfer","inputs":[{"name":"from","type":"address","indexed":true},{"name":"to","type":"address","indexed":true},{"name":"value","type":"uint256","indexed":false}]}
+]`
+
+func mustType(t *testing.T, s string) abi.Type {
+	t.Helper()
+
+	typ, err := abi.NewType(s, "", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return typ
+}
+
+func TestParse(t *testing.T) {
+	ether := new(big.Int).Mul(big.NewInt(15), big.NewInt(1e17))
+
+	tests := []struct {
+		typ  string
+		in   string
+		want string // Format of the parsed value
+		err  bool
+	}{
+		{typ: "address", in: "0x70997970C51812dc3A010C7d01b50e0d17dc79C8", want: "0x70997970C51812dc3A010C7d01b50e0d17dc79C8"},
+		{typ: "address", in: "0x70997970c51812dc3a010c7d01b50e0d17dc79c8", want: "0x70997970C51812dc3A010C7d01b50e0d17dc79C8"},
+		{typ: "address", in: "0x70997970C51812dc3A010C7d01b50e0d17dc79c8", err: true},
+		{typ: "address", in: "0x1234", err: true},
+		{typ: "bool", in: "true", want: "true"},
+		{typ: "uint256", in: "1000", want: "1000"},
+		{typ: "uint256", in: "0x3e8", want: "1000"},
+		{typ: "uint256", in: "1.5ether", want: ether.String()},
+		{typ: "uint256", in: "20gwei", want: "20000000000"},
+		{typ: "uint256", in: "7 wei", want: "7"},
+		{typ: "uint256", in: "-1", err: true},
+		{typ: "uint8", in: "255", want: "255"},
+		{typ: "uint8", in: "256", err: true},
+		{typ: "int8", in: "-128", want: "-128"},
+		{typ: "int8", in: "128", err: true},
+		{typ: "int256", in: "-2ether", want: "-2000000000000000000"},
+		{typ: "uint256", in: "1.5", err: true},
+		{typ: "string", in: "hello, world", want: `"hello, world"`},
+		{typ: "bytes", in: "0xdeadbeef", want: "0xdeadbeef"},
+		{typ: "bytes4", in: "0xa9059cbb", want: "0xa9059cbb"},
+		{typ: "bytes4", in: "0xa9059c", err: true},
+		{typ: "uint256[]", in: "[1, 2,3]", want: "[1,2,3]"},
+		{typ: "uint256[]", in: "[]", want: "[]"},
+		{typ: "address[2]", in: "[0x70997970C51812dc3A010C7d01b50e0d17dc79C8,0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266]", want: "[0x70997970C51812dc3A010C7d01b50e0d17dc79C8,0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266]"},
+		{typ: "address[2]", in: "[0x70997970C51812dc3A010C7d01b50e0d17dc79C8]", err: true},
+		{typ: "string[]", in: `["a,b", "c"]`, want: `["a,b","c"]`},
+		{typ: "uint8[][]", in: "[[1,2],[3]]", want: "[[1,2],[3]]"},
+		{typ: "uint256[]", in: "[1,2", err: true},
+	}
+
+	for _, tt := range tests {
+		typ := mustType(t, tt.typ)
+
+		v, err := Parse(typ, tt.in)
+		if tt.err {
+			if err == nil {
+				t.Errorf("Parse(%s, %q) = %v, want error", tt.typ, tt.in, Format(typ, v))
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("Parse(%s, %q): %v", tt.typ, tt.in, err)
+			continue
+		}
+
+		if got := Format(typ, v); got != tt.want {
+			t.Errorf("Parse(%s, %q) = %s, want %s", tt.typ, tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestPackTuple(t *testing.T) {
+	parsed, err := abi.JSON(strings.NewReader(testABI))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	method, err := Method(&parsed, "submit")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	in := "(0x70997970C51812dc3A010C7d01b50e0d17dc79C8,[1,2ether],0x" + strings.Repeat("ab", 32) + ")"
+
+	args, err := ParseArgs(method.Inputs, []string{in})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := parsed.Pack(method.Name, args...)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	values, err := method.Inputs.Unpack(data[4:])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := "(0x70997970C51812dc3A010C7d01b50e0d17dc79C8,[1,2000000000000000000],0x" + strings.Repeat("ab", 32) + ")"
+	if got := Format(method.Inputs[0].Type, values[0]); got != want {
+		t.Errorf("round trip %s, want %s", got, want)
+	}
+}
+
+func TestMethod(t *testing.T) {
+	parsed, err := abi.JSON(strings.NewReader(testABI))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, name := range []string{"transfer", "transfer(address,uint256)", "0xa9059cbb"} {
+		method, err := Method(&parsed, name)
+		if err != nil || method.Sig != "transfer(address,uint256)" {
+			t.Errorf("Method(%q) = %s: %v", name, method.Sig, err)
+		}
+	}
+
+	if _, err := Method(&parsed, "safeTransferFrom"); err == nil || !strings.Contains(err.Error(), "overloaded") {
+		t.Errorf("overloaded method without signature: %v", err)
+	}
+
+	method, err := Method(&parsed, "safeTransferFrom(address, address, uint256, bytes)")
+	if err != nil || len(method.Inputs) != 4 {
+		t.Errorf("overloaded method by signature: %v", err)
+	}
+
+	if _, err := Method(&parsed, "approve"); err == nil {
+		t.Error("found a missing method")
+	}
+
+	if _, err := ParseArgs(method.Inputs, []string{"0x01"}); err == nil {
+		t.Error("parsed too few arguments")
+	}
+}
+
+func TestDecode(t *testing.T) {
+	parsed, err := abi.JSON(strings.NewReader(testABI))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sender := common.HexToAddress("0x70997970C51812dc3A010C7d01b50e0d17dc79C8")
+
+	data, err := parsed.Errors["ERC20InsufficientBalance"].Inputs.Pack(sender, big.NewInt(0), big.NewInt(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	id := parsed.Errors["ERC20InsufficientBalance"].ID
+	data = append(id[:4:4], data...)
+
+	decoded, ok := DecodeError(&parsed, data)
+	if want := "ERC20InsufficientBalance(sender: " + sender.Hex() + ", balance: 0, needed: 1)"; !ok || decoded != want {
+		t.Errorf("DecodeError = %q, want %q", decoded, want)
+	}
+
+	// Error(string) from require
+	reason, err := abi.Arguments{{Type: mustType(t, "string")}}.Pack("insufficient")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	reason = append(hexutil.MustDecode("0x08c379a0"), reason...)
+	if decoded, ok := DecodeError(&parsed, reason); !ok || decoded != `Error("insufficient")` {
+		t.Errorf("DecodeError = %q", decoded)
+	}
+
+	if _, ok := DecodeError(&parsed, []byte{1, 2, 3, 4}); ok {
+		t.Error("decoded an unknown selector")
+	}
+}
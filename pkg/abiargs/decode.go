@@ -0,0 +1,98 @@
+package abiargs
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// Values formats unpacked values as "name: value" pairs, using the position
+// for unnamed arguments.
+func Values(args abi.Arguments, values []any) []string {
+	pairs := make([]string, len(values))
+	for i, v := range values {
+		name := fmt.Sprintf("#%d", i)
+		if i < len(args) && args[i].Name != "" {
+			name = args[i].Name
+		}
+
+		if i < len(args) {
+			pairs[i] = name + ": " + Format(args[i].Type, v)
+		} else {
+			pairs[i] = name + ": " + fmt.Sprint(v)
+		}
+	}
+
+	return pairs
+}
+
+// DecodeError formats revert data as a custom error of the ABI, such as
+// "ERC20InsufficientBalance(sender: 0x..., balance: 0, needed: 1)", or as the
+// reason of a require statement.
+func DecodeError(parsed *abi.ABI, data []byte) (string, bool) {
+	if len(data) < 4 {
+		return "", false
+	}
+
+	if reason, err := abi.UnpackRevert(data); err == nil {
+		return fmt.Sprintf("Error(%q)", reason), true
+	}
+
+	var selector [4]byte
+	copy(selector[:], data)
+
+	customErr, err := parsed.ErrorByID(selector)
+	if err != nil {
+		return "", false
+	}
+
+	values, err := customErr.Inputs.Unpack(data[4:])
+	if err != nil {
+		return "", false
+	}
+
+	return customErr.Name + "(" + strings.Join(Values(customErr.Inputs, values), ", ") + ")", true
+}
+
+// DecodeLog formats a log as an event of the ABI, such as
+// "Transfer(from: 0x..., to: 0x..., value: 1000)".
+func DecodeLog(parsed *abi.ABI, log *types.Log) (string, error) {
+	if len(log.Topics) == 0 {
+		return "", errors.New("anonymous log")
+	}
+
+	event, err := parsed.EventByID(log.Topics[0])
+	if err != nil {
+		return "", err
+	}
+
+	var indexed abi.Arguments
+	for _, input := range event.Inputs {
+		if input.Indexed {
+			indexed = append(indexed, input)
+		}
+	}
+
+	fields := make(map[string]any)
+
+	if err := abi.ParseTopicsIntoMap(fields, indexed, log.Topics[1:]); err != nil {
+		return "", err
+	}
+
+	if len(log.Data) > 0 {
+		if err := parsed.UnpackIntoMap(fields, event.Name, log.Data); err != nil {
+			return "", err
+		}
+	}
+
+	// Keep the declaration order of the event
+	values := make([]any, len(event.Inputs))
+	for i, input := range event.Inputs {
+		values[i] = fields[input.Name]
+	}
+
+	return event.Name + "(" + strings.Join(Values(event.Inputs, values), ", ") + ")", nil
+}
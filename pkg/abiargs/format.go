@@ -0,0 +1,65 @@
+package abiargs
+
+import (
+	"fmt"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// Format renders a value of type t, as unpacked by the abi package, in the
+// syntax Parse accepts: integers in decimal, addresses checksummed, bytes in
+// hex, strings quoted, lists in brackets and tuples in parentheses. The type
+// is needed because bytes and uint8[] are both []byte in Go.
+func Format(t abi.Type, v any) string {
+	rv := reflect.ValueOf(v)
+
+	switch t.T {
+	case abi.AddressTy:
+		if address, ok := v.(common.Address); ok {
+			return address.Hex()
+		}
+
+	case abi.StringTy:
+		if s, ok := v.(string); ok {
+			return strconv.Quote(s)
+		}
+
+	case abi.BytesTy, abi.FixedBytesTy, abi.HashTy:
+		if rv.Kind() == reflect.Slice || rv.Kind() == reflect.Array {
+			b := make([]byte, rv.Len())
+			reflect.Copy(reflect.ValueOf(b), rv)
+			return hexutil.Encode(b)
+		}
+
+	case abi.IntTy, abi.UintTy:
+		if n, ok := v.(*big.Int); ok {
+			return n.String()
+		}
+
+	case abi.SliceTy, abi.ArrayTy:
+		if rv.Kind() == reflect.Slice || rv.Kind() == reflect.Array {
+			elems := make([]string, rv.Len())
+			for i := range elems {
+				elems[i] = Format(*t.Elem, rv.Index(i).Interface())
+			}
+			return "[" + strings.Join(elems, ",") + "]"
+		}
+
+	case abi.TupleTy:
+		if rv.Kind() == reflect.Struct && rv.NumField() == len(t.TupleElems) {
+			fields := make([]string, len(t.TupleElems))
+			for i, elem := range t.TupleElems {
+				fields[i] = Format(*elem, rv.Field(i).Interface())
+			}
+			return "(" + strings.Join(fields, ",") + ")"
+		}
+	}
+
+	return fmt.Sprint(v)
+}
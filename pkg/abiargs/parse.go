@@ -0,0 +1,242 @@
+package abiargs
+
+import (
+	"errors"
+	"fmt"
+	"go-ethereum-example/pkg/eip55"
+	"go-ethereum-example/pkg/units"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// unitDecimals are the suffixes accepted by integer arguments, e.g. 1.5ether,
+// in matching order: gwei before wei.
+var unitDecimals = []struct {
+	unit     string
+	decimals uint8
+}{
+	{"ether", 18},
+	{"gwei", 9},
+	{"wei", 0},
+}
+
+var bigType = reflect.TypeOf(new(big.Int))
+
+// Parse converts a string into the Go value the abi package packs for t:
+//
+//	address   0x70997970C51812dc3A010C7d01b50e0d17dc79C8 (checksum verified if mixed case)
+//	uintN     1000, 0x3e8, 1.5ether, 20gwei; intN may be negative
+//	bool      true, false
+//	string    any text, quotes are removed inside lists
+//	bytes     0xdeadbeef
+//	bytesN    0x followed by exactly N bytes
+//	T[], T[N] [1,2,3]
+//	tuple     (0x7099...79C8,1000)
+func Parse(t abi.Type, s string) (any, error) {
+	s = strings.TrimSpace(s)
+
+	switch t.T {
+	case abi.AddressTy:
+		return ParseAddress(s)
+
+	case abi.BoolTy:
+		return strconv.ParseBool(s)
+
+	case abi.StringTy:
+		if len(s) >= 2 && s[0] == '"' && s[len(s)-1] == '"' {
+			return strconv.Unquote(s)
+		}
+		return s, nil
+
+	case abi.BytesTy:
+		return hexutil.Decode(s)
+
+	case abi.FixedBytesTy:
+		b, err := hexutil.Decode(s)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(b) != t.Size {
+			return nil, fmt.Errorf("got %d bytes, want %d", len(b), t.Size)
+		}
+
+		v := reflect.New(t.GetType()).Elem()
+		reflect.Copy(v, reflect.ValueOf(b))
+
+		return v.Interface(), nil
+
+	case abi.IntTy, abi.UintTy:
+		return parseInteger(t, s)
+
+	case abi.SliceTy, abi.ArrayTy:
+		elems, err := split(s, '[', ']')
+		if err != nil {
+			return nil, err
+		}
+
+		var v reflect.Value
+		if t.T == abi.SliceTy {
+			v = reflect.MakeSlice(t.GetType(), len(elems), len(elems))
+		} else {
+			if len(elems) != t.Size {
+				return nil, fmt.Errorf("got %d elements, want %d", len(elems), t.Size)
+			}
+			v = reflect.New(t.GetType()).Elem()
+		}
+
+		for i, elem := range elems {
+			parsed, err := Parse(*t.Elem, elem)
+			if err != nil {
+				return nil, fmt.Errorf("element %d: %w", i, err)
+			}
+			v.Index(i).Set(reflect.ValueOf(parsed))
+		}
+
+		return v.Interface(), nil
+
+	case abi.TupleTy:
+		elems, err := split(s, '(', ')')
+		if err != nil {
+			return nil, err
+		}
+
+		if len(elems) != len(t.TupleElems) {
+			return nil, fmt.Errorf("got %d fields, want %d", len(elems), len(t.TupleElems))
+		}
+
+		v := reflect.New(t.GetType()).Elem()
+		for i, elem := range elems {
+			parsed, err := Parse(*t.TupleElems[i], elem)
+			if err != nil {
+				return nil, fmt.Errorf("field %s: %w", t.TupleRawNames[i], err)
+			}
+			v.Field(i).Set(reflect.ValueOf(parsed))
+		}
+
+		return v.Interface(), nil
+
+	default:
+		return nil, fmt.Errorf("unsupported type %s", t)
+	}
+}
+
+// ParseAddress accepts hex addresses and, when they are mixed case, requires a
+// valid EIP-55 checksum.
+func ParseAddress(s string) (common.Address, error) {
+	return eip55.Parse(s)
+}
+
+// parseInteger parses a decimal or hex integer with an optional unit suffix
+// and checks that it fits the type.
+func parseInteger(t abi.Type, s string) (any, error) {
+	negative := strings.HasPrefix(s, "-")
+	digits := strings.TrimPrefix(s, "-")
+
+	var (
+		n   *big.Int
+		err error
+	)
+
+	if strings.HasPrefix(digits, "0x") {
+		var ok bool
+		if n, ok = new(big.Int).SetString(digits[2:], 16); !ok {
+			err = fmt.Errorf("invalid hex integer %q", s)
+		}
+	} else {
+		var decimals uint8
+
+		lower := strings.ToLower(digits)
+		for _, u := range unitDecimals {
+			if strings.HasSuffix(lower, u.unit) {
+				digits, decimals = strings.TrimSpace(digits[:len(digits)-len(u.unit)]), u.decimals
+				break
+			}
+		}
+
+		n, err = units.Parse(digits, decimals)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if negative {
+		n.Neg(n)
+	}
+
+	if t.T == abi.UintTy {
+		if n.Sign() < 0 || n.BitLen() > t.Size {
+			return nil, fmt.Errorf("%s out of range for %s", n, t)
+		}
+	} else {
+		limit := new(big.Int).Lsh(big.NewInt(1), uint(t.Size-1))
+		if n.Cmp(limit) >= 0 || n.Cmp(new(big.Int).Neg(limit)) < 0 {
+			return nil, fmt.Errorf("%s out of range for %s", n, t)
+		}
+	}
+
+	// Types up to 64 bits are packed from the matching Go integer type
+	if t.GetType() == bigType {
+		return n, nil
+	}
+
+	v := reflect.New(t.GetType()).Elem()
+	if t.T == abi.UintTy {
+		v.SetUint(n.Uint64())
+	} else {
+		v.SetInt(n.Int64())
+	}
+
+	return v.Interface(), nil
+}
+
+// split splits "[a,b]" or "(a,b)" into its elements, keeping nested lists,
+// tuples and quoted strings together.
+func split(s string, open, close byte) ([]string, error) {
+	if len(s) < 2 || s[0] != open || s[len(s)-1] != close {
+		return nil, fmt.Errorf("want %c...%c, got %q", open, close, s)
+	}
+
+	inner := strings.TrimSpace(s[1 : len(s)-1])
+	if inner == "" {
+		return nil, nil
+	}
+
+	var (
+		elems  []string
+		depth  int
+		quoted bool
+		start  int
+	)
+
+	for i := 0; i < len(inner); i++ {
+		switch c := inner[i]; {
+		case c == '"' && (i == 0 || inner[i-1] != '\\'):
+			quoted = !quoted
+		case quoted:
+		case c == '[' || c == '(':
+			depth++
+		case c == ']' || c == ')':
+			depth--
+			if depth < 0 {
+				return nil, fmt.Errorf("unbalanced %q", s)
+			}
+		case c == ',' && depth == 0:
+			elems = append(elems, strings.TrimSpace(inner[start:i]))
+			start = i + 1
+		}
+	}
+
+	if depth != 0 || quoted {
+		return nil, errors.New("unbalanced brackets or quotes")
+	}
+
+	return append(elems, strings.TrimSpace(inner[start:])), nil
+}
@@ -0,0 +1,368 @@
+// Package alert raises alerts on the Transfer stream of the watcher, from
+// rules read from a JSON file:
+//
+//	{
+//	  "rules": [
+//	    {"name": "large-transfer", "valueAbove": "10000"},
+//	    {"name": "treasury", "from": ["0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266"]},
+//	    {"name": "burst", "count": 5, "window": "1m"}
+//	  ]
+//	}
+//
+// An Engine is a sink: every transfer written to it is evaluated against the
+// rules, and each alert is written to the alert sinks as a copy of the event
+// with Alert set.
+package alert
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go-ethereum-example/pkg/sink"
+	"go-ethereum-example/pkg/units"
+	"math/big"
+	"os"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Config is the content of a rules file.
+type Config struct {
+	Rules []Rule `json:"rules"`
+}
+
+// Rule matches transfers by all the conditions it sets. A rule with Count
+// alerts when more than Count matching transfers from one sender fall within
+// Window, other rules alert on every matching transfer.
+type Rule struct {
+	Name string `json:"name"`
+
+	// Tokens, From and To restrict the rule to transfers of any of the
+	// tokens, from any of the senders and to any of the recipients. Involving
+	// matches a sender or recipient.
+	Tokens    []common.Address `json:"tokens,omitempty"`
+	From      []common.Address `json:"from,omitempty"`
+	To        []common.Address `json:"to,omitempty"`
+	Involving []common.Address `json:"involving,omitempty"`
+
+	// ValueAbove matches transfers of more than this amount, in token units
+	// such as "1000" or "0.5", scaled by the decimals of each token. Transfers
+	// of tokens whose decimals are unknown never match.
+	ValueAbove string `json:"valueAbove,omitempty"`
+
+	Count  int      `json:"count,omitempty"`
+	Window Duration `json:"window,omitempty"`
+
+	threshold *big.Rat
+}
+
+// Duration is a time.Duration written as a string in JSON, e.g. "1m".
+type Duration struct {
+	time.Duration
+}
+
+// UnmarshalText parses a duration such as "30s" or "1m".
+func (d *Duration) UnmarshalText(text []byte) error {
+	var err error
+	d.Duration, err = time.ParseDuration(string(text))
+
+	return err
+}
+
+// MarshalText formats the duration as time.Duration does.
+func (d Duration) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// LoadConfig reads and validates a rules file.
+func LoadConfig(path string) (*Config, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var config Config
+	if err := json.Unmarshal(b, &config); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	if err := config.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	return &config, nil
+}
+
+// Validate checks the rules, whose names must be unique as they are part of
+// the alert IDs.
+func (c *Config) Validate() error {
+	if len(c.Rules) == 0 {
+		return errors.New("no rules")
+	}
+
+	names := make(map[string]bool)
+	for i := range c.Rules {
+		if err := c.Rules[i].compile(); err != nil {
+			return err
+		}
+
+		if names[c.Rules[i].Name] {
+			return fmt.Errorf("duplicate rule %s", c.Rules[i].Name)
+		}
+
+		names[c.Rules[i].Name] = true
+	}
+
+	return nil
+}
+
+// compile validates the rule and parses its threshold.
+func (r *Rule) compile() error {
+	if r.Name == "" {
+		return errors.New("rule without a name")
+	}
+
+	if len(r.Involving) > 0 && (len(r.From) > 0 || len(r.To) > 0) {
+		return fmt.Errorf("rule %s: involving cannot be combined with from and to", r.Name)
+	}
+
+	if r.ValueAbove != "" {
+		threshold, ok := new(big.Rat).SetString(r.ValueAbove)
+		if !ok || threshold.Sign() < 0 {
+			return fmt.Errorf("rule %s: invalid valueAbove %q", r.Name, r.ValueAbove)
+		}
+
+		r.threshold = threshold
+	}
+
+	if r.Count < 0 || (r.Count > 0) != (r.Window.Duration > 0) {
+		return fmt.Errorf("rule %s: count and window go together", r.Name)
+	}
+
+	return nil
+}
+
+// matches reports whether a transfer meets the conditions of the rule,
+// leaving out Count.
+func (r *Rule) matches(event sink.Event) bool {
+	switch {
+	case len(r.Tokens) > 0 && !slices.Contains(r.Tokens, event.Token):
+		return false
+	case len(r.From) > 0 && !slices.Contains(r.From, event.From):
+		return false
+	case len(r.To) > 0 && !slices.Contains(r.To, event.To):
+		return false
+	case len(r.Involving) > 0 && !slices.Contains(r.Involving, event.From) && !slices.Contains(r.Involving, event.To):
+		return false
+	}
+
+	if r.threshold != nil {
+		value, ok := new(big.Int).SetString(event.Value, 10)
+		if !ok || event.DecimalsUnknown {
+			return false
+		}
+
+		scale := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(event.Decimals)), nil)
+		if new(big.Rat).SetFrac(value, scale).Cmp(r.threshold) <= 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Engine evaluates rules on transfers and writes the alerts they raise to
+// Alerts. Create it with New, which validates the rules.
+type Engine struct {
+	Rules  []Rule
+	Alerts sink.Sink
+
+	// Now is the clock of the Count windows for transfers without a block
+	// time, time.Now by default. Other transfers count at the time of their
+	// block, so replaying old blocks raises the alerts they raised live.
+	Now func() time.Time
+
+	mu     sync.Mutex
+	recent map[string]*window // by rule and sender
+	swept  time.Time
+}
+
+// window holds the recent transfers of a sender for a Count rule.
+type window struct {
+	length time.Duration
+	times  []time.Time
+
+	// counted holds the IDs of the transfers within the window, counted
+	// again since an alert or not, so that redelivered ones are ignored
+	counted map[string]time.Time
+	last    time.Time
+}
+
+// New returns an engine for the rules of config.
+func New(config *Config, alerts sink.Sink) (*Engine, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &Engine{Rules: config.Rules, Alerts: alerts}, nil
+}
+
+// Evaluate returns the alerts a transfer raises, in the order of the rules.
+// Other events, and transfers removed by reorgs, raise none.
+func (e *Engine) Evaluate(event sink.Event) []sink.Event {
+	if event.Event != "Transfer" || event.Removed {
+		return nil
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	var alerts []sink.Event
+	for i := range e.Rules {
+		rule := &e.Rules[i]
+
+		if !rule.matches(event) {
+			continue
+		}
+
+		message := describe(event)
+
+		if rule.Count > 0 {
+			count, ok := e.count(rule, event)
+			if !ok {
+				continue
+			}
+
+			message = fmt.Sprintf("%d transfers from %s within %s, the last %s", count, event.From.Hex(), rule.Window, message)
+		} else if rule.threshold != nil {
+			message = fmt.Sprintf("%s, above %s", message, rule.ValueAbove)
+		}
+
+		alert := event
+		alert.ID = event.ID + ":" + rule.Name
+		alert.Alert = &sink.Alert{Rule: rule.Name, Message: message}
+
+		alerts = append(alerts, alert)
+	}
+
+	return alerts
+}
+
+// count records a transfer of the sender of event, and reports whether the
+// sender made more than Count of them within Window. The window starts over
+// after an alert, so a long burst raises an alert every Count+1 transfers. A
+// transfer already counted, by its ID, is not counted again.
+func (e *Engine) count(rule *Rule, event sink.Event) (int, bool) {
+	at := e.time(event)
+	e.sweep(at)
+
+	key := rule.Name + ":" + event.From.Hex()
+
+	w := e.recent[key]
+	if w == nil {
+		w = &window{length: rule.Window.Duration, counted: make(map[string]time.Time)}
+		e.recent[key] = w
+	}
+
+	if _, ok := w.counted[event.ID]; ok {
+		return 0, false
+	}
+
+	// Drop the transfers that left the window
+	for len(w.times) > 0 && at.Sub(w.times[0]) >= w.length {
+		w.times = w.times[1:]
+	}
+
+	for id, t := range w.counted {
+		if at.Sub(t) >= w.length {
+			delete(w.counted, id)
+		}
+	}
+
+	w.times = append(w.times, at)
+	w.counted[event.ID] = at
+
+	if at.After(w.last) {
+		w.last = at
+	}
+
+	if len(w.times) <= rule.Count {
+		return 0, false
+	}
+
+	count := len(w.times)
+	w.times = nil
+
+	return count, true
+}
+
+// time returns the time a transfer counts at: the time of its block, or the
+// clock for transfers without a block time.
+func (e *Engine) time(event sink.Event) time.Time {
+	if event.BlockTime != 0 {
+		return time.Unix(int64(event.BlockTime), 0)
+	}
+
+	if e.Now != nil {
+		return e.Now()
+	}
+
+	return time.Now()
+}
+
+// sweep forgets, once a minute, the senders whose transfers all left their
+// window, so that the engine does not grow with every sender it has seen.
+func (e *Engine) sweep(at time.Time) {
+	if e.recent == nil {
+		e.recent = make(map[string]*window)
+	}
+
+	if at.Sub(e.swept) < time.Minute {
+		return
+	}
+
+	for key, w := range e.recent {
+		if at.Sub(w.last) >= w.length {
+			delete(e.recent, key)
+		}
+	}
+
+	e.swept = at
+}
+
+// describe summarizes a transfer, e.g. "transfer of 1.5 MTK from 0x… to 0x…".
+func describe(event sink.Event) string {
+	amount := event.Value
+	if value, ok := new(big.Int).SetString(event.Value, 10); ok {
+		amount = units.Format(value, event.Decimals)
+	}
+
+	token := event.Symbol
+	if token == "" {
+		token = event.Token.Hex()
+	}
+
+	return fmt.Sprintf("transfer of %s %s from %s to %s", amount, token, event.From.Hex(), event.To.Hex())
+}
+
+// Write evaluates a transfer and writes its alerts to Alerts. Every alert is
+// written even if an earlier one fails.
+func (e *Engine) Write(ctx context.Context, event sink.Event) error {
+	var errs []error
+	for _, alert := range e.Evaluate(event) {
+		if err := e.Alerts.Write(ctx, alert); err != nil {
+			errs = append(errs, fmt.Errorf("alert %s: %w", alert.Alert.Rule, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// Close closes the alert sinks.
+func (e *Engine) Close() error {
+	return e.Alerts.Close()
+}
@@ -0,0 +1,442 @@
+package alert
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	token "go-ethereum-example/gen"
+	"go-ethereum-example/pkg/sink"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+var (
+	mtk  = common.HexToAddress("0x5FbDB2315678afecb367f032d93F642f64180aa3")
+	usdt = common.HexToAddress("0xe7f1725E7734CE288F8367e1Bb143E90bb3F0512")
+
+	treasury = common.HexToAddress("0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266")
+	alice    = common.HexToAddress("0x70997970C51812dc3A010C7d01b50e0d17dc79C8")
+	bob      = common.HexToAddress("0x3C44CdDdB6a900fa2b585dd299e03d12FA4293BC")
+)
+
+// transfer returns the event of a synthetic Transfer log, as the watcher
+// delivers it.
+func transfer(tokenAddress, from, to common.Address, value string) sink.Event {
+	amount, ok := new(big.Int).SetString(value, 10)
+	if !ok {
+		panic(value)
+	}
+
+	event := sink.FromTransfer(&token.TokenTransfer{
+		From:  from,
+		To:    to,
+		Value: amount,
+		Raw: types.Log{
+			Address:     tokenAddress,
+			BlockNumber: 10,
+			TxHash:      common.BigToHash(amount),
+			Index:       1,
+		},
+	})
+
+	event.Symbol, event.Decimals = "MTK", 18
+	if tokenAddress == usdt {
+		event.Symbol, event.Decimals = "USDT", 6
+	}
+
+	return event
+}
+
+// ether scales whole MTK to base units.
+func ether(n int64) string {
+	return new(big.Int).Mul(big.NewInt(n), big.NewInt(1e18)).String()
+}
+
+func TestEvaluate(t *testing.T) {
+	tests := []struct {
+		name  string
+		rule  Rule
+		event sink.Event
+		want  string // message, empty for no alert
+	}{
+		{
+			name:  "value above",
+			rule:  Rule{ValueAbove: "1000"},
+			event: transfer(mtk, alice, bob, ether(1500)),
+			want:  "transfer of 1500 MTK from 0x70997970C51812dc3A010C7d01b50e0d17dc79C8 to 0x3C44CdDdB6a900fa2b585dd299e03d12FA4293BC, above 1000",
+		},
+		{
+			name:  "value at threshold",
+			rule:  Rule{ValueAbove: "1000"},
+			event: transfer(mtk, alice, bob, ether(1000)),
+		},
+		{
+			name:  "value scaled by decimals",
+			rule:  Rule{ValueAbove: "1000"},
+			event: transfer(usdt, alice, bob, "1000000001"),
+			want:  "transfer of 1000.000001 USDT from 0x70997970C51812dc3A010C7d01b50e0d17dc79C8 to 0x3C44CdDdB6a900fa2b585dd299e03d12FA4293BC, above 1000",
+		},
+		{
+			name:  "fractional threshold",
+			rule:  Rule{ValueAbove: "0.5"},
+			event: transfer(usdt, alice, bob, "500000"),
+		},
+		{
+			name:  "from watched address",
+			rule:  Rule{From: []common.Address{treasury}},
+			event: transfer(mtk, treasury, alice, "1"),
+			want:  "transfer of 0.000000000000000001 MTK from 0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266 to 0x70997970C51812dc3A010C7d01b50e0d17dc79C8",
+		},
+		{
+			name:  "from other address",
+			rule:  Rule{From: []common.Address{treasury}},
+			event: transfer(mtk, alice, treasury, "1"),
+		},
+		{
+			name:  "to",
+			rule:  Rule{To: []common.Address{bob}},
+			event: transfer(mtk, alice, bob, ether(1)),
+			want:  "transfer of 1 MTK from 0x70997970C51812dc3A010C7d01b50e0d17dc79C8 to 0x3C44CdDdB6a900fa2b585dd299e03d12FA4293BC",
+		},
+		{
+			name:  "involving recipient",
+			rule:  Rule{Involving: []common.Address{treasury}},
+			event: transfer(mtk, alice, treasury, ether(1)),
+			want:  "transfer of 1 MTK from 0x70997970C51812dc3A010C7d01b50e0d17dc79C8 to 0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266",
+		},
+		{
+			name:  "other token",
+			rule:  Rule{Tokens: []common.Address{usdt}, From: []common.Address{treasury}},
+			event: transfer(mtk, treasury, alice, ether(1)),
+		},
+		{
+			name:  "all conditions",
+			rule:  Rule{Tokens: []common.Address{usdt}, From: []common.Address{treasury}, ValueAbove: "10"},
+			event: transfer(usdt, treasury, alice, "10500000"),
+			want:  "transfer of 10.5 USDT from 0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266 to 0x70997970C51812dc3A010C7d01b50e0d17dc79C8, above 10",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.rule.Name = "rule"
+
+			engine, err := New(&Config{Rules: []Rule{tt.rule}}, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			alerts := engine.Evaluate(tt.event)
+
+			if tt.want == "" {
+				if len(alerts) != 0 {
+					t.Errorf("alerts %+v, want none", alerts[0].Alert)
+				}
+
+				return
+			}
+
+			if len(alerts) != 1 {
+				t.Fatalf("%d alerts, want 1", len(alerts))
+			}
+
+			alert := alerts[0]
+			if alert.Alert.Rule != "rule" || alert.Alert.Message != tt.want {
+				t.Errorf("alert %+v, want message %q", alert.Alert, tt.want)
+			}
+
+			if alert.ID != tt.event.ID+":rule" || alert.TxHash != tt.event.TxHash {
+				t.Errorf("alert event %+v of %+v", alert, tt.event)
+			}
+		})
+	}
+}
+
+func TestEvaluateSkipsOtherEvents(t *testing.T) {
+	engine, err := New(&Config{Rules: []Rule{{Name: "any"}}}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	removed := transfer(mtk, alice, bob, "1")
+	removed.Removed = true
+
+	approval := transfer(mtk, alice, bob, "1")
+	approval.Event = "Approval"
+
+	for _, event := range []sink.Event{removed, approval} {
+		if alerts := engine.Evaluate(event); len(alerts) != 0 {
+			t.Errorf("%s event removed=%t raised %d alerts", event.Event, event.Removed, len(alerts))
+		}
+	}
+}
+
+func TestEvaluateCount(t *testing.T) {
+	engine, err := New(&Config{Rules: []Rule{
+		{Name: "burst", Count: 2, Window: Duration{time.Minute}},
+	}}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The clock is far from the blocks, which set the time of transfers
+	engine.Now = func() time.Time { return time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC) }
+
+	start := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	at := func(event sink.Event, offset time.Duration) sink.Event {
+		event.BlockTime = uint64(start.Add(offset).Unix())
+		return event
+	}
+
+	// at is the offset of the block of each transfer from the start, want
+	// whether it raises an alert
+	steps := []struct {
+		at   time.Duration
+		from common.Address
+		want bool
+	}{
+		{0, alice, false},
+		{10 * time.Second, alice, false},
+		{20 * time.Second, bob, false}, // counted apart
+		{30 * time.Second, alice, true},
+
+		// The window starts over after an alert
+		{40 * time.Second, alice, false},
+		{50 * time.Second, alice, false},
+
+		// The transfer at 40s left the window
+		{100 * time.Second, alice, false},
+		{105 * time.Second, alice, true},
+	}
+
+	for i, step := range steps {
+		alerts := engine.Evaluate(at(transfer(mtk, step.from, treasury, fmt.Sprint(i+1)), step.at))
+		if got := len(alerts) == 1; got != step.want {
+			t.Errorf("transfer %d at %s: alert %t, want %t", i, step.at, got, step.want)
+		}
+	}
+
+	alerts := engine.Evaluate(at(transfer(mtk, bob, treasury, "20"), 105*time.Second))
+	alerts = append(alerts, engine.Evaluate(at(transfer(mtk, bob, treasury, "21"), 105*time.Second))...)
+	if len(alerts) != 0 {
+		t.Errorf("bob raised alerts after the first transfer left the window: %+v", alerts[0].Alert)
+	}
+
+	// Senders whose transfers left their window are forgotten
+	engine.Evaluate(at(transfer(mtk, alice, treasury, "22"), time.Hour))
+
+	if len(engine.recent) != 1 {
+		t.Errorf("%d windows after an hour, want 1", len(engine.recent))
+	}
+}
+
+// TestEvaluateCountReplay checks that transfers replayed long after their
+// blocks count at the time of their blocks, and that redelivered ones count
+// once.
+func TestEvaluateCountReplay(t *testing.T) {
+	engine, err := New(&Config{Rules: []Rule{
+		{Name: "burst", Count: 2, Window: Duration{time.Minute}},
+	}}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	start := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+
+	// Three transfers a minute apart, evaluated at once
+	var events []sink.Event
+	for i := 0; i < 3; i++ {
+		event := transfer(mtk, alice, bob, fmt.Sprint(i+1))
+		event.BlockTime = uint64(start.Add(time.Duration(i) * time.Minute).Unix())
+
+		events = append(events, event)
+	}
+
+	for i, event := range events {
+		if alerts := engine.Evaluate(event); len(alerts) != 0 {
+			t.Errorf("transfer %d raised %+v", i, alerts[0].Alert)
+		}
+	}
+
+	// The same transfer, delivered three times within its window
+	event := transfer(mtk, bob, alice, "10")
+	event.BlockTime = uint64(start.Add(time.Hour).Unix())
+
+	for i := 0; i < 3; i++ {
+		if alerts := engine.Evaluate(event); len(alerts) != 0 {
+			t.Errorf("delivery %d raised %+v", i, alerts[0].Alert)
+		}
+	}
+}
+
+func TestEvaluateUnknownDecimals(t *testing.T) {
+	engine, err := New(&Config{Rules: []Rule{
+		{Name: "large-transfer", ValueAbove: "1000"},
+		{Name: "treasury", From: []common.Address{treasury}},
+	}}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 1 MTK, which would be 10^18 tokens with zero decimals
+	event := transfer(mtk, treasury, alice, ether(1))
+	event.Decimals, event.DecimalsUnknown = 0, true
+
+	alerts := engine.Evaluate(event)
+	if len(alerts) != 1 || alerts[0].Alert.Rule != "treasury" {
+		t.Errorf("alerts %+v, want treasury only", alerts)
+	}
+}
+
+func TestEvaluateCountMessage(t *testing.T) {
+	engine, err := New(&Config{Rules: []Rule{
+		{Name: "treasury", From: []common.Address{treasury}},
+		{Name: "burst", From: []common.Address{treasury}, Count: 1, Window: Duration{time.Minute}},
+	}}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	engine.Evaluate(transfer(mtk, treasury, alice, ether(1)))
+
+	alerts := engine.Evaluate(transfer(mtk, treasury, bob, ether(2)))
+	if len(alerts) != 2 {
+		t.Fatalf("%d alerts, want one per rule", len(alerts))
+	}
+
+	want := "2 transfers from 0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266 within 1m0s, the last transfer of 2 MTK from 0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266 to 0x3C44CdDdB6a900fa2b585dd299e03d12FA4293BC"
+	if alerts[0].Alert.Rule != "treasury" || alerts[1].Alert.Rule != "burst" || alerts[1].Alert.Message != want {
+		t.Errorf("alerts %+v and %+v", alerts[0].Alert, alerts[1].Alert)
+	}
+}
+
+// recorder is a sink that keeps the events written to it, and fails them
+// when err is set.
+type recorder struct {
+	events []sink.Event
+	err    error
+}
+
+func (r *recorder) Write(ctx context.Context, event sink.Event) error {
+	r.events = append(r.events, event)
+	return r.err
+}
+
+func (r *recorder) Close() error {
+	return nil
+}
+
+func TestEngineWrite(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rules.json")
+
+	rules := `{"rules": [
+		{"name": "large-transfer", "valueAbove": "1000"},
+		{"name": "burst", "count": 1, "window": "1m"}
+	]}`
+	if err := os.WriteFile(path, []byte(rules), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	config, err := LoadConfig(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if config.Rules[1].Window.Duration != time.Minute {
+		t.Errorf("window %s, want 1m", config.Rules[1].Window)
+	}
+
+	out := new(recorder)
+
+	engine, err := New(config, out)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, event := range []sink.Event{
+		transfer(mtk, alice, bob, ether(2000)),
+		transfer(mtk, alice, bob, ether(1)),
+	} {
+		if err := engine.Write(context.Background(), event); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var got []string
+	for _, event := range out.events {
+		got = append(got, event.Alert.Rule)
+	}
+
+	if strings.Join(got, ",") != "large-transfer,burst" {
+		t.Errorf("alerts of rules %v, want large-transfer then burst", got)
+	}
+}
+
+func TestEngineFailureKeepsDelivery(t *testing.T) {
+	config := &Config{Rules: []Rule{
+		{Name: "large-transfer", ValueAbove: "1000"},
+		{Name: "any-transfer", ValueAbove: "0"},
+	}}
+
+	alerts := &recorder{err: errors.New("alert webhook down")}
+
+	engine, err := New(config, alerts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Alerts are evaluated ahead of the delivery sinks, as in subscribe
+	deliveries := new(recorder)
+	out := sink.Multi(engine, deliveries)
+
+	if err := out.Write(context.Background(), transfer(mtk, alice, bob, ether(2000))); err == nil {
+		t.Error("failed alerts not reported")
+	}
+
+	if len(alerts.events) != 2 {
+		t.Errorf("%d alerts written, want 2", len(alerts.events))
+	}
+
+	if len(deliveries.events) != 1 {
+		t.Errorf("%d events delivered, want 1", len(deliveries.events))
+	}
+}
+
+func TestLoadConfigErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		rules string
+		want  string
+	}{
+		{"no rules", `{"rules": []}`, "no rules"},
+		{"no name", `{"rules": [{"valueAbove": "1"}]}`, "without a name"},
+		{"duplicate", `{"rules": [{"name": "a"}, {"name": "a"}]}`, "duplicate rule a"},
+		{"bad value", `{"rules": [{"name": "a", "valueAbove": "lots"}]}`, "invalid valueAbove"},
+		{"negative value", `{"rules": [{"name": "a", "valueAbove": "-1"}]}`, "invalid valueAbove"},
+		{"count without window", `{"rules": [{"name": "a", "count": 3}]}`, "count and window"},
+		{"bad window", `{"rules": [{"name": "a", "count": 3, "window": "soon"}]}`, "invalid duration"},
+		{"involving and from", `{"rules": [{"name": "a", "involving": ["` + alice.Hex() + `"], "from": ["` + bob.Hex() + `"]}]}`, "cannot be combined"},
+		{"bad address", `{"rules": [{"name": "a", "from": ["0x1234"]}]}`, "hex string has length 4"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "rules.json")
+			if err := os.WriteFile(path, []byte(tt.rules), 0o644); err != nil {
+				t.Fatal(err)
+			}
+
+			_, err := LoadConfig(path)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("error %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,103 @@
+// Package allowance finds the spenders an owner has approved and revokes them.
+package allowance
+
+import (
+	"bytes"
+	"context"
+	token "go-ethereum-example/gen"
+	"go-ethereum-example/pkg/block"
+	"math/big"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// Allowance is the current allowance of one spender, with the block of the
+// latest Approval event that set it.
+type Allowance struct {
+	Spender   common.Address
+	Amount    *big.Int
+	LastBlock uint64
+}
+
+// Scan collects every spender that appears in an Approval event of owner from
+// fromBlock up to the block at and reads its allowance at that block.
+// Spenders whose allowance has been used up or revoked are included with a
+// zero amount.
+func Scan(ctx context.Context, at *block.Block, tokenInstance *token.Token, owner common.Address, fromBlock uint64) ([]Allowance, error) {
+	opts := at.CallOpts(ctx)
+
+	filterOpts := &bind.FilterOpts{Start: fromBlock, Context: ctx}
+	if !at.Pending {
+		end := at.Number.Uint64()
+		filterOpts.End = &end
+	}
+
+	it, err := tokenInstance.FilterApproval(filterOpts, []common.Address{owner}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	defer it.Close()
+
+	lastBlock := make(map[common.Address]uint64)
+
+	for it.Next() {
+		lastBlock[it.Event.Spender] = it.Event.Raw.BlockNumber
+	}
+
+	if err := it.Error(); err != nil {
+		return nil, err
+	}
+
+	allowances := make([]Allowance, 0, len(lastBlock))
+
+	for spender, block := range lastBlock {
+		amount, err := tokenInstance.Allowance(opts, owner, spender)
+		if err != nil {
+			return nil, err
+		}
+
+		allowances = append(allowances, Allowance{Spender: spender, Amount: amount, LastBlock: block})
+	}
+
+	sort.Slice(allowances, func(i, j int) bool {
+		return bytes.Compare(allowances[i].Spender[:], allowances[j].Spender[:]) < 0
+	})
+
+	return allowances, nil
+}
+
+// Revoke sets the allowance of every spender to zero, sending the approvals
+// back to back with consecutive nonces and waiting for all of them to be mined.
+func Revoke(ctx context.Context, backend bind.DeployBackend, tokenInstance *token.Token, opts *bind.TransactOpts, nonce uint64, spenders []common.Address) ([]*types.Receipt, error) {
+	txs := make([]*types.Transaction, 0, len(spenders))
+
+	for i, spender := range spenders {
+		txOpts := *opts
+		txOpts.Context = ctx
+		txOpts.Nonce = new(big.Int).SetUint64(nonce + uint64(i))
+
+		tx, err := tokenInstance.Approve(&txOpts, spender, new(big.Int))
+		if err != nil {
+			return nil, err
+		}
+
+		txs = append(txs, tx)
+	}
+
+	receipts := make([]*types.Receipt, 0, len(txs))
+
+	for _, tx := range txs {
+		receipt, err := bind.WaitMined(ctx, backend, tx)
+		if err != nil {
+			return receipts, err
+		}
+
+		receipts = append(receipts, receipt)
+	}
+
+	return receipts, nil
+}
@@ -0,0 +1,163 @@
+package allowance_test
+
+import (
+	"context"
+	"go-ethereum-example/pkg/allowance"
+	"go-ethereum-example/pkg/block"
+	"go-ethereum-example/pkg/testchain"
+	"math/big"
+	"strconv"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+var supply = new(big.Int).Mul(big.NewInt(1_000_000), big.NewInt(1e18))
+
+func TestScan(t *testing.T) {
+	chain := testchain.New(t)
+	owner, other := chain.Accounts[0], chain.Accounts[1]
+	alice, bob, carol := chain.Accounts[2].Address, chain.Accounts[3].Address, chain.Accounts[4].Address
+
+	_, tokenInstance := chain.DeployToken(owner, supply)
+
+	approve := func(from testchain.Account, spender common.Address, amount *big.Int) uint64 {
+		t.Helper()
+
+		tx, err := tokenInstance.Approve(chain.Transactor(from), spender, amount)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return chain.Receipt(tx).BlockNumber.Uint64()
+	}
+
+	first := approve(owner, alice, big.NewInt(60))
+	last := approve(owner, alice, big.NewInt(100))
+	unlimited := approve(owner, bob, math.MaxBig256)
+	approve(owner, carol, big.NewInt(5))
+	revoked := approve(owner, carol, new(big.Int))
+
+	// Approvals of another owner are not reported
+	approve(other, alice, big.NewInt(7))
+
+	header, err := chain.Client.HeaderByNumber(context.Background(), new(big.Int).SetUint64(first))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		at   string
+		want []allowance.Allowance
+	}{
+		{
+			name: "latest",
+			at:   "latest",
+			want: []allowance.Allowance{
+				{Spender: carol, Amount: new(big.Int), LastBlock: revoked},
+				{Spender: alice, Amount: big.NewInt(100), LastBlock: last},
+				{Spender: bob, Amount: math.MaxBig256, LastBlock: unlimited},
+			},
+		},
+		{
+			name: "past block",
+			at:   strconv.FormatUint(first, 10),
+			want: []allowance.Allowance{
+				{Spender: alice, Amount: big.NewInt(60), LastBlock: first},
+			},
+		},
+		{
+			name: "past block by hash",
+			at:   header.Hash().Hex(),
+			want: []allowance.Allowance{
+				{Spender: alice, Amount: big.NewInt(60), LastBlock: first},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			at, err := block.Resolve(ctx, chain.Client, tt.at)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := allowance.Scan(ctx, at, tokenInstance, owner.Address, 0)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// One entry per spender, sorted by address
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %d allowances %+v, want %d", len(got), got, len(tt.want))
+			}
+
+			for i, want := range tt.want {
+				if got[i].Spender != want.Spender || got[i].Amount.Cmp(want.Amount) != 0 || got[i].LastBlock != want.LastBlock {
+					t.Errorf("allowance %d: got %+v, want %+v", i, got[i], want)
+				}
+			}
+		})
+	}
+}
+
+func TestRevoke(t *testing.T) {
+	chain := testchain.New(t)
+	owner := chain.Accounts[0]
+	spenders := []common.Address{chain.Accounts[1].Address, chain.Accounts[2].Address}
+
+	_, tokenInstance := chain.DeployToken(owner, supply)
+
+	for _, spender := range spenders {
+		if _, err := tokenInstance.Approve(chain.Transactor(owner), spender, big.NewInt(50)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	ctx := context.Background()
+
+	nonce, err := chain.Client.PendingNonceAt(ctx, owner.Address)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	receipts, err := allowance.Revoke(ctx, chain.Client, tokenInstance, chain.Transactor(owner), nonce, spenders)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(receipts) != len(spenders) {
+		t.Fatalf("got %d receipts, want %d", len(receipts), len(spenders))
+	}
+
+	for i, receipt := range receipts {
+		if receipt.Status != types.ReceiptStatusSuccessful {
+			t.Errorf("revoke %s: status %d", spenders[i].Hex(), receipt.Status)
+		}
+
+		amount, err := tokenInstance.Allowance(&bind.CallOpts{Context: ctx}, owner.Address, spenders[i])
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if amount.Sign() != 0 {
+			t.Errorf("allowance of %s after revoke: %s", spenders[i].Hex(), amount)
+		}
+	}
+
+	// The approvals were sent with consecutive nonces
+	next, err := chain.Client.NonceAt(ctx, owner.Address, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if next != nonce+uint64(len(spenders)) {
+		t.Errorf("nonce %d after revoke, want %d", next, nonce+uint64(len(spenders)))
+	}
+}
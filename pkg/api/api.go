@@ -0,0 +1,420 @@
+// Package api serves token reads, transfer history and transfers over HTTP,
+// for clients that cannot embed Go:
+//
+//	GET  /tokens/{address}    metadata of a token
+//	GET  /balances/{holder}   balances of a holder in every token
+//	GET  /transfers           indexed transfers, by address and from a block, paged
+//	POST /transfers           send a transfer, authenticated
+//	GET  /openapi.json        OpenAPI description of the above
+//
+// Responses are JSON, with amounts as decimal strings in token units, such as
+// "1.5" for 1.5 MTK. Errors are {"error": "..."}.
+package api
+
+import (
+	"context"
+	"crypto/subtle"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go-ethereum-example/pkg/erc20"
+	"go-ethereum-example/pkg/revert"
+	"go-ethereum-example/pkg/units"
+	"go-ethereum-example/pkg/watcher"
+	"math/big"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+//go:embed openapi.json
+var openAPI []byte
+
+// Limits of GET /transfers.
+const (
+	DefaultLimit = 100
+	MaxLimit     = 1000
+)
+
+// Backend reads tokens and sends transfers.
+type Backend interface {
+	erc20.Backend
+	ethereum.BlockNumberReader
+}
+
+// Server serves the API for a list of tokens.
+type Server struct {
+	Backend Backend
+
+	// Tokens are the tokens served, other addresses are not found.
+	Tokens []common.Address
+
+	// Index holds the transfers of GET /transfers, and Watcher fills it.
+	Index   *Index
+	Watcher *watcher.Watcher
+
+	// Signer sends the transfers of POST /transfers, authenticated with
+	// APIToken as a bearer token. Transfers are disabled without either.
+	Signer   *bind.TransactOpts
+	APIToken string
+
+	// send serializes transfers, which would otherwise race for a nonce
+	send sync.Mutex
+
+	mu       sync.Mutex
+	metadata map[common.Address]*erc20.Metadata
+}
+
+// Handler routes the API.
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /tokens/{address}", s.getToken)
+	mux.HandleFunc("GET /balances/{holder}", s.getBalances)
+	mux.HandleFunc("GET /transfers", s.getTransfers)
+	mux.HandleFunc("POST /transfers", s.postTransfer)
+	mux.HandleFunc("GET /openapi.json", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(openAPI)
+	})
+
+	return mux
+}
+
+// Token is the response of GET /tokens/{address}.
+type Token struct {
+	Address     common.Address `json:"address"`
+	Name        string         `json:"name"`
+	Symbol      string         `json:"symbol"`
+	Decimals    uint8          `json:"decimals"`
+	TotalSupply string         `json:"totalSupply"`
+}
+
+func (s *Server) getToken(w http.ResponseWriter, r *http.Request) {
+	address, ok := s.token(w, r.PathValue("address"))
+	if !ok {
+		return
+	}
+
+	// Read afresh, the total supply changes with mints and burns
+	m, err := erc20.New(address, s.Backend).Metadata(&bind.CallOpts{Context: r.Context()})
+	if err != nil {
+		writeError(w, http.StatusBadGateway, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, Token{
+		Address:     address,
+		Name:        m.Name,
+		Symbol:      m.Symbol,
+		Decimals:    m.Decimals,
+		TotalSupply: units.Format(m.TotalSupply, m.Decimals),
+	})
+}
+
+// Balances is the response of GET /balances/{holder}.
+type Balances struct {
+	Holder      common.Address `json:"holder"`
+	BlockNumber uint64         `json:"blockNumber"`
+	Balances    []Balance      `json:"balances"`
+}
+
+// Balance is the balance of a holder in a token.
+type Balance struct {
+	Token    common.Address `json:"token"`
+	Symbol   string         `json:"symbol"`
+	Decimals uint8          `json:"decimals"`
+	Balance  string         `json:"balance"`
+}
+
+func (s *Server) getBalances(w http.ResponseWriter, r *http.Request) {
+	holder, ok := parseAddress(w, "holder", r.PathValue("holder"))
+	if !ok {
+		return
+	}
+
+	// Read every balance at the same block
+	head, err := s.Backend.BlockNumber(r.Context())
+	if err != nil {
+		writeError(w, http.StatusBadGateway, err)
+		return
+	}
+
+	opts := &bind.CallOpts{Context: r.Context(), BlockNumber: new(big.Int).SetUint64(head)}
+
+	response := Balances{Holder: holder, BlockNumber: head, Balances: []Balance{}}
+	for _, address := range s.Tokens {
+		m, err := s.tokenMetadata(r.Context(), address)
+		if err != nil {
+			writeError(w, http.StatusBadGateway, err)
+			return
+		}
+
+		balance, err := erc20.New(address, s.Backend).BalanceOf(opts, holder)
+		if err != nil {
+			writeError(w, http.StatusBadGateway, fmt.Errorf("balance in %s: %w", address.Hex(), err))
+			return
+		}
+
+		response.Balances = append(response.Balances, Balance{
+			Token:    address,
+			Symbol:   m.Symbol,
+			Decimals: m.Decimals,
+			Balance:  units.Format(balance, m.Decimals),
+		})
+	}
+
+	writeJSON(w, http.StatusOK, response)
+}
+
+// Transfers is the response of GET /transfers. More is set when the limit cut
+// the list short, and Next is then the cursor of the last transfer, which the
+// next page is asked after.
+type Transfers struct {
+	Transfers []Transfer `json:"transfers"`
+	More      bool       `json:"more"`
+	Next      string     `json:"next,omitempty"`
+
+	// IndexedBlock is the last block whose transfers are indexed.
+	IndexedBlock uint64 `json:"indexedBlock"`
+}
+
+// Transfer is an indexed transfer.
+type Transfer struct {
+	ID          string         `json:"id"`
+	Token       common.Address `json:"token"`
+	Symbol      string         `json:"symbol"`
+	From        common.Address `json:"from"`
+	To          common.Address `json:"to"`
+	Value       string         `json:"value"`
+	BlockNumber uint64         `json:"blockNumber"`
+	TxHash      common.Hash    `json:"txHash"`
+	LogIndex    uint           `json:"logIndex"`
+}
+
+func (s *Server) getTransfers(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	var address common.Address
+	if query.Has("address") {
+		var ok bool
+		if address, ok = parseAddress(w, "address", query.Get("address")); !ok {
+			return
+		}
+	}
+
+	fromBlock, err := parseUint(query.Get("fromBlock"), 0)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid fromBlock: %w", err))
+		return
+	}
+
+	var after *Cursor
+	if query.Has("after") {
+		if query.Has("fromBlock") {
+			writeError(w, http.StatusBadRequest, errors.New("fromBlock and after cannot be combined"))
+			return
+		}
+
+		cursor, err := ParseCursor(query.Get("after"))
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err)
+			return
+		}
+
+		after = &cursor
+	}
+
+	limit, err := parseUint(query.Get("limit"), DefaultLimit)
+	if err != nil || limit == 0 || limit > MaxLimit {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid limit %q, want 1 to %d", query.Get("limit"), MaxLimit))
+		return
+	}
+
+	events, more := s.Index.Transfers(address, fromBlock, after, int(limit))
+
+	response := Transfers{Transfers: []Transfer{}, More: more}
+	if more {
+		response.Next = CursorOf(events[len(events)-1]).String()
+	}
+	if s.Watcher != nil {
+		response.IndexedBlock = s.Watcher.Status().Last
+	}
+
+	for _, event := range events {
+		value, _ := new(big.Int).SetString(event.Value, 10)
+
+		response.Transfers = append(response.Transfers, Transfer{
+			ID:          event.ID,
+			Token:       event.Token,
+			Symbol:      event.Symbol,
+			From:        event.From,
+			To:          event.To,
+			Value:       units.Format(value, event.Decimals),
+			BlockNumber: event.BlockNumber,
+			TxHash:      event.TxHash,
+			LogIndex:    event.LogIndex,
+		})
+	}
+
+	writeJSON(w, http.StatusOK, response)
+}
+
+// TransferRequest is the body of POST /transfers.
+type TransferRequest struct {
+	Token  common.Address `json:"token"`
+	To     common.Address `json:"to"`
+	Amount string         `json:"amount"`
+}
+
+// TransferResponse is the response of POST /transfers, sent once the
+// transfer is broadcast; it may still fail to be mined.
+type TransferResponse struct {
+	TxHash common.Hash    `json:"txHash"`
+	Token  common.Address `json:"token"`
+	From   common.Address `json:"from"`
+	To     common.Address `json:"to"`
+	Amount string         `json:"amount"`
+}
+
+func (s *Server) postTransfer(w http.ResponseWriter, r *http.Request) {
+	if s.Signer == nil || s.APIToken == "" {
+		writeError(w, http.StatusNotImplemented, errors.New("transfers are disabled"))
+		return
+	}
+
+	bearer, _ := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if subtle.ConstantTimeCompare([]byte(bearer), []byte(s.APIToken)) != 1 {
+		w.Header().Set("WWW-Authenticate", "Bearer")
+		writeError(w, http.StatusUnauthorized, errors.New("invalid API token"))
+		return
+	}
+
+	var req TransferRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 1<<16)).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid body: %w", err))
+		return
+	}
+
+	if !slices.Contains(s.Tokens, req.Token) {
+		writeError(w, http.StatusNotFound, fmt.Errorf("unknown token %s", req.Token.Hex()))
+		return
+	}
+
+	if req.To == (common.Address{}) {
+		writeError(w, http.StatusBadRequest, errors.New("missing recipient"))
+		return
+	}
+
+	m, err := s.tokenMetadata(r.Context(), req.Token)
+	if err != nil {
+		writeError(w, http.StatusBadGateway, err)
+		return
+	}
+
+	amount, err := units.Parse(req.Amount, m.Decimals)
+	if err != nil || amount.Sign() <= 0 {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid amount %q for %d decimals", req.Amount, m.Decimals))
+		return
+	}
+
+	s.send.Lock()
+	defer s.send.Unlock()
+
+	opts := *s.Signer
+	opts.Context = r.Context()
+
+	tx, err := erc20.New(req.Token, s.Backend).Transfer(&opts, req.To, amount)
+	if err != nil {
+		// The simulation rejected the transfer
+		if _, reverted := revert.Data(err); reverted || errors.Is(err, erc20.ErrFalseReturned) {
+			writeError(w, http.StatusUnprocessableEntity, err)
+			return
+		}
+
+		writeError(w, http.StatusBadGateway, err)
+		return
+	}
+
+	writeJSON(w, http.StatusAccepted, TransferResponse{
+		TxHash: tx.Hash(),
+		Token:  req.Token,
+		From:   s.Signer.From,
+		To:     req.To,
+		Amount: units.Format(amount, m.Decimals),
+	})
+}
+
+// token parses the address of a served token, or writes an error.
+func (s *Server) token(w http.ResponseWriter, value string) (common.Address, bool) {
+	address, ok := parseAddress(w, "token", value)
+	if !ok {
+		return common.Address{}, false
+	}
+
+	if !slices.Contains(s.Tokens, address) {
+		writeError(w, http.StatusNotFound, fmt.Errorf("unknown token %s", address.Hex()))
+		return common.Address{}, false
+	}
+
+	return address, true
+}
+
+// tokenMetadata returns the metadata of a token, read once and cached for
+// its symbol and decimals.
+func (s *Server) tokenMetadata(ctx context.Context, address common.Address) (*erc20.Metadata, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if m, ok := s.metadata[address]; ok {
+		return m, nil
+	}
+
+	m, err := erc20.New(address, s.Backend).Metadata(&bind.CallOpts{Context: ctx})
+	if err != nil {
+		return nil, fmt.Errorf("metadata of %s: %w", address.Hex(), err)
+	}
+
+	if s.metadata == nil {
+		s.metadata = make(map[common.Address]*erc20.Metadata)
+	}
+
+	s.metadata[address] = m
+
+	return m, nil
+}
+
+func parseAddress(w http.ResponseWriter, name, value string) (common.Address, bool) {
+	if !common.IsHexAddress(value) {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid %s address %q", name, value))
+		return common.Address{}, false
+	}
+
+	return common.HexToAddress(value), true
+}
+
+func parseUint(value string, fallback uint64) (uint64, error) {
+	if value == "" {
+		return fallback, nil
+	}
+
+	return strconv.ParseUint(value, 10, 64)
+}
+
+func writeJSON(w http.ResponseWriter, code int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, code int, err error) {
+	writeJSON(w, code, struct {
+		Error string `json:"error"`
+	}{err.Error()})
+}
@@ -0,0 +1,377 @@
+package api_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"go-ethereum-example/pkg/api"
+	"go-ethereum-example/pkg/sink"
+	"go-ethereum-example/pkg/testchain"
+	"go-ethereum-example/pkg/watcher"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var supply = new(big.Int).Mul(big.NewInt(1_000_000), big.NewInt(1e18))
+
+const apiToken = "s3cret"
+
+// serve serves the API for the token, signing with the first account, with
+// the transfers of the chain indexed by a watcher.
+func serve(t *testing.T, chain *testchain.Chain, address common.Address) (*httptest.Server, *watcher.Watcher) {
+	t.Helper()
+
+	index := api.NewIndex()
+
+	w := &watcher.Watcher{
+		Dial:      func(ctx context.Context) (watcher.Client, error) { return chain.Client, nil },
+		Tokens:    []common.Address{address},
+		Sink:      index,
+		FromBlock: 1,
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	go w.Run(ctx)
+
+	server := httptest.NewServer((&api.Server{
+		Backend:  chain.Client,
+		Tokens:   []common.Address{address},
+		Index:    index,
+		Watcher:  w,
+		Signer:   chain.Transactor(chain.Accounts[0]),
+		APIToken: apiToken,
+	}).Handler())
+	t.Cleanup(server.Close)
+
+	return server, w
+}
+
+// do sends a request and decodes the JSON response into v, returning the
+// status code.
+func do(t *testing.T, server *httptest.Server, method, path, bearer string, body any, v any) int {
+	t.Helper()
+
+	var reader bytes.Buffer
+	if body != nil {
+		if err := json.NewEncoder(&reader).Encode(body); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	req, err := http.NewRequest(method, server.URL+path, &reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if bearer != "" {
+		req.Header.Set("Authorization", "Bearer "+bearer)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	if ct := resp.Header.Get("Content-Type"); ct != "application/json" {
+		t.Errorf("%s %s: content type %q", method, path, ct)
+	}
+
+	if v != nil {
+		if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+			t.Fatalf("%s %s: %v", method, path, err)
+		}
+	}
+
+	return resp.StatusCode
+}
+
+// waitIndexed waits until the watcher indexed the head block.
+func waitIndexed(t *testing.T, chain *testchain.Chain, w *watcher.Watcher) {
+	t.Helper()
+
+	head := chain.Head()
+
+	deadline := time.Now().Add(10 * time.Second)
+	for w.Status().Last < head {
+		if time.Now().After(deadline) {
+			t.Fatalf("block %d not indexed", head)
+		}
+
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+type apiError struct {
+	Error string `json:"error"`
+}
+
+func TestGetToken(t *testing.T) {
+	chain := testchain.New(t)
+	address, _ := chain.DeployToken(chain.Accounts[0], supply)
+	server, _ := serve(t, chain, address)
+
+	var token api.Token
+	if code := do(t, server, http.MethodGet, "/tokens/"+address.Hex(), "", nil, &token); code != http.StatusOK {
+		t.Fatalf("status %d", code)
+	}
+
+	want := api.Token{Address: address, Name: "MyToken", Symbol: "MTK", Decimals: 18, TotalSupply: "1000000"}
+	if token != want {
+		t.Errorf("token %+v, want %+v", token, want)
+	}
+
+	for path, code := range map[string]int{
+		"/tokens/0x1234": http.StatusBadRequest,
+		"/tokens/" + chain.Accounts[1].Address.Hex(): http.StatusNotFound,
+	} {
+		var e apiError
+		if got := do(t, server, http.MethodGet, path, "", nil, &e); got != code || e.Error == "" {
+			t.Errorf("GET %s: %d %+v, want %d", path, got, e, code)
+		}
+	}
+}
+
+func TestTransfers(t *testing.T) {
+	chain := testchain.New(t)
+	owner, alice, bob := chain.Accounts[0], chain.Accounts[1], chain.Accounts[2]
+
+	address, _ := chain.DeployToken(owner, supply)
+	server, w := serve(t, chain, address)
+
+	// Unauthenticated and invalid transfers are refused before signing
+	for _, tt := range []struct {
+		name   string
+		bearer string
+		body   any
+		code   int
+		error  string
+	}{
+		{"no token", "", api.TransferRequest{Token: address, To: alice.Address, Amount: "1"}, http.StatusUnauthorized, "invalid API token"},
+		{"wrong token", "guess", api.TransferRequest{Token: address, To: alice.Address, Amount: "1"}, http.StatusUnauthorized, "invalid API token"},
+		{"bad body", apiToken, "transfer", http.StatusBadRequest, "invalid body"},
+		{"unknown token", apiToken, api.TransferRequest{Token: alice.Address, To: bob.Address, Amount: "1"}, http.StatusNotFound, "unknown token"},
+		{"no recipient", apiToken, api.TransferRequest{Token: address, Amount: "1"}, http.StatusBadRequest, "missing recipient"},
+		{"bad amount", apiToken, api.TransferRequest{Token: address, To: alice.Address, Amount: "1.0000000000000000001"}, http.StatusBadRequest, "invalid amount"},
+		{"zero amount", apiToken, api.TransferRequest{Token: address, To: alice.Address, Amount: "0"}, http.StatusBadRequest, "invalid amount"},
+		{"reverts", apiToken, api.TransferRequest{Token: address, To: alice.Address, Amount: "2000000"}, http.StatusUnprocessableEntity, "ERC20InsufficientBalance"},
+	} {
+		var e apiError
+		if code := do(t, server, http.MethodPost, "/transfers", tt.bearer, tt.body, &e); code != tt.code || !strings.Contains(e.Error, tt.error) {
+			t.Errorf("%s: %d %q, want %d %q", tt.name, code, e.Error, tt.code, tt.error)
+		}
+	}
+
+	// Send 1.5 and 2.25 MTK to alice, and 3 to bob
+	for _, req := range []api.TransferRequest{
+		{Token: address, To: alice.Address, Amount: "1.5"},
+		{Token: address, To: alice.Address, Amount: "2.25"},
+		{Token: address, To: bob.Address, Amount: "3"},
+	} {
+		var resp api.TransferResponse
+		if code := do(t, server, http.MethodPost, "/transfers", apiToken, req, &resp); code != http.StatusAccepted {
+			t.Fatalf("POST %+v: status %d", req, code)
+		}
+
+		if resp.From != owner.Address || resp.To != req.To || resp.Amount != req.Amount || resp.TxHash == (common.Hash{}) {
+			t.Errorf("response %+v to %+v", resp, req)
+		}
+	}
+
+	var balances api.Balances
+	if code := do(t, server, http.MethodGet, "/balances/"+alice.Address.Hex(), "", nil, &balances); code != http.StatusOK {
+		t.Fatalf("status %d", code)
+	}
+
+	if len(balances.Balances) != 1 || balances.Balances[0].Balance != "3.75" || balances.Balances[0].Symbol != "MTK" || balances.BlockNumber == 0 {
+		t.Errorf("balances %+v", balances)
+	}
+
+	waitIndexed(t, chain, w)
+
+	var transfers api.Transfers
+	if code := do(t, server, http.MethodGet, "/transfers?address="+alice.Address.Hex(), "", nil, &transfers); code != http.StatusOK {
+		t.Fatalf("status %d", code)
+	}
+
+	var values []string
+	for _, transfer := range transfers.Transfers {
+		values = append(values, transfer.Value)
+
+		if transfer.To != alice.Address || transfer.Symbol != "MTK" {
+			t.Errorf("transfer %+v", transfer)
+		}
+	}
+
+	if strings.Join(values, ",") != "1.5,2.25" || transfers.More || transfers.IndexedBlock == 0 {
+		t.Errorf("transfers of alice %v, more %t, indexed block %d", values, transfers.More, transfers.IndexedBlock)
+	}
+
+	// All transfers from the block of the first one to alice on, which
+	// leaves out the mint of the deployment, one page at a time
+	first := transfers.Transfers[0].BlockNumber
+
+	transfers = api.Transfers{}
+	do(t, server, http.MethodGet, fmt.Sprintf("/transfers?limit=2&fromBlock=%d", first), "", nil, &transfers)
+
+	last := transfers.Transfers[1]
+
+	if len(transfers.Transfers) != 2 || !transfers.More || transfers.Transfers[0].Value != "1.5" || transfers.Next != fmt.Sprintf("%d:%d", last.BlockNumber, last.LogIndex) {
+		t.Errorf("first page %+v", transfers)
+	}
+
+	// The next page starts after the last transfer
+	next := transfers.Next
+
+	transfers = api.Transfers{}
+	do(t, server, http.MethodGet, "/transfers?limit=2&after="+next, "", nil, &transfers)
+
+	if len(transfers.Transfers) != 1 || transfers.More || transfers.Next != "" || transfers.Transfers[0].To != bob.Address {
+		t.Errorf("second page %+v", transfers)
+	}
+
+	for _, query := range []string{"address=alice", "fromBlock=-1", "limit=0", "limit=1001", "after=2", "after=2:x", "after=2:0&fromBlock=1"} {
+		var e apiError
+		if code := do(t, server, http.MethodGet, "/transfers?"+query, "", nil, &e); code != http.StatusBadRequest {
+			t.Errorf("GET /transfers?%s: %d %+v", query, code, e)
+		}
+	}
+}
+
+func TestTransfersDisabled(t *testing.T) {
+	server := httptest.NewServer((&api.Server{Index: api.NewIndex()}).Handler())
+	defer server.Close()
+
+	resp, err := http.Post(server.URL+"/transfers", "application/json", strings.NewReader("{}"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusNotImplemented {
+		t.Errorf("status %d, want 501", resp.StatusCode)
+	}
+}
+
+func TestIndexRemoved(t *testing.T) {
+	index := api.NewIndex()
+	alice, bob := common.HexToAddress("0x01"), common.HexToAddress("0x02")
+
+	transfer := sink.Event{ID: "0xaa:0", Event: "Transfer", From: alice, To: bob, Value: "1", BlockNumber: 5}
+	approval := sink.Event{ID: "0xbb:0", Event: "Approval", From: alice, To: bob, Value: "1", BlockNumber: 5}
+
+	for _, event := range []sink.Event{transfer, approval} {
+		index.Write(context.Background(), event)
+	}
+
+	if events, _ := index.Transfers(bob, 0, nil, 10); len(events) != 1 {
+		t.Fatalf("%d transfers of bob, want the transfer only", len(events))
+	}
+
+	transfer.Removed = true
+	index.Write(context.Background(), transfer)
+
+	for _, address := range []common.Address{{}, alice, bob} {
+		if events, _ := index.Transfers(address, 0, nil, 10); len(events) != 0 {
+			t.Errorf("transfers of %s after removal: %+v", address.Hex(), events)
+		}
+	}
+}
+
+// TestIndexPages checks that paging by cursor goes through a block with more
+// transfers than the limit, and that redelivered transfers are indexed once.
+func TestIndexPages(t *testing.T) {
+	index := api.NewIndex()
+	alice, bob := common.HexToAddress("0x01"), common.HexToAddress("0x02")
+
+	var events []sink.Event
+	for i := 0; i < 5; i++ {
+		events = append(events, sink.Event{ID: fmt.Sprintf("0xaa:%d", i), Event: "Transfer", From: alice, To: bob, Value: "1", BlockNumber: 5, LogIndex: uint(i)})
+	}
+	events = append(events, sink.Event{ID: "0xbb:0", Event: "Transfer", From: bob, To: alice, Value: "1", BlockNumber: 6})
+
+	for _, event := range append(events, events[:3]...) {
+		index.Write(context.Background(), event)
+	}
+
+	var ids []string
+	var after *api.Cursor
+
+	for pages := 0; ; pages++ {
+		if pages == len(events) {
+			t.Fatal("paging does not end")
+		}
+
+		page, more := index.Transfers(alice, 0, after, 2)
+		for _, event := range page {
+			ids = append(ids, event.ID)
+		}
+
+		if !more {
+			break
+		}
+
+		cursor, err := api.ParseCursor(api.CursorOf(page[len(page)-1]).String())
+		if err != nil {
+			t.Fatal(err)
+		}
+		after = &cursor
+	}
+
+	if strings.Join(ids, ",") != "0xaa:0,0xaa:1,0xaa:2,0xaa:3,0xaa:4,0xbb:0" {
+		t.Errorf("paged %v", ids)
+	}
+
+	// A transfer removed by a reorg is indexed again when its transaction is
+	// mined again
+	last := events[len(events)-1]
+
+	removed := last
+	removed.Removed = true
+	index.Write(context.Background(), removed)
+
+	if page, _ := index.Transfers(bob, 6, nil, 10); len(page) != 0 {
+		t.Errorf("transfers of bob after removal %+v", page)
+	}
+
+	index.Write(context.Background(), last)
+
+	if page, _ := index.Transfers(bob, 6, nil, 10); len(page) != 1 || page[0].ID != last.ID {
+		t.Errorf("transfers of bob after reindexing %+v", page)
+	}
+}
+
+func TestOpenAPI(t *testing.T) {
+	server := httptest.NewServer((&api.Server{Index: api.NewIndex()}).Handler())
+	defer server.Close()
+
+	var spec struct {
+		Paths map[string]map[string]json.RawMessage `json:"paths"`
+	}
+	if code := do(t, server, http.MethodGet, "/openapi.json", "", nil, &spec); code != http.StatusOK {
+		t.Fatalf("status %d", code)
+	}
+
+	// Every route is documented
+	for _, route := range []string{
+		"get /tokens/{address}",
+		"get /balances/{holder}",
+		"get /transfers",
+		"post /transfers",
+		"get /openapi.json",
+	} {
+		method, path, _ := strings.Cut(route, " ")
+		if _, ok := spec.Paths[path][method]; !ok {
+			t.Errorf("%s is not documented", route)
+		}
+	}
+}
@@ -0,0 +1,154 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"go-ethereum-example/pkg/sink"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Index keeps the transfers delivered by a watcher in memory, by address, for
+// GET /transfers. It is a sink, so a watcher fills it:
+//
+//	index := api.NewIndex()
+//	w := &watcher.Watcher{Dial: dial, Tokens: tokens, Sink: index, FromBlock: 1}
+type Index struct {
+	mu        sync.RWMutex
+	all       []sink.Event
+	byAddress map[common.Address][]sink.Event
+	ids       map[string]bool
+}
+
+// NewIndex returns an empty index.
+func NewIndex() *Index {
+	return &Index{byAddress: make(map[common.Address][]sink.Event), ids: make(map[string]bool)}
+}
+
+// Cursor is the position of a transfer in chain order, written
+// "<block number>:<log index>" in GET /transfers.
+type Cursor struct {
+	BlockNumber uint64
+	LogIndex    uint
+}
+
+// ParseCursor reads a cursor written by Cursor.String.
+func ParseCursor(s string) (Cursor, error) {
+	block, index, ok := strings.Cut(s, ":")
+	if !ok {
+		return Cursor{}, fmt.Errorf("invalid cursor %q", s)
+	}
+
+	blockNumber, err := strconv.ParseUint(block, 10, 64)
+	if err != nil {
+		return Cursor{}, fmt.Errorf("invalid cursor %q", s)
+	}
+
+	logIndex, err := strconv.ParseUint(index, 10, 32)
+	if err != nil {
+		return Cursor{}, fmt.Errorf("invalid cursor %q", s)
+	}
+
+	return Cursor{BlockNumber: blockNumber, LogIndex: uint(logIndex)}, nil
+}
+
+// CursorOf returns the position of a transfer.
+func CursorOf(event sink.Event) Cursor {
+	return Cursor{BlockNumber: event.BlockNumber, LogIndex: event.LogIndex}
+}
+
+// String formats the cursor as ParseCursor reads it.
+func (c Cursor) String() string {
+	return fmt.Sprintf("%d:%d", c.BlockNumber, c.LogIndex)
+}
+
+// before reports whether the transfer at c comes before event.
+func (c Cursor) before(event sink.Event) bool {
+	if c.BlockNumber != event.BlockNumber {
+		return c.BlockNumber < event.BlockNumber
+	}
+
+	return c.LogIndex < event.LogIndex
+}
+
+// Write adds a transfer, or drops it when a reorg removed it. Approvals and
+// transfers already indexed, by ID, are ignored. Events arrive in chain order,
+// which keeps the lists sorted.
+func (x *Index) Write(ctx context.Context, event sink.Event) error {
+	if event.Event != "Transfer" {
+		return nil
+	}
+
+	x.mu.Lock()
+	defer x.mu.Unlock()
+
+	addresses := []common.Address{event.From}
+	if event.To != event.From {
+		addresses = append(addresses, event.To)
+	}
+
+	if event.Removed {
+		remove := func(e sink.Event) bool { return e.ID == event.ID }
+
+		x.all = slices.DeleteFunc(x.all, remove)
+		for _, address := range addresses {
+			x.byAddress[address] = slices.DeleteFunc(x.byAddress[address], remove)
+		}
+
+		delete(x.ids, event.ID)
+
+		return nil
+	}
+
+	// Redelivered after a reconnect or a restart from an earlier block
+	if x.ids[event.ID] {
+		return nil
+	}
+
+	x.ids[event.ID] = true
+
+	x.all = append(x.all, event)
+	for _, address := range addresses {
+		x.byAddress[address] = append(x.byAddress[address], event)
+	}
+
+	return nil
+}
+
+// Close does nothing.
+func (x *Index) Close() error {
+	return nil
+}
+
+// Transfers returns up to limit transfers, of address if it is not the zero
+// address, in chain order, and whether there are more. They start after the
+// transfer at after if it is set, from fromBlock otherwise.
+func (x *Index) Transfers(address common.Address, fromBlock uint64, after *Cursor, limit int) ([]sink.Event, bool) {
+	x.mu.RLock()
+	defer x.mu.RUnlock()
+
+	events := x.all
+	if address != (common.Address{}) {
+		events = x.byAddress[address]
+	}
+
+	start := sort.Search(len(events), func(i int) bool {
+		if after != nil {
+			return after.before(events[i])
+		}
+
+		return events[i].BlockNumber >= fromBlock
+	})
+	events = events[start:]
+
+	if len(events) > limit {
+		return slices.Clone(events[:limit]), true
+	}
+
+	return slices.Clone(events), false
+}
@@ -0,0 +1,134 @@
+// Package block resolves the -block flag of the read commands, so their
+// results are pinned to one exact block and can be reproduced later:
+//
+//	at, err := block.Resolve(ctx, client, "finalized")
+//	balance, err := tokenInstance.BalanceOf(at.CallOpts(ctx), holder)
+//	fmt.Printf("At %s\n", at)
+package block
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// Usage describes the accepted values, for flag help texts.
+const Usage = "block to read at: a number, a hash, or latest, pending, safe or finalized"
+
+// Parse parses a decimal or hex block number, a block hash or one of the tags
+// latest, pending, safe, finalized and earliest.
+func Parse(s string) (rpc.BlockNumberOrHash, error) {
+	s = strings.TrimSpace(s)
+
+	switch strings.ToLower(s) {
+	case "", "latest":
+		return rpc.BlockNumberOrHashWithNumber(rpc.LatestBlockNumber), nil
+	case "pending":
+		return rpc.BlockNumberOrHashWithNumber(rpc.PendingBlockNumber), nil
+	case "safe":
+		return rpc.BlockNumberOrHashWithNumber(rpc.SafeBlockNumber), nil
+	case "finalized":
+		return rpc.BlockNumberOrHashWithNumber(rpc.FinalizedBlockNumber), nil
+	case "earliest":
+		return rpc.BlockNumberOrHashWithNumber(rpc.EarliestBlockNumber), nil
+	}
+
+	if len(s) == 66 && strings.HasPrefix(s, "0x") {
+		hash, err := hexutil.Decode(s)
+		if err != nil {
+			return rpc.BlockNumberOrHash{}, fmt.Errorf("invalid block hash %q: %w", s, err)
+		}
+
+		return rpc.BlockNumberOrHashWithHash(common.BytesToHash(hash), true), nil
+	}
+
+	var (
+		number uint64
+		err    error
+	)
+
+	if strings.HasPrefix(s, "0x") {
+		number, err = hexutil.DecodeUint64(s)
+	} else {
+		number, err = strconv.ParseUint(s, 10, 63)
+	}
+
+	if err != nil {
+		return rpc.BlockNumberOrHash{}, fmt.Errorf("invalid block %q: want a number, a hash or a tag", s)
+	}
+
+	return rpc.BlockNumberOrHashWithNumber(rpc.BlockNumber(number)), nil
+}
+
+// HeaderReader reads block headers.
+type HeaderReader interface {
+	HeaderByHash(ctx context.Context, hash common.Hash) (*types.Header, error)
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+}
+
+// Block is a resolved block.
+type Block struct {
+	Number  *big.Int
+	Hash    common.Hash
+	Pending bool // the pending block, whose hash is not final
+
+	byHash bool // given by hash, which pins it across reorgs
+}
+
+// Resolve parses s and looks up the block it refers to.
+func Resolve(ctx context.Context, client HeaderReader, s string) (*Block, error) {
+	ref, err := Parse(s)
+	if err != nil {
+		return nil, err
+	}
+
+	var header *types.Header
+
+	if hash, ok := ref.Hash(); ok {
+		header, err = client.HeaderByHash(ctx, hash)
+	} else {
+		number, _ := ref.Number()
+		header, err = client.HeaderByNumber(ctx, big.NewInt(number.Int64()))
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("resolve block %q: %w", s, err)
+	}
+
+	number, _ := ref.Number()
+
+	_, byHash := ref.Hash()
+
+	return &Block{Number: header.Number, Hash: header.Hash(), Pending: number == rpc.PendingBlockNumber, byHash: byHash}, nil
+}
+
+// CallOpts returns call options reading the state of the block. A block
+// given by hash is read by hash, so a reorg fails the read instead of
+// reading another block. Other blocks are pinned by number; the hash printed
+// with the result tells whether a later run read the same block.
+func (b *Block) CallOpts(ctx context.Context) *bind.CallOpts {
+	switch {
+	case b.Pending:
+		return &bind.CallOpts{Context: ctx, Pending: true}
+	case b.byHash:
+		return &bind.CallOpts{Context: ctx, BlockHash: b.Hash}
+	}
+
+	return &bind.CallOpts{Context: ctx, BlockNumber: b.Number}
+}
+
+func (b *Block) String() string {
+	if b.Pending {
+		return fmt.Sprintf("pending block %d", b.Number)
+	}
+
+	return fmt.Sprintf("block %d (%s)", b.Number, b.Hash.Hex())
+}
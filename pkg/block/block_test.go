@@ -0,0 +1,127 @@
+package block_test
+
+import (
+	"context"
+	"go-ethereum-example/pkg/block"
+	"go-ethereum-example/pkg/testchain"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		in     string
+		number rpc.BlockNumber
+		hash   bool
+		err    bool
+	}{
+		{in: "latest", number: rpc.LatestBlockNumber},
+		{in: "", number: rpc.LatestBlockNumber},
+		{in: "Pending", number: rpc.PendingBlockNumber},
+		{in: "safe", number: rpc.SafeBlockNumber},
+		{in: "finalized", number: rpc.FinalizedBlockNumber},
+		{in: "earliest", number: rpc.EarliestBlockNumber},
+		{in: "1234", number: 1234},
+		{in: "0x4d2", number: 1234},
+		{in: "0x88e96d4537bea4d9c05d12549907b32561d3bf31f45aae734cdc119f13406cb6", hash: true},
+		{in: "-1", err: true},
+		{in: "head", err: true},
+		{in: "0x88e96d4537bea4d9c05d12549907b32561d3bf31f45aae734cdc119f13406cbz", err: true},
+	}
+
+	for _, tt := range tests {
+		ref, err := block.Parse(tt.in)
+		if tt.err {
+			if err == nil {
+				t.Errorf("Parse(%q) succeeded", tt.in)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("Parse(%q): %v", tt.in, err)
+			continue
+		}
+
+		if _, ok := ref.Hash(); ok != tt.hash {
+			t.Errorf("Parse(%q) hash %v, want %v", tt.in, ok, tt.hash)
+		}
+
+		if number, ok := ref.Number(); !tt.hash && (!ok || number != tt.number) {
+			t.Errorf("Parse(%q) number %d, want %d", tt.in, number, tt.number)
+		}
+	}
+}
+
+func TestResolve(t *testing.T) {
+	chain := testchain.New(t)
+	ctx := context.Background()
+	owner, recipient := chain.Accounts[0], chain.Accounts[1]
+
+	_, tokenInstance := chain.DeployToken(owner, big.NewInt(1000))
+
+	before, err := block.Resolve(ctx, chain.Client, "latest")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := tokenInstance.Transfer(chain.Transactor(owner), recipient.Address, big.NewInt(10)); err != nil {
+		t.Fatal(err)
+	}
+
+	latest, err := block.Resolve(ctx, chain.Client, "latest")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if latest.Number.Uint64() != before.Number.Uint64()+1 {
+		t.Fatalf("latest block %d, want %d", latest.Number, before.Number.Uint64()+1)
+	}
+
+	// The block before the transfer, by number and by hash
+	for _, s := range []string{before.Number.String(), before.Hash.Hex()} {
+		at, err := block.Resolve(ctx, chain.Client, s)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if at.Hash != before.Hash {
+			t.Errorf("%s resolved to %s", s, at)
+		}
+
+		// A hash pins the read to the block even across a reorg
+		opts := at.CallOpts(ctx)
+		if byHash := s == before.Hash.Hex(); (opts.BlockHash == before.Hash) != byHash || (opts.BlockNumber != nil) == byHash {
+			t.Errorf("%s: call options %+v", s, opts)
+		}
+
+		balance, err := tokenInstance.BalanceOf(opts, recipient.Address)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if balance.Sign() != 0 {
+			t.Errorf("balance at %s is %s, want 0", at, balance)
+		}
+	}
+
+	balance, err := tokenInstance.BalanceOf(latest.CallOpts(ctx), recipient.Address)
+	if err != nil || balance.Int64() != 10 {
+		t.Errorf("balance at %s is %v: %v", latest, balance, err)
+	}
+
+	pending, err := block.Resolve(ctx, chain.Client, "pending")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !pending.Pending || !pending.CallOpts(ctx).Pending {
+		t.Errorf("pending resolved to %s", pending)
+	}
+
+	if _, err := block.Resolve(ctx, chain.Client, "1000"); err == nil {
+		t.Error("resolved a block in the future")
+	}
+}
@@ -0,0 +1,137 @@
+package compile
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Artifacts returns the build artifacts of a contract keyed by file name, as
+// solc --abi --bin --metadata --storage-layout --pretty-json -o would write
+// them: <name>.abi, <name>.bin, <name>_meta.json and <name>_storage.json.
+func Artifacts(name string, c Contract) (map[string][]byte, error) {
+	abi, err := PrettyJSON(c.ABI)
+	if err != nil {
+		return nil, err
+	}
+
+	meta, err := IndentMetadata([]byte(c.Metadata))
+	if err != nil {
+		return nil, fmt.Errorf("%s metadata: %w", name, err)
+	}
+
+	storage, err := PrettyJSON(c.StorageLayout)
+	if err != nil {
+		return nil, fmt.Errorf("%s storage layout: %w", name, err)
+	}
+
+	return map[string][]byte{
+		name + ".abi":          abi,
+		name + ".bin":          []byte(c.EVM.Bytecode.Object),
+		name + "_meta.json":    meta,
+		name + "_storage.json": storage,
+	}, nil
+}
+
+// IndentMetadata indents the compact metadata of solc by four spaces, keeping
+// its key order, so that build/<name>_meta.json stays readable and diffable.
+func IndentMetadata(metadata []byte) ([]byte, error) {
+	var b bytes.Buffer
+	if err := json.Indent(&b, metadata, "", "    "); err != nil {
+		return nil, err
+	}
+
+	return b.Bytes(), nil
+}
+
+// PrettyJSON re-indents JSON the way solc's --pretty-json does: two-space
+// indentation, sorted keys, and non-empty arrays opened on their own line.
+func PrettyJSON(raw []byte) ([]byte, error) {
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+
+	var v interface{}
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+
+	var b bytes.Buffer
+	if err := writePretty(&b, v, 0); err != nil {
+		return nil, err
+	}
+
+	return b.Bytes(), nil
+}
+
+func writePretty(b *bytes.Buffer, v interface{}, indent int) error {
+	pad := strings.Repeat(" ", indent)
+
+	switch v := v.(type) {
+	case map[string]interface{}:
+		if len(v) == 0 {
+			b.WriteString("{}")
+			return nil
+		}
+
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		b.WriteString("{\n")
+		for i, k := range keys {
+			key, _ := json.Marshal(k)
+			b.WriteString(pad + "  ")
+			b.Write(key)
+			b.WriteByte(':')
+
+			if items, ok := v[k].([]interface{}); ok && len(items) > 0 {
+				b.WriteString("\n" + pad + "  ")
+			} else {
+				b.WriteByte(' ')
+			}
+
+			if err := writePretty(b, v[k], indent+2); err != nil {
+				return err
+			}
+
+			if i < len(keys)-1 {
+				b.WriteByte(',')
+			}
+			b.WriteByte('\n')
+		}
+		b.WriteString(pad + "}")
+
+	case []interface{}:
+		if len(v) == 0 {
+			b.WriteString("[]")
+			return nil
+		}
+
+		b.WriteString("[\n")
+		for i, item := range v {
+			b.WriteString(pad + "  ")
+			if err := writePretty(b, item, indent+2); err != nil {
+				return err
+			}
+
+			if i < len(v)-1 {
+				b.WriteByte(',')
+			}
+			b.WriteByte('\n')
+		}
+		b.WriteString(pad + "]")
+
+	default:
+		enc, err := json.Marshal(v)
+		if err != nil {
+			return err
+		}
+		b.Write(enc)
+	}
+
+	return nil
+}
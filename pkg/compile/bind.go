@@ -0,0 +1,16 @@
+package compile
+
+import (
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+)
+
+// Binding generates an abigen Go binding for a contract, equivalent to
+// abigen --abi=... --bin=... --pkg=pkg --type=typ.
+func Binding(pkg, typ string, abi []byte, bin string) ([]byte, error) {
+	code, err := bind.Bind([]string{typ}, []string{string(abi)}, []string{bin}, nil, pkg, bind.LangGo, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return []byte(code), nil
+}
@@ -0,0 +1,117 @@
+package compile
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"regexp"
+)
+
+// ConfigFile is the location of the pinned compiler configuration, relative
+// to the repository root.
+const ConfigFile = "contracts/compile.json"
+
+// Config pins everything that affects the compiler output, so that every
+// machine produces byte-identical artifacts and bindings.
+type Config struct {
+	Solc       string        `json:"solc"` // required compiler version, e.g. 0.8.21
+	Optimizer  Optimizer     `json:"optimizer"`
+	EVMVersion string        `json:"evmVersion"`
+	Remappings []string      `json:"remappings"`
+	Build      string        `json:"build"`   // artifact directory
+	Package    string        `json:"package"` // Go package of the bindings
+	Targets    []Target      `json:"targets"`
+	Errors     *ErrorsTarget `json:"errors,omitempty"`
+}
+
+// Target is a contract that gets a Go binding. Artifacts are written for every
+// contract in the compiler output, dependencies included.
+type Target struct {
+	Source   string `json:"source"`   // Solidity file, relative to the root
+	Contract string `json:"contract"` // contract name in the source
+	Binding  string `json:"binding"`  // Go file, relative to the root
+	Type     string `json:"type"`     // Go type of the binding
+}
+
+// LoadConfig reads a compiler configuration.
+func LoadConfig(path string) (*Config, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var cfg Config
+	if err := json.Unmarshal(b, &cfg); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	if cfg.Solc == "" || cfg.Build == "" || cfg.Package == "" {
+		return nil, fmt.Errorf("%s: solc, build and package are required", path)
+	}
+
+	return &cfg, nil
+}
+
+// Save writes the configuration back to path.
+func (c *Config) Save(path string) error {
+	b, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, append(b, '\n'), 0o644)
+}
+
+// AddTarget adds a target, replacing any existing target for the same contract.
+func (c *Config) AddTarget(t Target) {
+	for i := range c.Targets {
+		if c.Targets[i].Contract == t.Contract {
+			c.Targets[i] = t
+			return
+		}
+	}
+
+	c.Targets = append(c.Targets, t)
+}
+
+// Input returns the standard JSON input compiling every target source.
+func (c *Config) Input() *Input {
+	sources := make(map[string]Source)
+	for _, t := range c.Targets {
+		sources[t.Source] = Source{URLs: []string{t.Source}}
+	}
+
+	return &Input{
+		Language: "Solidity",
+		Sources:  sources,
+		Settings: Settings{
+			Remappings: c.Remappings,
+			Optimizer:  c.Optimizer,
+			EVMVersion: c.EVMVersion,
+			Metadata:   &MetadataSettings{UseLiteralContent: true, BytecodeHash: "ipfs"},
+			OutputSelection: map[string]map[string][]string{
+				"*": {"*": {"abi", "evm.bytecode.object", "metadata", "storageLayout"}},
+			},
+		},
+	}
+}
+
+var versionPattern = regexp.MustCompile(`Version: (\d+\.\d+\.\d+)\+commit\.[0-9a-f]+`)
+
+// Version returns the version of the solc binary, e.g. 0.8.21.
+func Version(ctx context.Context) (string, error) {
+	out, err := exec.CommandContext(ctx, Binary(), "--version").Output()
+	if err != nil {
+		return "", fmt.Errorf("%s --version: %w", Binary(), err)
+	}
+
+	m := versionPattern.FindSubmatch(out)
+	if m == nil {
+		return "", fmt.Errorf("%s --version: unexpected output %q", Binary(), bytes.TrimSpace(out))
+	}
+
+	return string(m[1]), nil
+}
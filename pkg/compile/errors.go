@@ -0,0 +1,227 @@
+package compile
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/format"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+)
+
+// ErrorsTarget collects the custom errors of several contracts into one Go
+// file, since abigen skips the error entries of an ABI.
+type ErrorsTarget struct {
+	Binding   string   `json:"binding"`   // Go file, relative to the root
+	Contracts []string `json:"contracts"` // contracts whose errors are bound
+}
+
+var errorsTemplate = template.Must(template.New("errors").Parse(`// Code generated by cmd/compile - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package {{.Package}}
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = big.NewInt
+	_ = common.Big1
+)
+
+// ErrorsABI is the input ABI of the custom errors bound in this file.
+const ErrorsABI = {{printf "%q" .ABI}}
+
+var errorsABI = func() abi.ABI {
+	parsed, err := abi.JSON(strings.NewReader(ErrorsABI))
+	if err != nil {
+		panic(err)
+	}
+	return parsed
+}()
+
+var customErrors = map[string]func() error{
+{{- range .Errors}}
+	"{{.Name}}": func() error { return new({{.Name}}) },
+{{- end}}
+}
+
+// UnpackError decodes revert data into the matching custom error, e.g.
+// *ERC20InsufficientBalance. It returns nil if the selector is unknown or the
+// arguments do not decode.
+func UnpackError(data []byte) error {
+	if len(data) < 4 {
+		return nil
+	}
+
+	e, err := errorsABI.ErrorByID([4]byte(data[:4]))
+	if err != nil {
+		return nil
+	}
+
+	values, err := e.Inputs.Unpack(data[4:])
+	if err != nil {
+		return nil
+	}
+
+	v := customErrors[e.Name]()
+	if err := e.Inputs.Copy(v, values); err != nil {
+		return nil
+	}
+
+	return v
+}
+{{range .Errors}}
+// {{.Name}} is the custom error {{.Sig}} from the {{.From}} ABI.
+{{- if .Fields}}
+type {{.Name}} struct {
+{{- range .Fields}}
+	{{.Name}} {{.Type}}
+{{- end}}
+}
+
+// Error implements the error interface.
+func (e *{{.Name}}) Error() string {
+	return fmt.Sprintf("{{.Name}}({{.Format}})"{{range .Fields}}, e.{{.Name}}{{end}})
+}
+{{- else}}
+type {{.Name}} struct{}
+
+// Error implements the error interface.
+func (e *{{.Name}}) Error() string {
+	return "{{.Name}}()"
+}
+{{- end}}
+{{end}}`))
+
+type errorView struct {
+	Name   string
+	Sig    string
+	From   string
+	Fields []errorField
+}
+
+type errorField struct {
+	Name string
+	Type string
+	Verb string
+}
+
+// Format is the fmt format listing the error arguments.
+func (e errorView) Format() string {
+	parts := make([]string, len(e.Fields))
+	for i, f := range e.Fields {
+		parts[i] = strings.ToLower(f.Name[:1]) + f.Name[1:] + ": " + f.Verb
+	}
+
+	return strings.Join(parts, ", ")
+}
+
+// ErrorBinding generates Go types for the custom errors in the given ABIs,
+// keyed by contract name, and an UnpackError function decoding revert data
+// into them. An error declared by several contracts is bound once.
+func ErrorBinding(pkg string, abis map[string][]byte) ([]byte, error) {
+	names := make([]string, 0, len(abis))
+	for name := range abis {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	seen := make(map[string]abi.Error)
+	declared := make(map[string]string)
+	var entries []json.RawMessage
+
+	for _, contract := range names {
+		parsed, err := abi.JSON(bytes.NewReader(abis[contract]))
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", contract, err)
+		}
+
+		var raw []json.RawMessage
+		if err := json.Unmarshal(abis[contract], &raw); err != nil {
+			return nil, fmt.Errorf("%s: %w", contract, err)
+		}
+
+		for _, entry := range raw {
+			var head struct{ Type, Name string }
+			if err := json.Unmarshal(entry, &head); err != nil {
+				return nil, err
+			}
+
+			if head.Type != "error" {
+				continue
+			}
+
+			e := parsed.Errors[head.Name]
+			if other, ok := seen[e.Name]; ok {
+				if other.Sig != e.Sig {
+					return nil, fmt.Errorf("error %s declared as both %s and %s", e.Name, other.Sig, e.Sig)
+				}
+				continue
+			}
+
+			seen[e.Name] = e
+			declared[e.Name] = contract
+			entries = append(entries, entry)
+		}
+	}
+
+	errorNames := make([]string, 0, len(seen))
+	for name := range seen {
+		errorNames = append(errorNames, name)
+	}
+	sort.Strings(errorNames)
+
+	views := make([]errorView, 0, len(errorNames))
+	for _, name := range errorNames {
+		e := seen[name]
+
+		view := errorView{Name: e.Name, Sig: e.Sig, From: declared[name]}
+		for i, input := range e.Inputs {
+			fieldName := input.Name
+			if fieldName == "" {
+				fieldName = fmt.Sprintf("arg%d", i)
+			}
+
+			// Byte arrays read better as hex than as a list of numbers
+			verb := "%v"
+			if input.Type.T == abi.BytesTy || input.Type.T == abi.FixedBytesTy {
+				verb = "%#x"
+			}
+
+			view.Fields = append(view.Fields, errorField{
+				Name: abi.ToCamelCase(fieldName),
+				Type: input.Type.GetType().String(),
+				Verb: verb,
+			})
+		}
+
+		views = append(views, view)
+	}
+
+	abiJSON, err := json.Marshal(entries)
+	if err != nil {
+		return nil, err
+	}
+
+	var b bytes.Buffer
+	if err := errorsTemplate.Execute(&b, map[string]interface{}{
+		"Package": pkg,
+		"ABI":     string(abiJSON),
+		"Errors":  views,
+	}); err != nil {
+		return nil, err
+	}
+
+	return format.Source(b.Bytes())
+}
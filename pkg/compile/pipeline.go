@@ -0,0 +1,154 @@
+package compile
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+)
+
+// Files are generated files keyed by slash-separated path relative to the
+// repository root.
+type Files map[string][]byte
+
+// Build compiles every target of the configuration with the pinned compiler
+// and returns the artifacts of all contracts in the output together with the
+// Go bindings of the targets and of their custom errors. Nothing is written to
+// disk.
+func Build(ctx context.Context, root string, cfg *Config) (Files, error) {
+	version, err := Version(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if version != cfg.Solc {
+		return nil, fmt.Errorf("solc %s is pinned, but %s is %s", cfg.Solc, Binary(), version)
+	}
+
+	output, err := Run(ctx, root, cfg.Input())
+	if err != nil {
+		return nil, err
+	}
+
+	files := make(Files)
+	sources := make(map[string]string)
+
+	for source, contracts := range output.Contracts {
+		for name, c := range contracts {
+			if other, ok := sources[name]; ok && other != source {
+				return nil, fmt.Errorf("contract %s is defined in both %s and %s", name, other, source)
+			}
+			sources[name] = source
+
+			artifacts, err := Artifacts(name, c)
+			if err != nil {
+				return nil, err
+			}
+
+			for file, content := range artifacts {
+				files[path.Join(cfg.Build, file)] = content
+			}
+		}
+	}
+
+	for _, t := range cfg.Targets {
+		c, err := output.Find(t.Contract)
+		if err != nil {
+			return nil, err
+		}
+
+		binding, err := Binding(cfg.Package, t.Type, c.ABI, c.EVM.Bytecode.Object)
+		if err != nil {
+			return nil, fmt.Errorf("%s binding: %w", t.Contract, err)
+		}
+
+		files[t.Binding] = binding
+	}
+
+	if cfg.Errors != nil {
+		abis := make(map[string][]byte)
+		for _, name := range cfg.Errors.Contracts {
+			c, err := output.Find(name)
+			if err != nil {
+				return nil, err
+			}
+			abis[name] = c.ABI
+		}
+
+		binding, err := ErrorBinding(cfg.Package, abis)
+		if err != nil {
+			return nil, fmt.Errorf("error binding: %w", err)
+		}
+
+		files[cfg.Errors.Binding] = binding
+	}
+
+	return files, nil
+}
+
+// Paths returns the file paths in sorted order.
+func (f Files) Paths() []string {
+	paths := make([]string, 0, len(f))
+	for p := range f {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+
+	return paths
+}
+
+// Write writes the files under root.
+func (f Files) Write(root string) error {
+	for _, p := range f.Paths() {
+		name := filepath.Join(root, filepath.FromSlash(p))
+
+		if err := os.MkdirAll(filepath.Dir(name), 0o755); err != nil {
+			return err
+		}
+
+		if err := os.WriteFile(name, f[p], 0o644); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Stale compares the files with what is on disk under root and returns the
+// paths that differ or are missing, plus any file in the build directory that
+// the compiler no longer produces.
+func (f Files) Stale(root, buildDir string) ([]string, error) {
+	var stale []string
+
+	for _, p := range f.Paths() {
+		current, err := os.ReadFile(filepath.Join(root, filepath.FromSlash(p)))
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return nil, err
+		}
+
+		if err != nil || !bytes.Equal(current, f[p]) {
+			stale = append(stale, p)
+		}
+	}
+
+	entries, err := os.ReadDir(filepath.Join(root, filepath.FromSlash(buildDir)))
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+
+	for _, e := range entries {
+		p := path.Join(buildDir, e.Name())
+		if _, ok := f[p]; !ok && !e.IsDir() {
+			stale = append(stale, p)
+		}
+	}
+
+	sort.Strings(stale)
+
+	return stale, nil
+}
@@ -0,0 +1,131 @@
+// Package compile drives solc through its standard JSON interface and turns the
+// output into build artifacts and abigen bindings.
+package compile
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// Input is the solc standard JSON input.
+type Input struct {
+	Language string            `json:"language"`
+	Sources  map[string]Source `json:"sources"`
+	Settings Settings          `json:"settings"`
+}
+
+// Source is a source unit, given inline or by path.
+type Source struct {
+	Content string   `json:"content,omitempty"`
+	URLs    []string `json:"urls,omitempty"`
+}
+
+// Settings are the compiler settings of the standard JSON input.
+type Settings struct {
+	Remappings      []string                       `json:"remappings,omitempty"`
+	Optimizer       Optimizer                      `json:"optimizer"`
+	EVMVersion      string                         `json:"evmVersion,omitempty"`
+	Metadata        *MetadataSettings              `json:"metadata,omitempty"`
+	OutputSelection map[string]map[string][]string `json:"outputSelection"`
+}
+
+// Optimizer configures the solc optimizer.
+type Optimizer struct {
+	Enabled bool `json:"enabled"`
+	Runs    int  `json:"runs"`
+}
+
+// MetadataSettings configures the contract metadata.
+type MetadataSettings struct {
+	UseLiteralContent bool   `json:"useLiteralContent,omitempty"`
+	BytecodeHash      string `json:"bytecodeHash,omitempty"`
+}
+
+// Output is the solc standard JSON output.
+type Output struct {
+	Errors    []Error                        `json:"errors"`
+	Contracts map[string]map[string]Contract `json:"contracts"`
+}
+
+// Error is a compiler error or warning.
+type Error struct {
+	Severity         string `json:"severity"`
+	FormattedMessage string `json:"formattedMessage"`
+}
+
+// Contract is the compiler output of one contract.
+type Contract struct {
+	ABI           json.RawMessage `json:"abi"`
+	Metadata      string          `json:"metadata"`
+	StorageLayout json.RawMessage `json:"storageLayout"`
+	EVM           struct {
+		Bytecode struct {
+			Object string `json:"object"`
+		} `json:"bytecode"`
+	} `json:"evm"`
+}
+
+// Binary returns the solc binary to run: $SOLC if set, otherwise solc from PATH.
+func Binary() string {
+	if solc := os.Getenv("SOLC"); solc != "" {
+		return solc
+	}
+
+	return "solc"
+}
+
+// Run compiles the input with solc, resolving imports relative to baseDir. Any
+// compiler error fails the compilation.
+func Run(ctx context.Context, baseDir string, input *Input) (*Output, error) {
+	in, err := json.Marshal(input)
+	if err != nil {
+		return nil, err
+	}
+
+	var stdout, stderr bytes.Buffer
+
+	cmd := exec.CommandContext(ctx, Binary(), "--standard-json", "--base-path", ".")
+	cmd.Dir = baseDir
+	cmd.Stdin = bytes.NewReader(in)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("%s: %w: %s", Binary(), err, strings.TrimSpace(stderr.String()))
+	}
+
+	var output Output
+	if err := json.Unmarshal(stdout.Bytes(), &output); err != nil {
+		return nil, err
+	}
+
+	var errs []error
+	for _, e := range output.Errors {
+		if e.Severity == "error" {
+			errs = append(errs, errors.New(strings.TrimSpace(e.FormattedMessage)))
+		}
+	}
+
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+
+	return &output, nil
+}
+
+// Find returns the named contract from the output.
+func (o *Output) Find(name string) (Contract, error) {
+	for _, contracts := range o.Contracts {
+		if c, ok := contracts[name]; ok {
+			return c, nil
+		}
+	}
+
+	return Contract{}, fmt.Errorf("contract %s not in compiler output", name)
+}
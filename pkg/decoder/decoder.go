@@ -0,0 +1,406 @@
+// Package decoder indexes the methods, events and errors of every ABI in
+// build/ by selector and topic, so transaction input, revert data and receipt
+// logs can be decoded without knowing which contract produced them:
+//
+//	registry, _ := decoder.Load("build")
+//	call, err := registry.DecodeCall(tx.Data())
+//	if errors.Is(err, decoder.ErrUnknown) {
+//		...
+//	}
+package decoder
+
+import (
+	"errors"
+	"fmt"
+	"go-ethereum-example/pkg/abiargs"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// ErrUnknown is returned for selectors and topics that no loaded ABI declares.
+var ErrUnknown = errors.New("unknown")
+
+// Field is a decoded argument.
+type Field struct {
+	Name    string
+	Type    abi.Type
+	Indexed bool
+	Value   any
+}
+
+// String formats the field as "name: value". Indexed strings, bytes, arrays
+// and tuples are stored as the hash of their value, which is shown instead.
+func (f Field) String() string {
+	if hash, ok := f.Value.(common.Hash); ok && f.Indexed {
+		return f.Name + ": " + hash.Hex()
+	}
+
+	return f.Name + ": " + abiargs.Format(f.Type, f.Value)
+}
+
+// Decoded is a decoded method call, event or error.
+type Decoded struct {
+	Name      string
+	Signature string
+
+	// Contracts are the names of the ABIs that declare the signature.
+	Contracts []string
+
+	Fields []Field
+}
+
+// String formats the result as "name(field: value, ...)".
+func (d *Decoded) String() string {
+	fields := make([]string, len(d.Fields))
+	for i, field := range d.Fields {
+		fields[i] = field.String()
+	}
+
+	return d.Name + "(" + strings.Join(fields, ", ") + ")"
+}
+
+// Describe formats the result of a Decode method: the decoded value with its
+// signature, or UNKNOWN or UNDECODABLE with the error.
+func Describe(decoded *Decoded, err error) string {
+	switch {
+	case errors.Is(err, ErrUnknown):
+		return fmt.Sprintf("UNKNOWN (%v)", err)
+	case err != nil:
+		return fmt.Sprintf("UNDECODABLE (%v)", err)
+	}
+
+	return fmt.Sprintf("%s [%s]", decoded, decoded.Signature)
+}
+
+type method struct {
+	abi.Method
+	contracts []string
+}
+
+type event struct {
+	abi.Event
+	contracts []string
+}
+
+type customError struct {
+	abi.Error
+	contracts []string
+}
+
+// Registry maps selectors and topics to the declarations of the loaded ABIs.
+// Declarations shared by several contracts, such as Transfer, are stored once.
+type Registry struct {
+	methods map[[4]byte][]*method
+	events  map[common.Hash][]*event
+	errors  map[[4]byte][]*customError
+}
+
+// New returns a registry that knows the Error(string) and Panic(uint256)
+// reverts of Solidity.
+func New() *Registry {
+	r := &Registry{
+		methods: make(map[[4]byte][]*method),
+		events:  make(map[common.Hash][]*event),
+		errors:  make(map[[4]byte][]*customError),
+	}
+
+	stringType, _ := abi.NewType("string", "", nil)
+	uint256Type, _ := abi.NewType("uint256", "", nil)
+
+	builtin := &abi.ABI{Errors: map[string]abi.Error{
+		"Error": abi.NewError("Error", abi.Arguments{{Name: "reason", Type: stringType}}),
+		"Panic": abi.NewError("Panic", abi.Arguments{{Name: "code", Type: uint256Type}}),
+	}}
+	r.Add("Solidity", builtin)
+
+	return r
+}
+
+// Load returns a registry of every .abi file in the build directory and its
+// subdirectories, such as build/fixtures, named after the file.
+func Load(build string) (*Registry, error) {
+	var paths []string
+
+	err := filepath.WalkDir(build, func(path string, d fs.DirEntry, err error) error {
+		if err == nil && !d.IsDir() && filepath.Ext(path) == ".abi" {
+			paths = append(paths, path)
+		}
+
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("no ABI files in %s", build)
+	}
+
+	r := New()
+
+	for _, path := range paths {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, err
+		}
+
+		parsed, err := abi.JSON(f)
+		f.Close()
+
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+
+		r.Add(strings.TrimSuffix(filepath.Base(path), ".abi"), &parsed)
+	}
+
+	return r, nil
+}
+
+// Add registers the methods, events and errors of a contract's ABI.
+func (r *Registry) Add(contract string, parsed *abi.ABI) {
+	for _, m := range parsed.Methods {
+		var selector [4]byte
+		copy(selector[:], m.ID)
+
+		if known := findMethod(r.methods[selector], m.Sig); known != nil {
+			known.contracts = appendContract(known.contracts, contract)
+			continue
+		}
+
+		r.methods[selector] = append(r.methods[selector], &method{Method: m, contracts: []string{contract}})
+	}
+
+	for _, e := range parsed.Events {
+		if e.Anonymous {
+			continue
+		}
+
+		// Events with the same signature may differ in which fields are indexed,
+		// such as Transfer in ERC-20 and ERC-721
+		if known := findEvent(r.events[e.ID], e); known != nil {
+			known.contracts = appendContract(known.contracts, contract)
+			continue
+		}
+
+		r.events[e.ID] = append(r.events[e.ID], &event{Event: e, contracts: []string{contract}})
+	}
+
+	for _, e := range parsed.Errors {
+		var selector [4]byte
+		copy(selector[:], e.ID[:4])
+
+		if known := findError(r.errors[selector], e.Sig); known != nil {
+			known.contracts = appendContract(known.contracts, contract)
+			continue
+		}
+
+		r.errors[selector] = append(r.errors[selector], &customError{Error: e, contracts: []string{contract}})
+	}
+}
+
+// DecodeCall decodes transaction input or eth_call data.
+func (r *Registry) DecodeCall(data []byte) (*Decoded, error) {
+	if len(data) < 4 {
+		return nil, fmt.Errorf("calldata of %d bytes has no selector", len(data))
+	}
+
+	var selector [4]byte
+	copy(selector[:], data)
+
+	candidates := r.methods[selector]
+	if len(candidates) == 0 {
+		return nil, fmt.Errorf("%w selector %s", ErrUnknown, hexutil.Encode(selector[:]))
+	}
+
+	var errs []error
+	for _, m := range candidates {
+		values, err := m.Inputs.Unpack(data[4:])
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", m.Sig, err))
+			continue
+		}
+
+		return &Decoded{
+			Name:      m.RawName,
+			Signature: m.Sig,
+			Contracts: m.contracts,
+			Fields:    fields(m.Inputs, values),
+		}, nil
+	}
+
+	return nil, errors.Join(errs...)
+}
+
+// DecodeError decodes revert data, including the Error(string) of require
+// and the Panic(uint256) of failed assertions and arithmetic.
+func (r *Registry) DecodeError(data []byte) (*Decoded, error) {
+	if len(data) < 4 {
+		return nil, fmt.Errorf("revert data of %d bytes has no selector", len(data))
+	}
+
+	var selector [4]byte
+	copy(selector[:], data)
+
+	candidates := r.errors[selector]
+	if len(candidates) == 0 {
+		return nil, fmt.Errorf("%w error %s", ErrUnknown, hexutil.Encode(selector[:]))
+	}
+
+	var errs []error
+	for _, e := range candidates {
+		values, err := e.Inputs.Unpack(data[4:])
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", e.Sig, err))
+			continue
+		}
+
+		return &Decoded{
+			Name:      e.Name,
+			Signature: e.Sig,
+			Contracts: e.contracts,
+			Fields:    fields(e.Inputs, values),
+		}, nil
+	}
+
+	return nil, errors.Join(errs...)
+}
+
+// DecodeLog decodes a receipt log by its first topic.
+func (r *Registry) DecodeLog(log *types.Log) (*Decoded, error) {
+	if len(log.Topics) == 0 {
+		return nil, errors.New("anonymous log")
+	}
+
+	candidates := r.events[log.Topics[0]]
+	if len(candidates) == 0 {
+		return nil, fmt.Errorf("%w event %s", ErrUnknown, log.Topics[0].Hex())
+	}
+
+	var errs []error
+	for _, e := range candidates {
+		decoded, err := decodeEvent(e, log)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", e.Sig, err))
+			continue
+		}
+
+		return decoded, nil
+	}
+
+	return nil, errors.Join(errs...)
+}
+
+func decodeEvent(e *event, log *types.Log) (*Decoded, error) {
+	var indexed abi.Arguments
+	for _, input := range e.Inputs {
+		if input.Indexed {
+			indexed = append(indexed, input)
+		}
+	}
+
+	if len(log.Topics)-1 != len(indexed) {
+		return nil, fmt.Errorf("got %d indexed fields, want %d", len(log.Topics)-1, len(indexed))
+	}
+
+	topics := make(map[string]any)
+	if err := abi.ParseTopicsIntoMap(topics, indexed, log.Topics[1:]); err != nil {
+		return nil, err
+	}
+
+	values, err := e.Inputs.NonIndexed().Unpack(log.Data)
+	if err != nil {
+		return nil, err
+	}
+
+	// Merge both in the declaration order of the event
+	decoded := &Decoded{Name: e.RawName, Signature: e.Sig, Contracts: e.contracts}
+
+	for i, input := range e.Inputs {
+		field := Field{Name: fieldName(input, i), Type: input.Type, Indexed: input.Indexed}
+
+		if input.Indexed {
+			field.Value = topics[input.Name]
+		} else {
+			field.Value, values = values[0], values[1:]
+		}
+
+		decoded.Fields = append(decoded.Fields, field)
+	}
+
+	return decoded, nil
+}
+
+func fields(args abi.Arguments, values []any) []Field {
+	fields := make([]Field, len(values))
+	for i, v := range values {
+		fields[i] = Field{Name: fieldName(args[i], i), Type: args[i].Type, Value: v}
+	}
+
+	return fields
+}
+
+func fieldName(arg abi.Argument, i int) string {
+	if arg.Name == "" {
+		return fmt.Sprintf("#%d", i)
+	}
+
+	return arg.Name
+}
+
+func findMethod(methods []*method, sig string) *method {
+	for _, m := range methods {
+		if m.Sig == sig {
+			return m
+		}
+	}
+
+	return nil
+}
+
+func findEvent(events []*event, e abi.Event) *event {
+	for _, known := range events {
+		if known.Sig == e.Sig && sameIndexed(known.Inputs, e.Inputs) {
+			return known
+		}
+	}
+
+	return nil
+}
+
+func findError(errs []*customError, sig string) *customError {
+	for _, e := range errs {
+		if e.Sig == sig {
+			return e
+		}
+	}
+
+	return nil
+}
+
+func sameIndexed(a, b abi.Arguments) bool {
+	for i := range a {
+		if a[i].Indexed != b[i].Indexed {
+			return false
+		}
+	}
+
+	return true
+}
+
+func appendContract(contracts []string, contract string) []string {
+	i := sort.SearchStrings(contracts, contract)
+	if i < len(contracts) && contracts[i] == contract {
+		return contracts
+	}
+
+	return append(contracts[:i], append([]string{contract}, contracts[i:]...)...)
+}
@@ -0,0 +1,216 @@
+package decoder
+
+import (
+	"errors"
+	token "go-ethereum-example/gen"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+var (
+	owner     = common.HexToAddress("0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266")
+	recipient = common.HexToAddress("0x70997970C51812dc3A010C7d01b50e0d17dc79C8")
+)
+
+func loadRegistry(t *testing.T) *Registry {
+	t.Helper()
+
+	registry, err := Load("../../build")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return registry
+}
+
+func tokenABI(t *testing.T) *abi.ABI {
+	t.Helper()
+
+	parsed, err := token.TokenMetaData.GetAbi()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return parsed
+}
+
+func TestDecodeCall(t *testing.T) {
+	registry := loadRegistry(t)
+
+	data, err := tokenABI(t).Pack("transfer", recipient, big.NewInt(1000))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	call, err := registry.DecodeCall(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := "transfer(to: " + recipient.Hex() + ", value: 1000)"; call.String() != want {
+		t.Errorf("DecodeCall = %s, want %s", call, want)
+	}
+
+	if call.Signature != "transfer(address,uint256)" {
+		t.Errorf("signature %s", call.Signature)
+	}
+
+	// Declared by every token and the interface, but stored once, including
+	// the fixtures in build/fixtures
+	for _, contract := range []string{"IERC20", "MyToken", "NoReturnToken"} {
+		found := false
+		for _, c := range call.Contracts {
+			found = found || c == contract
+		}
+
+		if !found {
+			t.Errorf("contracts %v miss %s", call.Contracts, contract)
+		}
+	}
+
+	if _, err := registry.DecodeCall(hexutil.MustDecode("0x12345678")); !errors.Is(err, ErrUnknown) {
+		t.Errorf("unknown selector: %v", err)
+	}
+
+	// Known selector with truncated arguments
+	if _, err := registry.DecodeCall(data[:20]); err == nil || errors.Is(err, ErrUnknown) {
+		t.Errorf("truncated calldata: %v", err)
+	}
+}
+
+func TestDecodeError(t *testing.T) {
+	registry := loadRegistry(t)
+
+	customErr := tokenABI(t).Errors["ERC20InsufficientBalance"]
+
+	args, err := customErr.Inputs.Pack(owner, big.NewInt(0), big.NewInt(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	decoded, err := registry.DecodeError(append(customErr.ID.Bytes()[:4], args...))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := "ERC20InsufficientBalance(sender: " + owner.Hex() + ", balance: 0, needed: 1)"; decoded.String() != want {
+		t.Errorf("DecodeError = %s, want %s", decoded, want)
+	}
+
+	// Built-in errors of Solidity
+	tests := []struct {
+		data string
+		want string
+	}{
+		{
+			data: "0x08c379a0" +
+				"0000000000000000000000000000000000000000000000000000000000000020" +
+				"0000000000000000000000000000000000000000000000000000000000000004" +
+				"6e6f706500000000000000000000000000000000000000000000000000000000",
+			want: `Error(reason: "nope")`,
+		},
+		{
+			data: "0x4e487b71" + "0000000000000000000000000000000000000000000000000000000000000011",
+			want: "Panic(code: 17)",
+		},
+	}
+
+	for _, tt := range tests {
+		decoded, err := registry.DecodeError(hexutil.MustDecode(tt.data))
+		if err != nil || decoded.String() != tt.want {
+			t.Errorf("DecodeError = %v, %v, want %s", decoded, err, tt.want)
+		}
+	}
+
+	if _, err := registry.DecodeError(hexutil.MustDecode("0xdeadbeef")); !errors.Is(err, ErrUnknown) {
+		t.Errorf("unknown error: %v", err)
+	}
+}
+
+func TestDecodeLog(t *testing.T) {
+	registry := loadRegistry(t)
+
+	event := tokenABI(t).Events["Transfer"]
+
+	data, err := event.Inputs.NonIndexed().Pack(big.NewInt(1000))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	log := &types.Log{
+		Topics: []common.Hash{event.ID, common.BytesToHash(owner.Bytes()), common.BytesToHash(recipient.Bytes())},
+		Data:   data,
+	}
+
+	decoded, err := registry.DecodeLog(log)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := "Transfer(from: " + owner.Hex() + ", to: " + recipient.Hex() + ", value: 1000)"; decoded.String() != want {
+		t.Errorf("DecodeLog = %s, want %s", decoded, want)
+	}
+
+	if !decoded.Fields[0].Indexed || decoded.Fields[2].Indexed {
+		t.Errorf("indexed fields %+v", decoded.Fields)
+	}
+
+	// Same signature with the value indexed, as in ERC-721
+	log.Topics = append(log.Topics, common.BigToHash(big.NewInt(7)))
+	log.Data = nil
+
+	if _, err := registry.DecodeLog(log); err == nil || !strings.Contains(err.Error(), "indexed") {
+		t.Errorf("decoded an ERC-721 transfer with the ERC-20 event: %v", err)
+	}
+
+	registry.Add("ERC721", mustABI(t, `[{"type":"event","name":"Transfer","inputs":[
+		{"name":"from","type":"address","indexed":true},
+		{"name":"to","type":"address","indexed":true},
+		{"name":"tokenId","type":"uint256","indexed":true}]}]`))
+
+	decoded, err = registry.DecodeLog(log)
+	if err != nil || !strings.HasSuffix(decoded.String(), "tokenId: 7)") {
+		t.Errorf("DecodeLog = %v, %v", decoded, err)
+	}
+
+	log.Topics[0] = common.HexToHash("0x01")
+	if _, err := registry.DecodeLog(log); !errors.Is(err, ErrUnknown) {
+		t.Errorf("unknown topic: %v", err)
+	}
+}
+
+func TestDescribe(t *testing.T) {
+	registry := loadRegistry(t)
+
+	tests := []struct {
+		data string
+		want string
+	}{
+		{data: "0x4e487b71" + "0000000000000000000000000000000000000000000000000000000000000011", want: "Panic(code: 17) [Panic(uint256)]"},
+		{data: "0xdeadbeef", want: "UNKNOWN ("},
+		{data: "0x4e487b71", want: "UNDECODABLE ("},
+	}
+
+	for _, tt := range tests {
+		if got := Describe(registry.DecodeError(hexutil.MustDecode(tt.data))); !strings.HasPrefix(got, tt.want) {
+			t.Errorf("Describe(%s) = %s, want %s...", tt.data, got, tt.want)
+		}
+	}
+}
+
+func mustABI(t *testing.T, s string) *abi.ABI {
+	t.Helper()
+
+	parsed, err := abi.JSON(strings.NewReader(s))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return &parsed
+}
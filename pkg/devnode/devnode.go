@@ -0,0 +1,340 @@
+// Package devnode runs an in-process development chain served over HTTP and
+// WebSocket JSON-RPC, as a stand-in for anvil. It mines on demand or on an
+// interval, funds the anvil accounts, predeploys Multicall3 and supports
+// evm_snapshot and evm_revert.
+package devnode
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	token "go-ethereum-example/gen"
+	"go-ethereum-example/pkg/multicall"
+	"go-ethereum-example/pkg/testchain"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/eth"
+	"github.com/ethereum/go-ethereum/eth/catalyst"
+	"github.com/ethereum/go-ethereum/eth/downloader"
+	"github.com/ethereum/go-ethereum/eth/ethconfig"
+	"github.com/ethereum/go-ethereum/eth/filters"
+	"github.com/ethereum/go-ethereum/eth/tracers"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/node"
+	"github.com/ethereum/go-ethereum/p2p"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	// Register the native tracers (callTracer, prestateTracer) for debug_trace*
+	_ "github.com/ethereum/go-ethereum/eth/tracers/native"
+)
+
+// Config configures the development chain.
+type Config struct {
+	Host        string        // listen host for HTTP and WebSocket, e.g. 127.0.0.1
+	Port        int           // listen port shared by HTTP and WebSocket
+	ChainID     *big.Int      // chain ID, anvil uses 31337
+	BlockTime   time.Duration // interval mining period, zero mines every transaction right away
+	Balance     *big.Int      // genesis balance of each account
+	GasLimit    uint64        // block gas limit
+	DeployToken bool          // deploy MyToken from the first account at startup
+}
+
+// DefaultConfig matches the defaults of anvil.
+func DefaultConfig() Config {
+	return Config{
+		Host:     "127.0.0.1",
+		Port:     8545,
+		ChainID:  big.NewInt(31337),
+		Balance:  testchain.DefaultBalance,
+		GasLimit: 30_000_000,
+	}
+}
+
+// TokenSupply is the initial supply of the predeployed MyToken, as in cmd/deploy.
+var TokenSupply = new(big.Int).Mul(big.NewInt(1_000_000), new(big.Int).Exp(big.NewInt(10), big.NewInt(18), nil))
+
+// Node is a running development chain.
+type Node struct {
+	Accounts []testchain.Account
+	Token    common.Address // address of the predeployed MyToken, if any
+
+	stack   *node.Node
+	backend *eth.Ethereum
+	beacon  *catalyst.SimulatedBeacon
+	cfg     Config
+
+	// mu serializes block production between the automine loop and the RPC API
+	mu        sync.Mutex
+	snapshots []snapshot
+
+	quit chan struct{}
+	wg   sync.WaitGroup
+}
+
+type snapshot struct {
+	number uint64
+	hash   common.Hash
+}
+
+// Start starts the chain and its RPC servers.
+func Start(cfg Config) (*Node, error) {
+	accounts := testchain.DevAccounts()
+
+	multicallCode, err := multicall.Code()
+	if err != nil {
+		return nil, err
+	}
+
+	alloc := types.GenesisAlloc{multicall.Address: {Code: multicallCode}}
+	for _, a := range accounts {
+		alloc[a.Address] = types.Account{Balance: cfg.Balance}
+	}
+
+	chainConfig := *params.AllDevChainProtocolChanges
+	chainConfig.ChainID = cfg.ChainID
+
+	stack, err := node.New(&node.Config{
+		Name:             "devnode",
+		P2P:              p2p.Config{NoDiscovery: true, ListenAddr: "", MaxPeers: 0},
+		HTTPHost:         cfg.Host,
+		HTTPPort:         cfg.Port,
+		HTTPModules:      []string{"eth", "net", "web3", "txpool", "debug", "evm"},
+		HTTPCors:         []string{"*"},
+		HTTPVirtualHosts: []string{"*"},
+		WSHost:           cfg.Host,
+		WSPort:           cfg.Port,
+		WSModules:        []string{"eth", "net", "web3", "txpool", "debug", "evm"},
+		WSOrigins:        []string{"*"},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ethConf := ethconfig.Defaults
+	ethConf.NetworkId = cfg.ChainID.Uint64()
+	ethConf.SyncMode = downloader.FullSync
+	ethConf.TxPool.NoLocals = true
+	ethConf.Miner.GasCeil = cfg.GasLimit
+	ethConf.Genesis = &core.Genesis{
+		Config:     &chainConfig,
+		GasLimit:   cfg.GasLimit,
+		Difficulty: new(big.Int), // post-merge from genesis, so Shanghai is active
+		Alloc:      alloc,
+	}
+
+	backend, err := eth.New(stack, &ethConf)
+	if err != nil {
+		stack.Close()
+		return nil, err
+	}
+
+	n := &Node{Accounts: accounts, stack: stack, backend: backend, cfg: cfg, quit: make(chan struct{})}
+
+	filterSystem := filters.NewFilterSystem(backend.APIBackend, filters.Config{})
+	stack.RegisterAPIs(append(tracers.APIs(backend.APIBackend),
+		rpc.API{Namespace: "eth", Service: filters.NewFilterAPI(filterSystem)},
+		rpc.API{Namespace: "evm", Service: &evmAPI{n: n}},
+	))
+
+	if err := stack.Start(); err != nil {
+		stack.Close()
+		return nil, err
+	}
+
+	// The beacon only mines when told to, so that the mining loop and the
+	// evm_ API can share one lock
+	n.beacon, err = catalyst.NewSimulatedBeacon(0, backend)
+	if err != nil {
+		stack.Close()
+		return nil, err
+	}
+
+	n.wg.Add(1)
+	go n.mineLoop()
+
+	if cfg.DeployToken {
+		if err := n.deployToken(); err != nil {
+			n.Close()
+			return nil, fmt.Errorf("deploy token: %w", err)
+		}
+	}
+
+	return n, nil
+}
+
+// HTTPEndpoint returns the HTTP JSON-RPC endpoint.
+func (n *Node) HTTPEndpoint() string {
+	return n.stack.HTTPEndpoint()
+}
+
+// WSEndpoint returns the WebSocket JSON-RPC endpoint.
+func (n *Node) WSEndpoint() string {
+	return n.stack.WSEndpoint()
+}
+
+// Close stops mining and shuts the node down.
+func (n *Node) Close() error {
+	close(n.quit)
+	n.wg.Wait()
+
+	return n.stack.Close()
+}
+
+// mineLoop seals a block every block time, or whenever transactions enter
+// the pool if there is no block time.
+func (n *Node) mineLoop() {
+	defer n.wg.Done()
+
+	if n.cfg.BlockTime > 0 {
+		ticker := time.NewTicker(n.cfg.BlockTime)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				n.Mine()
+			case <-n.quit:
+				return
+			}
+		}
+	}
+
+	txs := make(chan core.NewTxsEvent, 16)
+	sub := n.backend.TxPool().SubscribeTransactions(txs, true)
+	defer sub.Unsubscribe()
+
+	for {
+		select {
+		case <-txs:
+			n.minePending()
+		case <-sub.Err():
+			return
+		case <-n.quit:
+			return
+		}
+	}
+}
+
+// Mine seals a block with the pending transactions.
+func (n *Node) Mine() common.Hash {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	return n.beacon.Commit()
+}
+
+// minePending seals a block if the pool still has pending transactions; a
+// revert may have dropped them since they were announced.
+func (n *Node) minePending() {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	pool := n.backend.TxPool()
+	if err := pool.Sync(); err != nil {
+		return
+	}
+
+	if pending, _ := pool.Stats(); pending > 0 {
+		n.beacon.Commit()
+	}
+}
+
+// Snapshot records the current head and returns its id.
+func (n *Node) Snapshot() uint64 {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	head := n.backend.BlockChain().CurrentBlock()
+	n.snapshots = append(n.snapshots, snapshot{number: head.Number.Uint64(), hash: head.Hash()})
+
+	return uint64(len(n.snapshots) - 1)
+}
+
+// Revert drops pending transactions and rewinds the chain to a snapshot. The
+// snapshot and any taken after it are discarded, as in anvil.
+func (n *Node) Revert(id uint64) (bool, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if id >= uint64(len(n.snapshots)) {
+		return false, nil
+	}
+
+	s := n.snapshots[id]
+	n.snapshots = n.snapshots[:id]
+
+	if err := n.backend.BlockChain().SetHead(s.number); err != nil {
+		return false, err
+	}
+
+	// The pool re-injects the transactions of the dropped blocks on reset, so
+	// wait for it before clearing
+	if err := n.backend.TxPool().Sync(); err != nil {
+		return false, err
+	}
+
+	n.beacon.Rollback()
+
+	if head := n.backend.BlockChain().CurrentBlock(); head.Hash() != s.hash {
+		return false, errors.New("snapshot block is no longer canonical")
+	}
+
+	return true, nil
+}
+
+func (n *Node) deployToken() error {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	client := ethclient.NewClient(n.stack.Attach())
+	defer client.Close()
+
+	opts, err := bind.NewKeyedTransactorWithChainID(n.Accounts[0].Key, n.cfg.ChainID)
+	if err != nil {
+		return err
+	}
+	opts.Context = ctx
+
+	address, tx, _, err := token.DeployToken(opts, client, TokenSupply)
+	if err != nil {
+		return err
+	}
+
+	// With interval mining the deployment waits for the next block
+	if _, err := bind.WaitDeployed(ctx, client, tx); err != nil {
+		return err
+	}
+
+	n.Token = address
+
+	return nil
+}
+
+// evmAPI implements the evm_ namespace of anvil and hardhat.
+type evmAPI struct {
+	n *Node
+}
+
+// Snapshot implements evm_snapshot.
+func (api *evmAPI) Snapshot() hexutil.Uint64 {
+	return hexutil.Uint64(api.n.Snapshot())
+}
+
+// Revert implements evm_revert.
+func (api *evmAPI) Revert(id hexutil.Uint64) (bool, error) {
+	return api.n.Revert(uint64(id))
+}
+
+// Mine implements evm_mine.
+func (api *evmAPI) Mine() string {
+	api.n.Mine()
+	return "0x0"
+}
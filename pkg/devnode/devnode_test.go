@@ -0,0 +1,303 @@
+package devnode_test
+
+import (
+	"context"
+	token "go-ethereum-example/gen"
+	"go-ethereum-example/pkg/devnode"
+	"go-ethereum-example/pkg/multicall"
+	"go-ethereum-example/pkg/testchain"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+func start(t *testing.T, cfg devnode.Config) (*devnode.Node, *ethclient.Client) {
+	t.Helper()
+
+	cfg.Port = 0
+
+	node, err := devnode.Start(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { node.Close() })
+
+	client, err := ethclient.Dial(node.HTTPEndpoint())
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(client.Close)
+
+	return node, client
+}
+
+func timeout(t *testing.T) context.Context {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	t.Cleanup(cancel)
+
+	return ctx
+}
+
+// TestStart checks what cmd/deploy, cmd/interact and cmd/subscribe rely on:
+// the chain ID, funded accounts, Multicall3 and the predeployed token, with
+// its events delivered over WebSocket.
+func TestStart(t *testing.T) {
+	cfg := devnode.DefaultConfig()
+	cfg.DeployToken = true
+
+	node, client := start(t, cfg)
+	ctx := timeout(t)
+
+	chainID, err := client.ChainID(ctx)
+	if err != nil || chainID.Int64() != 31337 {
+		t.Fatalf("chain ID %v: %v", chainID, err)
+	}
+
+	for _, a := range node.Accounts {
+		balance, err := client.BalanceAt(ctx, a.Address, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// The first account paid for the token deployment
+		if balance.Sign() == 0 || balance.Cmp(cfg.Balance) > 0 {
+			t.Errorf("%s has %s wei", a.Address.Hex(), balance)
+		}
+	}
+
+	if code, err := client.CodeAt(ctx, multicall.Address, nil); err != nil || len(code) == 0 {
+		t.Errorf("no Multicall3 code at %s: %v", multicall.Address.Hex(), err)
+	}
+
+	// The first deployment of the first account, as on a fresh anvil
+	if node.Token.Hex() != "0x5FbDB2315678afecb367f032d93F642f64180aa3" {
+		t.Errorf("token at %s", node.Token.Hex())
+	}
+
+	tokenInstance, err := token.NewToken(node.Token, client)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	owner, alice := node.Accounts[0], node.Accounts[1]
+
+	if balance, err := tokenInstance.BalanceOf(nil, owner.Address); err != nil || balance.Cmp(devnode.TokenSupply) != 0 {
+		t.Errorf("owner balance %v: %v", balance, err)
+	}
+
+	wsClient, err := ethclient.Dial(node.WSEndpoint())
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(wsClient.Close)
+
+	wsToken, err := token.NewToken(node.Token, wsClient)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	transfers := make(chan *token.TokenTransfer, 1)
+
+	sub, err := wsToken.WatchTransfer(&bind.WatchOpts{Context: ctx}, transfers, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sub.Unsubscribe()
+
+	opts, err := bind.NewKeyedTransactorWithChainID(owner.Key, cfg.ChainID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := tokenInstance.Transfer(opts, alice.Address, big.NewInt(1000)); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case transfer := <-transfers:
+		if transfer.From != owner.Address || transfer.To != alice.Address || transfer.Value.Int64() != 1000 {
+			t.Errorf("transfer %+v", transfer)
+		}
+	case err := <-sub.Err():
+		t.Fatal(err)
+	case <-ctx.Done():
+		t.Fatal("no Transfer event over WebSocket")
+	}
+}
+
+func TestSnapshotRevert(t *testing.T) {
+	node, client := start(t, devnode.DefaultConfig())
+	ctx := timeout(t)
+
+	owner := node.Accounts[0]
+
+	opts, err := bind.NewKeyedTransactorWithChainID(owner.Key, big.NewInt(31337))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var id hexutil.Uint64
+	if err := client.Client().CallContext(ctx, &id, "evm_snapshot"); err != nil {
+		t.Fatal(err)
+	}
+
+	before, err := client.BlockNumber(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	address, tx, _, err := token.DeployToken(opts, client, big.NewInt(1000))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := bind.WaitDeployed(ctx, client, tx); err != nil {
+		t.Fatal(err)
+	}
+
+	var reverted bool
+	if err := client.Client().CallContext(ctx, &reverted, "evm_revert", id); err != nil || !reverted {
+		t.Fatalf("evm_revert %v: %v", reverted, err)
+	}
+
+	if after, _ := client.BlockNumber(ctx); after != before {
+		t.Errorf("block %d after revert, want %d", after, before)
+	}
+
+	if code, _ := client.CodeAt(ctx, address, nil); len(code) != 0 {
+		t.Error("token still deployed after revert")
+	}
+
+	if nonce, _ := client.NonceAt(ctx, owner.Address, nil); nonce != 0 {
+		t.Errorf("nonce %d after revert, want 0", nonce)
+	}
+
+	// A snapshot is used up by reverting to it
+	if err := client.Client().CallContext(ctx, &reverted, "evm_revert", id); err != nil || reverted {
+		t.Errorf("second evm_revert %v: %v", reverted, err)
+	}
+
+	// The chain goes on from the snapshot: the same deployment lands at the
+	// same address again
+	opts.Nonce = nil
+
+	redeployed, tx, _, err := token.DeployToken(opts, client, big.NewInt(1000))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := bind.WaitDeployed(ctx, client, tx); err != nil {
+		t.Fatal(err)
+	}
+
+	if redeployed != address {
+		t.Errorf("redeployed at %s, want %s", redeployed.Hex(), address.Hex())
+	}
+}
+
+// TestAutomine checks that every transaction is mined in its own block as soon
+// as it is sent.
+func TestAutomine(t *testing.T) {
+	node, client := start(t, devnode.DefaultConfig())
+	ctx := timeout(t)
+
+	owner, alice := node.Accounts[0], node.Accounts[1]
+
+	for i := 0; i < 3; i++ {
+		tx := send(t, client, owner, alice.Address)
+
+		receipt, err := bind.WaitMined(ctx, client, tx)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if receipt.BlockNumber.Uint64() != uint64(i+1) {
+			t.Errorf("transaction %d mined in block %d", i, receipt.BlockNumber)
+		}
+	}
+}
+
+// TestIntervalMining checks that with a block time transactions wait for the
+// next block, and that blocks come even without transactions.
+func TestIntervalMining(t *testing.T) {
+	cfg := devnode.DefaultConfig()
+	cfg.BlockTime = time.Hour
+
+	node, client := start(t, cfg)
+	ctx := timeout(t)
+
+	owner, alice := node.Accounts[0], node.Accounts[1]
+
+	tx := send(t, client, owner, alice.Address)
+
+	time.Sleep(200 * time.Millisecond)
+
+	if _, pending, err := client.TransactionByHash(ctx, tx.Hash()); err != nil || !pending {
+		t.Fatalf("transaction pending %v before the next block: %v", pending, err)
+	}
+
+	node.Mine()
+
+	if receipt, err := bind.WaitMined(ctx, client, tx); err != nil || receipt.BlockNumber.Uint64() != 1 {
+		t.Fatalf("receipt %+v: %v", receipt, err)
+	}
+
+	cfg.BlockTime = 50 * time.Millisecond
+
+	_, client = start(t, cfg)
+
+	for {
+		number, err := client.BlockNumber(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if number >= 3 {
+			break
+		}
+
+		select {
+		case <-ctx.Done():
+			t.Fatalf("block %d after %s", number, 10*time.Second)
+		case <-time.After(cfg.BlockTime):
+		}
+	}
+}
+
+// send transfers 1 wei from the account to the address.
+func send(t *testing.T, client *ethclient.Client, from testchain.Account, to common.Address) *types.Transaction {
+	t.Helper()
+
+	ctx := context.Background()
+
+	nonce, err := client.PendingNonceAt(ctx, from.Address)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tx, err := types.SignNewTx(from.Key, types.LatestSignerForChainID(big.NewInt(31337)), &types.DynamicFeeTx{
+		ChainID:   big.NewInt(31337),
+		Nonce:     nonce,
+		GasTipCap: big.NewInt(params.GWei),
+		GasFeeCap: big.NewInt(10 * params.GWei),
+		Gas:       21_000,
+		To:        &to,
+		Value:     big.NewInt(1),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := client.SendTransaction(ctx, tx); err != nil {
+		t.Fatal(err)
+	}
+
+	return tx
+}
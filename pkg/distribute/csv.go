@@ -0,0 +1,116 @@
+// Package distribute pays many recipients from a CSV file, recording every
+// transaction in a journal so an interrupted run can resume safely.
+package distribute
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"go-ethereum-example/pkg/eip55"
+	"go-ethereum-example/pkg/units"
+	"io"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Row is a validated recipient line of the CSV file.
+type Row struct {
+	Line    int
+	Address common.Address
+	Amount  *big.Int
+}
+
+// ReadCSV reads address,amount rows with decimal amounts. Every row is
+// validated and all problems are reported together. A header row is skipped.
+func ReadCSV(r io.Reader, decimals uint8) ([]Row, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = 2
+	reader.TrimLeadingSpace = true
+	reader.Comment = '#'
+
+	var (
+		rows []Row
+		errs []error
+		seen = make(map[common.Address]int)
+	)
+
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		line, _ := reader.FieldPos(0)
+
+		if len(rows) == 0 && len(errs) == 0 && strings.EqualFold(record[0], "address") {
+			continue
+		}
+
+		address, err := parseAddress(record[0])
+		if err != nil {
+			errs = append(errs, fmt.Errorf("line %d: %w", line, err))
+			continue
+		}
+
+		if prev, ok := seen[address]; ok {
+			errs = append(errs, fmt.Errorf("line %d: duplicate address %s (first on line %d)", line, address.Hex(), prev))
+			continue
+		}
+
+		seen[address] = line
+
+		amount, err := units.Parse(record[1], decimals)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("line %d: %w", line, err))
+			continue
+		}
+
+		if amount.Sign() == 0 {
+			errs = append(errs, fmt.Errorf("line %d: zero amount", line))
+			continue
+		}
+
+		rows = append(rows, Row{Line: line, Address: address, Amount: amount})
+	}
+
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+
+	if len(rows) == 0 {
+		return nil, errors.New("no rows")
+	}
+
+	return rows, nil
+}
+
+// parseAddress parses a recipient address, which must not be zero.
+func parseAddress(s string) (common.Address, error) {
+	address, err := eip55.Parse(s)
+	if err != nil {
+		return common.Address{}, err
+	}
+
+	if address == (common.Address{}) {
+		return common.Address{}, errors.New("zero address")
+	}
+
+	return address, nil
+}
+
+// Total sums the amounts of all rows.
+func Total(rows []Row) *big.Int {
+	total := new(big.Int)
+	for _, row := range rows {
+		total.Add(total, row.Amount)
+	}
+
+	return total
+}
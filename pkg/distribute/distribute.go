@@ -0,0 +1,290 @@
+package distribute
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	token "go-ethereum-example/gen"
+	"math/big"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// Backend is the chain access needed to send and confirm transfers.
+type Backend interface {
+	bind.ContractBackend
+	bind.DeployBackend
+}
+
+// Distributor sends one Token transfer per row with locally managed nonces.
+type Distributor struct {
+	Backend     Backend
+	Token       *token.Token
+	Opts        *bind.TransactOpts // signer and fees; the nonce is managed by the distributor
+	Journal     *Journal
+	Concurrency int         // maximum number of unconfirmed transactions
+	OnEntry     func(Entry) // called for every journal entry recorded, may be nil
+}
+
+// Plan splits rows into those already confirmed according to the journal,
+// those with a transaction sent by a previous run but not confirmed, and those
+// never sent. Run resolves the sent rows before sending anything new.
+//
+// It fails when a row does not match its journal entry, e.g. because the CSV
+// was edited since the last run, as resuming could then pay a recipient twice.
+func (d *Distributor) Plan(rows []Row) (done, sent, fresh []Row, err error) {
+	for _, row := range rows {
+		entry, ok, err := d.entry(row)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+
+		switch {
+		case ok && entry.Status == StatusConfirmed:
+			done = append(done, row)
+		case ok && entry.Status == StatusSent:
+			sent = append(sent, row)
+		default:
+			fresh = append(fresh, row)
+		}
+	}
+
+	return done, sent, fresh, nil
+}
+
+// Owed returns the amount that has yet to leave the sender's balance for the
+// rows to be paid: the rows never sent, and the sent ones whose transaction is
+// not mined yet.
+func (d *Distributor) Owed(ctx context.Context, rows []Row) (*big.Int, error) {
+	_, sent, fresh, err := d.Plan(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	owed := Total(fresh)
+
+	for _, row := range sent {
+		entry, _ := d.Journal.Get(row.Address)
+
+		if _, err := d.Backend.TransactionReceipt(ctx, entry.Hash); err == nil {
+			continue
+		} else if !errors.Is(err, ethereum.NotFound) {
+			return nil, fmt.Errorf("line %d: receipt of %s: %w", row.Line, entry.Hash.Hex(), err)
+		}
+
+		owed.Add(owed, row.Amount)
+	}
+
+	return owed, nil
+}
+
+// entry returns the journal entry of a row, checking that it is for the same
+// amount.
+func (d *Distributor) entry(row Row) (Entry, bool, error) {
+	entry, ok := d.Journal.Get(row.Address)
+	if ok && entry.Amount != row.Amount.String() {
+		return Entry{}, false, fmt.Errorf("line %d: %s is journaled with amount %s, not %s; the CSV changed since the last run", row.Line, row.Address.Hex(), entry.Amount, row.Amount)
+	}
+
+	return entry, ok, nil
+}
+
+// Run pays every row that is not confirmed in the journal. It stops sending
+// after the first failed broadcast, since later nonces would leave a gap.
+func (d *Distributor) Run(ctx context.Context, rows []Row) error {
+	// Refuse to resume over a CSV that no longer matches the journal
+	if _, _, _, err := d.Plan(rows); err != nil {
+		return err
+	}
+
+	concurrency := d.Concurrency
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	var (
+		wg   sync.WaitGroup
+		sem  = make(chan struct{}, concurrency)
+		mu   sync.Mutex
+		errs []error
+	)
+
+	fail := func(err error) {
+		mu.Lock()
+		errs = append(errs, err)
+		mu.Unlock()
+	}
+
+	wait := func(entry Entry, tx *types.Transaction) {
+		defer wg.Done()
+		defer func() { <-sem }()
+
+		receipt, err := bind.WaitMined(ctx, d.Backend, tx)
+		if err != nil {
+			fail(fmt.Errorf("line %d: %w", entry.Line, err))
+			return
+		}
+
+		entry.Status = StatusConfirmed
+		if receipt.Status != types.ReceiptStatusSuccessful {
+			entry.Status = StatusFailed
+			fail(fmt.Errorf("line %d: transaction %s reverted", entry.Line, entry.Hash.Hex()))
+		}
+
+		entry.Raw = nil
+		if err := d.record(entry); err != nil {
+			fail(err)
+		}
+	}
+
+	// Resolve transactions left unconfirmed by a previous run by rebroadcasting
+	// the exact transaction that was journaled.
+	var fresh []Row
+
+	for _, row := range rows {
+		entry, ok := d.Journal.Get(row.Address)
+		if !ok || entry.Status != StatusSent {
+			if !ok || entry.Status != StatusConfirmed {
+				fresh = append(fresh, row)
+			}
+			continue
+		}
+
+		tx := new(types.Transaction)
+		if err := tx.UnmarshalBinary(entry.Raw); err != nil {
+			return fmt.Errorf("line %d: %w", row.Line, err)
+		}
+
+		if err := d.Backend.SendTransaction(ctx, tx); err != nil {
+			if !isKnown(err) && !isNonceTooLow(err) {
+				wg.Wait()
+				return fmt.Errorf("line %d: rebroadcast %s: %w", row.Line, tx.Hash().Hex(), err)
+			}
+
+			// The nonce is used: either by this transaction, which then has a
+			// receipt, or by another one, in which case the row was never paid.
+			if _, receiptErr := d.Backend.TransactionReceipt(ctx, tx.Hash()); isNonceTooLow(err) && receiptErr != nil {
+				entry.Status, entry.Raw = StatusFailed, nil
+				if err := d.record(entry); err != nil {
+					wg.Wait()
+					return err
+				}
+
+				fresh = append(fresh, row)
+				continue
+			}
+		}
+
+		sem <- struct{}{}
+		wg.Add(1)
+		go wait(entry, tx)
+	}
+
+	nonce, err := d.Backend.PendingNonceAt(ctx, d.Opts.From)
+	if err != nil {
+		wg.Wait()
+		return err
+	}
+
+	for _, row := range fresh {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			wg.Wait()
+			return ctx.Err()
+		}
+
+		mu.Lock()
+		stop := len(errs) > 0
+		mu.Unlock()
+
+		if stop {
+			<-sem
+			break
+		}
+
+		entry, tx, err := d.send(ctx, row, nonce)
+		if err != nil {
+			<-sem
+			fail(fmt.Errorf("line %d: %w", row.Line, err))
+			break
+		}
+
+		nonce++
+
+		wg.Add(1)
+		go wait(entry, tx)
+	}
+
+	wg.Wait()
+
+	return errors.Join(errs...)
+}
+
+// send signs the transfer, journals it and only then broadcasts it. A failed
+// broadcast leaves the entry sent: the transaction may have reached the node
+// anyway, so the next run rebroadcasts it rather than signing a new one.
+func (d *Distributor) send(ctx context.Context, row Row, nonce uint64) (Entry, *types.Transaction, error) {
+	opts := *d.Opts
+	opts.Context = ctx
+	opts.Nonce = new(big.Int).SetUint64(nonce)
+	opts.NoSend = true
+
+	tx, err := d.Token.Transfer(&opts, row.Address, row.Amount)
+	if err != nil {
+		return Entry{}, nil, err
+	}
+
+	raw, err := tx.MarshalBinary()
+	if err != nil {
+		return Entry{}, nil, err
+	}
+
+	entry := Entry{
+		Line:    row.Line,
+		Address: row.Address,
+		Amount:  row.Amount.String(),
+		Nonce:   nonce,
+		Hash:    tx.Hash(),
+		Raw:     raw,
+		Status:  StatusSent,
+	}
+
+	if err := d.record(entry); err != nil {
+		return Entry{}, nil, err
+	}
+
+	if err := d.Backend.SendTransaction(ctx, tx); err != nil {
+		return Entry{}, nil, fmt.Errorf("broadcast %s: %w", tx.Hash().Hex(), err)
+	}
+
+	return entry, tx, nil
+}
+
+func (d *Distributor) record(entry Entry) error {
+	if err := d.Journal.Record(entry); err != nil {
+		return err
+	}
+
+	if d.OnEntry != nil {
+		d.OnEntry(entry)
+	}
+
+	return nil
+}
+
+// isKnown reports whether a rebroadcast was rejected because the node already
+// has the transaction in its pool.
+func isKnown(err error) bool {
+	return strings.Contains(strings.ToLower(err.Error()), "already known")
+}
+
+// isNonceTooLow reports whether a rebroadcast was rejected because its nonce
+// has already been used.
+func isNonceTooLow(err error) bool {
+	return strings.Contains(strings.ToLower(err.Error()), "nonce too low")
+}
@@ -0,0 +1,266 @@
+package distribute_test
+
+import (
+	"context"
+	"errors"
+	token "go-ethereum-example/gen"
+	"go-ethereum-example/pkg/distribute"
+	"go-ethereum-example/pkg/testchain"
+	"math/big"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+var supply = big.NewInt(1_000_000)
+
+// flakyBackend fails the broadcast of the nth transaction it is given, after
+// passing it on to the node when delivered is set, as a dropped connection
+// may.
+type flakyBackend struct {
+	distribute.Backend
+
+	n         int
+	delivered bool
+	sent      int
+}
+
+func (b *flakyBackend) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	b.sent++
+	if b.sent != b.n {
+		return b.Backend.SendTransaction(ctx, tx)
+	}
+
+	if b.delivered {
+		if err := b.Backend.SendTransaction(ctx, tx); err != nil {
+			return err
+		}
+	}
+
+	return errors.New("connection reset by peer")
+}
+
+type fixture struct {
+	chain       *testchain.Chain
+	distributor func(backend distribute.Backend) *distribute.Distributor
+	rows        func(csv string) []distribute.Row
+	balance     func(row distribute.Row) int64
+}
+
+func setup(t *testing.T) *fixture {
+	chain := testchain.New(t)
+	owner := chain.Accounts[0]
+
+	address, tokenInstance := chain.DeployToken(owner, supply)
+	journalPath := filepath.Join(t.TempDir(), "recipients.csv.journal")
+
+	return &fixture{
+		chain: chain,
+
+		// Every distributor reopens the journal, as a new run would
+		distributor: func(backend distribute.Backend) *distribute.Distributor {
+			journal, err := distribute.OpenJournal(journalPath)
+			if err != nil {
+				t.Fatal(err)
+			}
+			t.Cleanup(func() { journal.Close() })
+
+			tokenInstance, err := token.NewToken(address, backend)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			return &distribute.Distributor{
+				Backend:     backend,
+				Token:       tokenInstance,
+				Opts:        chain.Transactor(owner),
+				Journal:     journal,
+				Concurrency: 2,
+			}
+		},
+
+		rows: func(csv string) []distribute.Row {
+			rows, err := distribute.ReadCSV(strings.NewReader(csv), 0)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			return rows
+		},
+
+		balance: func(row distribute.Row) int64 {
+			balance, err := tokenInstance.BalanceOf(nil, row.Address)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			return balance.Int64()
+		},
+	}
+}
+
+// recipients returns CSV rows paying 100, 200... to the accounts.
+func recipients(accounts []testchain.Account) string {
+	var csv string
+	for i, a := range accounts {
+		csv += a.Address.Hex() + "," + big.NewInt(int64(100*(i+1))).String() + "\n"
+	}
+
+	return csv
+}
+
+func TestRun(t *testing.T) {
+	f := setup(t)
+	rows := f.rows(recipients(f.chain.Accounts[1:4]))
+
+	d := f.distributor(f.chain.Client)
+
+	done, sent, fresh, err := d.Plan(rows)
+	if err != nil || len(done) != 0 || len(sent) != 0 || len(fresh) != 3 {
+		t.Fatalf("plan %d done, %d sent, %d fresh: %v", len(done), len(sent), len(fresh), err)
+	}
+
+	if err := d.Run(context.Background(), rows); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, row := range rows {
+		if balance := f.balance(row); balance != row.Amount.Int64() {
+			t.Errorf("line %d: balance %d, want %s", row.Line, balance, row.Amount)
+		}
+	}
+
+	// A second run finds everything confirmed and sends nothing
+	d = f.distributor(f.chain.Client)
+
+	done, _, fresh, err = d.Plan(rows)
+	if err != nil || len(done) != 3 || len(fresh) != 0 {
+		t.Fatalf("plan after run %d done, %d fresh: %v", len(done), len(fresh), err)
+	}
+
+	if err := d.Run(context.Background(), rows); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, row := range rows {
+		if balance := f.balance(row); balance != row.Amount.Int64() {
+			t.Errorf("line %d: balance %d after a second run, want %s", row.Line, balance, row.Amount)
+		}
+	}
+}
+
+// TestPlanEditedCSV checks that rows keep their journal entries when lines
+// move, and that a changed amount is refused.
+func TestPlanEditedCSV(t *testing.T) {
+	f := setup(t)
+	accounts := f.chain.Accounts
+
+	rows := f.rows(recipients(accounts[1:3]))
+
+	if err := f.distributor(f.chain.Client).Run(context.Background(), rows); err != nil {
+		t.Fatal(err)
+	}
+
+	// A recipient inserted before the paid ones moves them down a line
+	inserted := f.rows(accounts[5].Address.Hex() + ",7\n" + recipients(accounts[1:3]))
+
+	d := f.distributor(f.chain.Client)
+
+	done, _, fresh, err := d.Plan(inserted)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(done) != 2 || len(fresh) != 1 || fresh[0].Address != accounts[5].Address {
+		t.Fatalf("plan %d done, fresh %+v", len(done), fresh)
+	}
+
+	if err := d.Run(context.Background(), inserted); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, row := range inserted {
+		if balance := f.balance(row); balance != row.Amount.Int64() {
+			t.Errorf("%s: balance %d, want %s", row.Address.Hex(), balance, row.Amount)
+		}
+	}
+
+	// A paid recipient whose amount changed
+	changed := f.rows(accounts[1].Address.Hex() + ",150\n")
+
+	if _, _, _, err := d.Plan(changed); err == nil {
+		t.Error("planned a row whose amount differs from the journal")
+	}
+
+	if err := d.Run(context.Background(), changed); err == nil {
+		t.Error("ran a row whose amount differs from the journal")
+	}
+
+	if balance := f.balance(changed[0]); balance != 100 {
+		t.Errorf("balance %d after a refused run, want 100", balance)
+	}
+}
+
+// TestResume checks that a transfer whose broadcast failed is paid exactly
+// once by the next run, whether or not it reached the node.
+func TestResume(t *testing.T) {
+	for _, delivered := range []bool{false, true} {
+		name := "lost"
+		if delivered {
+			name = "delivered"
+		}
+
+		t.Run(name, func(t *testing.T) {
+			f := setup(t)
+			rows := f.rows(recipients(f.chain.Accounts[1:4]))
+
+			// The second transfer fails to broadcast, and the run stops there
+			flaky := &flakyBackend{Backend: f.chain.Client, n: 2, delivered: delivered}
+
+			d := f.distributor(flaky)
+			d.Concurrency = 1
+
+			if err := d.Run(context.Background(), rows); err == nil {
+				t.Fatal("run succeeded despite a failed broadcast")
+			}
+
+			d = f.distributor(f.chain.Client)
+
+			done, sent, fresh, err := d.Plan(rows)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if len(done) != 1 || len(sent) != 1 || sent[0] != rows[1] || len(fresh) != 1 {
+				t.Fatalf("plan %d done, sent %+v, %d fresh", len(done), sent, len(fresh))
+			}
+
+			// Only what has not left the sender's balance yet is owed
+			owed, err := d.Owed(context.Background(), rows)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			want := rows[1].Amount.Int64() + rows[2].Amount.Int64()
+			if delivered {
+				want = rows[2].Amount.Int64()
+			}
+
+			if owed.Int64() != want {
+				t.Errorf("owed %s, want %d", owed, want)
+			}
+
+			if err := d.Run(context.Background(), rows); err != nil {
+				t.Fatal(err)
+			}
+
+			for _, row := range rows {
+				if balance := f.balance(row); balance != row.Amount.Int64() {
+					t.Errorf("line %d: balance %d, want %s", row.Line, balance, row.Amount)
+				}
+			}
+		})
+	}
+}
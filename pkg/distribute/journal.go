@@ -0,0 +1,125 @@
+package distribute
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"os"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// Entry statuses recorded in the journal.
+const (
+	StatusSent      = "sent"
+	StatusConfirmed = "confirmed"
+	StatusFailed    = "failed"
+)
+
+// Entry is one journal line. The signed transaction is recorded before it is
+// broadcast, so a resumed run rebroadcasts the same transaction (same nonce)
+// instead of paying the recipient twice.
+type Entry struct {
+	Line    int            `json:"line"`
+	Address common.Address `json:"address"`
+	Amount  string         `json:"amount"`
+	Nonce   uint64         `json:"nonce"`
+	Hash    common.Hash    `json:"hash"`
+	Raw     hexutil.Bytes  `json:"raw,omitempty"`
+	Status  string         `json:"status"`
+}
+
+// Journal is an append-only JSONL file of entries. The latest entry per
+// recipient address wins, so rows keep their entries when the CSV lines move.
+type Journal struct {
+	mu      sync.Mutex
+	f       *os.File
+	entries map[common.Address]Entry
+}
+
+// OpenJournal opens or creates the journal at path and loads its entries.
+func OpenJournal(path string) (*Journal, error) {
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, err
+	}
+
+	j := &Journal{f: f, entries: make(map[common.Address]Entry)}
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+
+	for scanner.Scan() {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+
+		var entry Entry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			f.Close()
+			return nil, err
+		}
+
+		j.entries[entry.Address] = entry
+	}
+
+	if err := scanner.Err(); err != nil {
+		f.Close()
+		return nil, err
+	}
+
+	return j, nil
+}
+
+// Get returns the latest entry for a recipient.
+func (j *Journal) Get(address common.Address) (Entry, bool) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	entry, ok := j.entries[address]
+	return entry, ok
+}
+
+// Record appends an entry and syncs it to disk before returning.
+func (j *Journal) Record(entry Entry) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if j.f == nil {
+		return errors.New("journal is closed")
+	}
+
+	b, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	if _, err := j.f.Write(append(b, '\n')); err != nil {
+		return err
+	}
+
+	if err := j.f.Sync(); err != nil {
+		return err
+	}
+
+	j.entries[entry.Address] = entry
+
+	return nil
+}
+
+// Close closes the journal file.
+func (j *Journal) Close() error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if j.f == nil {
+		return nil
+	}
+
+	err := j.f.Close()
+	j.f = nil
+
+	return err
+}
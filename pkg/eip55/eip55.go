@@ -0,0 +1,28 @@
+// Package eip55 parses hex addresses, checking the EIP-55 checksum of mixed
+// case ones, for every command and file format that takes addresses.
+package eip55
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Parse accepts hex addresses and, when they are mixed case, requires a valid
+// EIP-55 checksum.
+func Parse(s string) (common.Address, error) {
+	if !common.IsHexAddress(s) {
+		return common.Address{}, fmt.Errorf("invalid address %q", s)
+	}
+
+	address := common.HexToAddress(s)
+
+	// Mixed case means an EIP-55 checksum, which must match
+	digits := strings.TrimPrefix(strings.TrimPrefix(s, "0x"), "0X")
+	if digits != strings.ToLower(digits) && digits != strings.ToUpper(digits) && digits != address.Hex()[2:] {
+		return common.Address{}, fmt.Errorf("address %q has an invalid checksum", s)
+	}
+
+	return address, nil
+}
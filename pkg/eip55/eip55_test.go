@@ -0,0 +1,37 @@
+package eip55_test
+
+import (
+	"go-ethereum-example/pkg/eip55"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestParse(t *testing.T) {
+	want := common.HexToAddress("0x70997970C51812dc3A010C7d01b50e0d17dc79C8")
+
+	tests := []struct {
+		in  string
+		err bool
+	}{
+		{in: "0x70997970C51812dc3A010C7d01b50e0d17dc79C8"},
+		{in: "0x70997970c51812dc3a010c7d01b50e0d17dc79c8"},
+		{in: "0x70997970C51812DC3A010C7D01B50E0D17DC79C8"},
+		{in: "70997970c51812dc3a010c7d01b50e0d17dc79c8"},
+		{in: "0x70997970C51812dc3A010C7d01b50e0d17dc79c8", err: true},
+		{in: "0x1234", err: true},
+		{in: "", err: true},
+	}
+
+	for _, tt := range tests {
+		got, err := eip55.Parse(tt.in)
+		if (err != nil) != tt.err {
+			t.Errorf("Parse(%q) error %v, want error %t", tt.in, err, tt.err)
+			continue
+		}
+
+		if err == nil && got != want {
+			t.Errorf("Parse(%q) = %s, want %s", tt.in, got.Hex(), want.Hex())
+		}
+	}
+}
@@ -0,0 +1,541 @@
+// Package eip712 hashes, signs and verifies EIP-712 typed structured data in the
+// JSON shape used by eth_signTypedData_v4.
+package eip712
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// DomainType is the name of the domain struct type.
+const DomainType = "EIP712Domain"
+
+// Field is a member of a struct type.
+type Field struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+// Types maps struct type names to their members.
+type Types map[string][]Field
+
+// TypedData is a message together with its types and signing domain.
+type TypedData struct {
+	Types       Types                  `json:"types"`
+	PrimaryType string                 `json:"primaryType"`
+	Domain      map[string]interface{} `json:"domain"`
+	Message     map[string]interface{} `json:"message"`
+}
+
+// domainFields lists the EIP712Domain members in the order the EIP defines them.
+var domainFields = []Field{
+	{Name: "name", Type: "string"},
+	{Name: "version", Type: "string"},
+	{Name: "chainId", Type: "uint256"},
+	{Name: "verifyingContract", Type: "address"},
+	{Name: "salt", Type: "bytes32"},
+}
+
+// DomainFields returns the EIP712Domain type for the members present in domain.
+func DomainFields(domain map[string]interface{}) []Field {
+	var fields []Field
+	for _, f := range domainFields {
+		if _, ok := domain[f.Name]; ok {
+			fields = append(fields, f)
+		}
+	}
+
+	return fields
+}
+
+// types returns td.Types with EIP712Domain derived from the domain if missing.
+func (td *TypedData) types() Types {
+	if _, ok := td.Types[DomainType]; ok {
+		return td.Types
+	}
+
+	types := make(Types, len(td.Types)+1)
+	for k, v := range td.Types {
+		types[k] = v
+	}
+
+	types[DomainType] = DomainFields(td.Domain)
+
+	return types
+}
+
+// DomainSeparator returns hashStruct(EIP712Domain, domain).
+func (td *TypedData) DomainSeparator() (common.Hash, error) {
+	return td.types().HashStruct(DomainType, td.Domain)
+}
+
+// MessageHash returns hashStruct(primaryType, message).
+func (td *TypedData) MessageHash() (common.Hash, error) {
+	return td.types().HashStruct(td.PrimaryType, td.Message)
+}
+
+// Digest returns keccak256("\x19\x01" || domainSeparator || hashStruct(message)),
+// the hash that is signed.
+func (td *TypedData) Digest() (common.Hash, error) {
+	separator, err := td.DomainSeparator()
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("domain: %w", err)
+	}
+
+	if td.PrimaryType == DomainType {
+		return crypto.Keccak256Hash([]byte{0x19, 0x01}, separator[:]), nil
+	}
+
+	message, err := td.MessageHash()
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("message: %w", err)
+	}
+
+	return crypto.Keccak256Hash([]byte{0x19, 0x01}, separator[:], message[:]), nil
+}
+
+// Sign signs the digest and returns the 65-byte r || s || v signature with v
+// in {27, 28}, as returned by eth_signTypedData_v4.
+func (td *TypedData) Sign(privateKey *ecdsa.PrivateKey) ([]byte, error) {
+	digest, err := td.Digest()
+	if err != nil {
+		return nil, err
+	}
+
+	sig, err := crypto.Sign(digest[:], privateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	sig[64] += 27
+
+	return sig, nil
+}
+
+// Recover returns the address that signed the typed data. Both {0, 1} and
+// {27, 28} recovery ids are accepted.
+func (td *TypedData) Recover(sig []byte) (common.Address, error) {
+	if len(sig) != crypto.SignatureLength {
+		return common.Address{}, fmt.Errorf("signature must be %d bytes", crypto.SignatureLength)
+	}
+
+	digest, err := td.Digest()
+	if err != nil {
+		return common.Address{}, err
+	}
+
+	raw := common.CopyBytes(sig)
+	if raw[64] >= 27 {
+		raw[64] -= 27
+	}
+
+	pub, err := crypto.SigToPub(digest[:], raw)
+	if err != nil {
+		return common.Address{}, err
+	}
+
+	return crypto.PubkeyToAddress(*pub), nil
+}
+
+// Verify reports whether sig was produced by signer.
+func (td *TypedData) Verify(signer common.Address, sig []byte) (bool, error) {
+	recovered, err := td.Recover(sig)
+	if err != nil {
+		return false, err
+	}
+
+	return recovered == signer, nil
+}
+
+// EncodeType returns the type string of a struct, e.g.
+// "Mail(Person from,Person to,string contents)Person(string name,address wallet)".
+// Referenced struct types are appended sorted by name.
+func (types Types) EncodeType(primary string) (string, error) {
+	if _, ok := types[primary]; !ok {
+		return "", fmt.Errorf("unknown type %q", primary)
+	}
+
+	deps := make(map[string]bool)
+	if err := types.dependencies(primary, deps); err != nil {
+		return "", err
+	}
+
+	delete(deps, primary)
+
+	names := make([]string, 0, len(deps))
+	for name := range deps {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var b strings.Builder
+	for _, name := range append([]string{primary}, names...) {
+		b.WriteString(name)
+		b.WriteByte('(')
+		for i, f := range types[name] {
+			if i > 0 {
+				b.WriteByte(',')
+			}
+			b.WriteString(f.Type)
+			b.WriteByte(' ')
+			b.WriteString(f.Name)
+		}
+		b.WriteByte(')')
+	}
+
+	return b.String(), nil
+}
+
+func (types Types) dependencies(name string, found map[string]bool) error {
+	if found[name] {
+		return nil
+	}
+
+	fields, ok := types[name]
+	if !ok {
+		return fmt.Errorf("unknown type %q", name)
+	}
+
+	found[name] = true
+
+	for _, f := range fields {
+		if base := baseType(f.Type); types.isStruct(base) {
+			if err := types.dependencies(base, found); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// TypeHash returns keccak256(encodeType(name)).
+func (types Types) TypeHash(name string) (common.Hash, error) {
+	encoded, err := types.EncodeType(name)
+	if err != nil {
+		return common.Hash{}, err
+	}
+
+	return crypto.Keccak256Hash([]byte(encoded)), nil
+}
+
+// HashStruct returns keccak256(typeHash || encodeData(data)).
+func (types Types) HashStruct(name string, data map[string]interface{}) (common.Hash, error) {
+	encoded, err := types.EncodeData(name, data)
+	if err != nil {
+		return common.Hash{}, err
+	}
+
+	return crypto.Keccak256Hash(encoded), nil
+}
+
+// EncodeData returns typeHash || enc(member) for every member of the struct, in
+// declaration order, each encoded as 32 bytes. Missing members are an error.
+func (types Types) EncodeData(name string, data map[string]interface{}) ([]byte, error) {
+	fields, ok := types[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown type %q", name)
+	}
+
+	typeHash, err := types.TypeHash(name)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(data) > len(fields) {
+		return nil, fmt.Errorf("%s: %d values for %d members", name, len(data), len(fields))
+	}
+
+	buf := bytes.NewBuffer(typeHash.Bytes())
+
+	for _, f := range fields {
+		value, ok := data[f.Name]
+		if !ok {
+			return nil, fmt.Errorf("%s: missing member %q", name, f.Name)
+		}
+
+		enc, err := types.encodeValue(f.Type, value)
+		if err != nil {
+			return nil, fmt.Errorf("%s.%s: %w", name, f.Name, err)
+		}
+
+		buf.Write(enc)
+	}
+
+	return buf.Bytes(), nil
+}
+
+var arraySuffix = regexp.MustCompile(`\[(\d*)\]$`)
+
+// baseType strips every array suffix, e.g. "Person[][2]" -> "Person".
+func baseType(typ string) string {
+	if i := strings.IndexByte(typ, '['); i >= 0 {
+		return typ[:i]
+	}
+
+	return typ
+}
+
+func (types Types) isStruct(typ string) bool {
+	_, ok := types[typ]
+	return ok
+}
+
+// encodeValue encodes a single member value as 32 bytes.
+func (types Types) encodeValue(typ string, value interface{}) ([]byte, error) {
+	// Arrays: keccak256 of the concatenated encodings of the elements
+	if m := arraySuffix.FindStringSubmatch(typ); m != nil {
+		elemType := typ[:len(typ)-len(m[0])]
+
+		items, err := toSlice(value)
+		if err != nil {
+			return nil, err
+		}
+
+		if m[1] != "" {
+			if n, _ := strconv.Atoi(m[1]); n != len(items) {
+				return nil, fmt.Errorf("%s: got %d elements", typ, len(items))
+			}
+		}
+
+		var buf bytes.Buffer
+		for i, item := range items {
+			enc, err := types.encodeValue(elemType, item)
+			if err != nil {
+				return nil, fmt.Errorf("[%d]: %w", i, err)
+			}
+			buf.Write(enc)
+		}
+
+		return crypto.Keccak256(buf.Bytes()), nil
+	}
+
+	// Structs: hashStruct of the nested value
+	if types.isStruct(typ) {
+		data, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("%s: expected object, got %T", typ, value)
+		}
+
+		hash, err := types.HashStruct(typ, data)
+		if err != nil {
+			return nil, err
+		}
+
+		return hash.Bytes(), nil
+	}
+
+	return encodeAtomic(typ, value)
+}
+
+func encodeAtomic(typ string, value interface{}) ([]byte, error) {
+	switch {
+	case typ == "string":
+		s, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("string: got %T", value)
+		}
+
+		return crypto.Keccak256([]byte(s)), nil
+
+	case typ == "bytes":
+		b, err := toBytes(value)
+		if err != nil {
+			return nil, err
+		}
+
+		return crypto.Keccak256(b), nil
+
+	case typ == "bool":
+		b, ok := value.(bool)
+		if !ok {
+			return nil, fmt.Errorf("bool: got %T", value)
+		}
+
+		if b {
+			return math.U256Bytes(big.NewInt(1)), nil
+		}
+
+		return make([]byte, 32), nil
+
+	case typ == "address":
+		addr, err := toAddress(value)
+		if err != nil {
+			return nil, err
+		}
+
+		return common.LeftPadBytes(addr[:], 32), nil
+
+	case strings.HasPrefix(typ, "bytes"):
+		size, err := strconv.Atoi(typ[len("bytes"):])
+		if err != nil || size < 1 || size > 32 {
+			return nil, fmt.Errorf("unknown type %q", typ)
+		}
+
+		b, err := toBytes(value)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(b) != size {
+			return nil, fmt.Errorf("%s: got %d bytes", typ, len(b))
+		}
+
+		return common.RightPadBytes(b, 32), nil
+
+	case strings.HasPrefix(typ, "uint"), strings.HasPrefix(typ, "int"):
+		signed := strings.HasPrefix(typ, "int")
+
+		bits, err := strconv.Atoi(strings.TrimPrefix(strings.TrimPrefix(typ, "u"), "int"))
+		if err != nil || bits < 8 || bits > 256 || bits%8 != 0 {
+			return nil, fmt.Errorf("unknown type %q", typ)
+		}
+
+		n, err := toBigInt(value)
+		if err != nil {
+			return nil, err
+		}
+
+		min, max := new(big.Int), new(big.Int).Lsh(big.NewInt(1), uint(bits))
+		if signed {
+			max.Rsh(max, 1)
+			min.Neg(max)
+		}
+
+		if n.Cmp(min) < 0 || n.Cmp(max) >= 0 {
+			return nil, fmt.Errorf("%s: %s out of range", typ, n)
+		}
+
+		return math.U256Bytes(new(big.Int).Set(n)), nil
+	}
+
+	return nil, fmt.Errorf("unknown type %q", typ)
+}
+
+func toSlice(value interface{}) ([]interface{}, error) {
+	switch v := value.(type) {
+	case []interface{}:
+		return v, nil
+	case []map[string]interface{}:
+		items := make([]interface{}, len(v))
+		for i := range v {
+			items[i] = v[i]
+		}
+		return items, nil
+	case []string:
+		items := make([]interface{}, len(v))
+		for i := range v {
+			items[i] = v[i]
+		}
+		return items, nil
+	case []common.Address:
+		items := make([]interface{}, len(v))
+		for i := range v {
+			items[i] = v[i]
+		}
+		return items, nil
+	}
+
+	return nil, fmt.Errorf("expected array, got %T", value)
+}
+
+func toBytes(value interface{}) ([]byte, error) {
+	switch v := value.(type) {
+	case []byte:
+		return v, nil
+	case hexutil.Bytes:
+		return v, nil
+	case common.Hash:
+		return v.Bytes(), nil
+	case string:
+		return hexutil.Decode(v)
+	}
+
+	return nil, fmt.Errorf("expected bytes, got %T", value)
+}
+
+func toAddress(value interface{}) (common.Address, error) {
+	switch v := value.(type) {
+	case common.Address:
+		return v, nil
+	case string:
+		if !common.IsHexAddress(v) {
+			return common.Address{}, fmt.Errorf("invalid address %q", v)
+		}
+		return common.HexToAddress(v), nil
+	}
+
+	return common.Address{}, fmt.Errorf("expected address, got %T", value)
+}
+
+// toBigInt accepts Go integers, JSON numbers and decimal or 0x-prefixed strings.
+func toBigInt(value interface{}) (*big.Int, error) {
+	switch v := value.(type) {
+	case *big.Int:
+		return v, nil
+	case int:
+		return big.NewInt(int64(v)), nil
+	case int64:
+		return big.NewInt(v), nil
+	case uint64:
+		return new(big.Int).SetUint64(v), nil
+	case float64:
+		if v != float64(int64(v)) {
+			return nil, fmt.Errorf("non-integer number %v", v)
+		}
+		return big.NewInt(int64(v)), nil
+	case json.Number:
+		return parseBigInt(v.String())
+	case string:
+		return parseBigInt(v)
+	}
+
+	return nil, fmt.Errorf("expected integer, got %T", value)
+}
+
+func parseBigInt(s string) (*big.Int, error) {
+	n, ok := math.ParseBig256(s)
+	if !ok {
+		if neg, ok := math.ParseBig256(strings.TrimPrefix(s, "-")); ok && strings.HasPrefix(s, "-") {
+			return neg.Neg(neg), nil
+		}
+
+		return nil, fmt.Errorf("invalid integer %q", s)
+	}
+
+	return n, nil
+}
+
+// Parse decodes eth_signTypedData_v4 JSON. Numbers are kept exact.
+func Parse(data []byte) (*TypedData, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	var td TypedData
+	if err := dec.Decode(&td); err != nil {
+		return nil, err
+	}
+
+	if td.PrimaryType == "" {
+		return nil, errors.New("missing primaryType")
+	}
+
+	if td.Domain == nil {
+		return nil, errors.New("missing domain")
+	}
+
+	return &td, nil
+}
@@ -0,0 +1,262 @@
+package eip712
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// Reference vectors from the EIP-712 specification (mail.json) and the
+// eth_signTypedData_v4 array example (mail_arrays.json), signed with the key
+// keccak256("cow").
+var referenceTests = []struct {
+	file            string
+	encodeType      string
+	typeHash        string
+	domainSeparator string
+	messageHash     string
+	digest          string
+	signature       string
+}{
+	{
+		file:            "mail.json",
+		encodeType:      "Mail(Person from,Person to,string contents)Person(string name,address wallet)",
+		typeHash:        "0xa0cedeb2dc280ba39b857546d74f5549c3a1d7bdc2dd96bf881f76108e23dac2",
+		domainSeparator: "0xf2cee375fa42b42143804025fc449deafd50cc031ca257e0b194a650a912090f",
+		messageHash:     "0xc52c0ee5d84264471806290a3f2c4cecfc5490626bf912d01f240d7a274b371e",
+		digest:          "0xbe609aee343fb3c4b28e1df9e632fca64fcfaede20f02e86244efddf30957bd2",
+		signature:       "0x4355c47d63924e8a72e509b65029052eb6c299d53a04e167c5775fd466751c9d07299936d304c153f6443dfa05f40ff007d72911b6f72307f996231605b915621c",
+	},
+	{
+		file:            "mail_arrays.json",
+		encodeType:      "Mail(Person from,Person[] to,string contents)Person(string name,address[] wallets)",
+		typeHash:        "0x4bd8a9a2b93427bb184aca81e24beb30ffa3c747e2a33d4225ec08bf12e2e753",
+		domainSeparator: "0xf2cee375fa42b42143804025fc449deafd50cc031ca257e0b194a650a912090f",
+		messageHash:     "0xeb4221181ff3f1a83ea7313993ca9218496e424604ba9492bb4052c03d5c3df8",
+		digest:          "0xa85c2e2b118698e88db68a8105b794a8cc7cec074e89ef991cb4f5f533819cc2",
+		signature:       "0x65cbd956f2fae28a601bebc9b906cea0191744bd4c4247bcd27cd08f8eb6b71c78efdf7a31dc9abee78f492292721f362d296cf86b4538e07b51303b67f749061b",
+	},
+}
+
+func loadTypedData(t *testing.T, name string) *TypedData {
+	t.Helper()
+
+	b, err := os.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	td, err := Parse(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return td
+}
+
+func TestReferenceVectors(t *testing.T) {
+	cow, _ := crypto.ToECDSA(crypto.Keccak256([]byte("cow")))
+
+	for _, tt := range referenceTests {
+		t.Run(tt.file, func(t *testing.T) {
+			td := loadTypedData(t, tt.file)
+
+			encodeType, err := td.Types.EncodeType(td.PrimaryType)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if encodeType != tt.encodeType {
+				t.Errorf("encodeType = %q, want %q", encodeType, tt.encodeType)
+			}
+
+			typeHash, err := td.Types.TypeHash(td.PrimaryType)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if typeHash.Hex() != tt.typeHash {
+				t.Errorf("typeHash = %s, want %s", typeHash.Hex(), tt.typeHash)
+			}
+
+			separator, err := td.DomainSeparator()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if separator.Hex() != tt.domainSeparator {
+				t.Errorf("domainSeparator = %s, want %s", separator.Hex(), tt.domainSeparator)
+			}
+
+			messageHash, err := td.MessageHash()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if messageHash.Hex() != tt.messageHash {
+				t.Errorf("hashStruct(message) = %s, want %s", messageHash.Hex(), tt.messageHash)
+			}
+
+			digest, err := td.Digest()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if digest.Hex() != tt.digest {
+				t.Errorf("digest = %s, want %s", digest.Hex(), tt.digest)
+			}
+
+			sig, err := td.Sign(cow)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if hexutil.Encode(sig) != tt.signature {
+				t.Errorf("signature = %s, want %s", hexutil.Encode(sig), tt.signature)
+			}
+
+			ok, err := td.Verify(common.HexToAddress("0xCD2a3d9F938E13CD947Ec05AbC7FE734Df8DD826"), sig)
+			if err != nil || !ok {
+				t.Errorf("signature does not verify for Cow: %v", err)
+			}
+		})
+	}
+}
+
+func TestDomainTypeDerived(t *testing.T) {
+	td := loadTypedData(t, "mail.json")
+	delete(td.Types, DomainType)
+
+	separator, err := td.DomainSeparator()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if separator.Hex() != referenceTests[0].domainSeparator {
+		t.Fatalf("domainSeparator = %s, want %s", separator.Hex(), referenceTests[0].domainSeparator)
+	}
+}
+
+func TestNestedStructsAndFixedArrays(t *testing.T) {
+	types := Types{
+		"Order": {
+			{Name: "maker", Type: "address"},
+			{Name: "legs", Type: "Leg[2]"},
+			{Name: "matrix", Type: "uint8[][]"},
+			{Name: "salt", Type: "bytes32"},
+		},
+		"Leg": {
+			{Name: "asset", Type: "Asset"},
+			{Name: "amount", Type: "int256"},
+		},
+		"Asset": {
+			{Name: "token", Type: "address"},
+			{Name: "id", Type: "uint256"},
+		},
+	}
+
+	encodeType, err := types.EncodeType("Order")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := "Order(address maker,Leg[2] legs,uint8[][] matrix,bytes32 salt)Asset(address token,uint256 id)Leg(Asset asset,int256 amount)"
+	if encodeType != want {
+		t.Fatalf("encodeType = %q, want %q", encodeType, want)
+	}
+
+	leg := func(amount interface{}) map[string]interface{} {
+		return map[string]interface{}{
+			"asset":  map[string]interface{}{"token": "0x5FbDB2315678afecb367f032d93F642f64180aa3", "id": "0x01"},
+			"amount": amount,
+		}
+	}
+
+	order := map[string]interface{}{
+		"maker":  common.HexToAddress("0x70997970C51812dc3A010C7d01b50e0d17dc79C8"),
+		"legs":   []interface{}{leg(-5), leg("1000000000000000000")},
+		"matrix": []interface{}{[]interface{}{1, 2}, []interface{}{}},
+		"salt":   "0x" + strings.Repeat("ab", 32),
+	}
+
+	// encodeData is the type hash followed by one 32-byte word per member
+	encoded, err := types.EncodeData("Order", order)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(encoded) != 32*5 {
+		t.Fatalf("encodeData length = %d, want %d", len(encoded), 32*5)
+	}
+
+	// The second leg is encoded as hashStruct(Leg), itself embedding hashStruct(Asset)
+	legHash, err := types.HashStruct("Leg", leg(-5))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	secondLeg, _ := types.HashStruct("Leg", leg("1000000000000000000"))
+	if got := crypto.Keccak256Hash(legHash[:], secondLeg[:]); common.BytesToHash(encoded[64:96]) != got {
+		t.Fatal("fixed array of structs not encoded as keccak256 of member hashes")
+	}
+
+	bad := []struct {
+		name  string
+		tweak func(map[string]interface{})
+	}{
+		{"wrong fixed length", func(m map[string]interface{}) { m["legs"] = []interface{}{leg(1)} }},
+		{"uint8 overflow", func(m map[string]interface{}) { m["matrix"] = []interface{}{[]interface{}{256}} }},
+		{"short bytes32", func(m map[string]interface{}) { m["salt"] = "0xabcd" }},
+		{"missing member", func(m map[string]interface{}) { delete(m, "maker") }},
+		{"invalid address", func(m map[string]interface{}) { m["maker"] = "0x1234" }},
+	}
+
+	for _, tt := range bad {
+		m := make(map[string]interface{}, len(order))
+		for k, v := range order {
+			m[k] = v
+		}
+		tt.tweak(m)
+
+		if _, err := types.EncodeData("Order", m); err == nil {
+			t.Errorf("%s: expected error", tt.name)
+		}
+	}
+}
+
+func TestRecoverOtherSigner(t *testing.T) {
+	td := loadTypedData(t, "mail_arrays.json")
+
+	key, _ := crypto.GenerateKey()
+
+	sig, err := td.Sign(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	signer, err := td.Recover(sig)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if signer != crypto.PubkeyToAddress(key.PublicKey) {
+		t.Fatalf("recovered %s", signer.Hex())
+	}
+
+	// Changing the message changes the recovered signer
+	td.Message["contents"] = "Hello, Alice!"
+
+	ok, err := td.Verify(signer, sig)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if ok {
+		t.Fatal("signature verified for a different message")
+	}
+}
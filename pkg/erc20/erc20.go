@@ -0,0 +1,441 @@
+// Package erc20 is a client for arbitrary ERC-20 tokens. Unlike the Token
+// binding it makes low-level calls and decodes the results itself, so it copes
+// with tokens that deviate from the standard: names and symbols returned as
+// bytes32 (MKR), transfers that return nothing (USDT) or false instead of
+// reverting, and missing optional metadata. Transfers follow the semantics of
+// OpenZeppelin's SafeERC20: a call succeeds if it does not revert and returns
+// either nothing or true.
+package erc20
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	token "go-ethereum-example/gen"
+	"go-ethereum-example/pkg/revert"
+	"math/big"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+var (
+	// ErrNotContract is returned when there is no code at the token address.
+	ErrNotContract = errors.New("no contract code at token address")
+
+	// ErrFalseReturned is returned when a token returns false instead of
+	// reverting.
+	ErrFalseReturned = errors.New("token returned false")
+)
+
+// Quirk is a deviation from the ERC-20 standard.
+type Quirk uint
+
+const (
+	// Bytes32Metadata means name or symbol is returned as bytes32.
+	Bytes32Metadata Quirk = 1 << iota
+	// MissingMetadata means name, symbol or decimals is not implemented.
+	MissingMetadata
+	// NoReturnValue means transfer returns nothing instead of a bool.
+	NoReturnValue
+)
+
+var quirkNames = []string{"bytes32 metadata", "missing metadata", "no return value"}
+
+// String lists the quirks, e.g. "bytes32 metadata, no return value".
+func (q Quirk) String() string {
+	if q == 0 {
+		return "none"
+	}
+
+	var names []string
+	for i, name := range quirkNames {
+		if q&(1<<i) != 0 {
+			names = append(names, name)
+		}
+	}
+
+	return strings.Join(names, ", ")
+}
+
+// Has reports whether all quirks of other are set.
+func (q Quirk) Has(other Quirk) bool {
+	return q&other == other
+}
+
+var stringType, _ = abi.NewType("string", "", nil)
+
+// The Token ABI is only used to pack calls; results are decoded by hand
+var erc20ABI = sync.OnceValue(func() *abi.ABI {
+	parsed, err := token.TokenMetaData.GetAbi()
+	if err != nil {
+		panic(err)
+	}
+
+	return parsed
+})
+
+// Backend calls contracts, sends transactions and waits for them.
+type Backend interface {
+	bind.ContractBackend
+	bind.DeployBackend
+}
+
+// Token is an ERC-20 token of unknown implementation.
+type Token struct {
+	Address common.Address
+
+	backend  Backend
+	contract *bind.BoundContract
+}
+
+// New returns a client for the token at address.
+func New(address common.Address, backend Backend) *Token {
+	return &Token{
+		Address:  address,
+		backend:  backend,
+		contract: bind.NewBoundContract(address, *erc20ABI(), backend, backend, backend),
+	}
+}
+
+// Metadata is the normalized metadata of a token. Missing names and symbols
+// are empty, missing decimals are zero, so amounts are shown in base units.
+type Metadata struct {
+	Name        string
+	Symbol      string
+	Decimals    uint8
+	TotalSupply *big.Int
+	Quirks      Quirk
+}
+
+// Metadata reads the name, symbol, decimals and total supply, tolerating
+// bytes32 strings, wider decimals types and missing optional functions. Only
+// totalSupply is required.
+func (t *Token) Metadata(opts *bind.CallOpts) (*Metadata, error) {
+	if opts == nil {
+		opts = new(bind.CallOpts)
+	}
+
+	if err := t.checkCode(opts); err != nil {
+		return nil, err
+	}
+
+	m := new(Metadata)
+
+	for _, field := range []struct {
+		method string
+		value  *string
+	}{
+		{"name", &m.Name},
+		{"symbol", &m.Symbol},
+	} {
+		out, err := t.call(opts, field.method)
+		if err != nil {
+			m.Quirks |= MissingMetadata
+			continue
+		}
+
+		s, isBytes32, err := decodeString(out)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", field.method, err)
+		}
+
+		if isBytes32 {
+			m.Quirks |= Bytes32Metadata
+		}
+
+		*field.value = s
+	}
+
+	out, err := t.call(opts, "decimals")
+	if err != nil {
+		m.Quirks |= MissingMetadata
+	} else {
+		decimals, err := decodeUint(out)
+		if err != nil {
+			return nil, fmt.Errorf("decimals: %w", err)
+		}
+
+		if !decimals.IsUint64() || decimals.Uint64() > 255 {
+			return nil, fmt.Errorf("decimals %s out of range", decimals)
+		}
+
+		m.Decimals = uint8(decimals.Uint64())
+	}
+
+	if m.TotalSupply, err = t.uint(opts, "totalSupply"); err != nil {
+		return nil, fmt.Errorf("totalSupply: %w", err)
+	}
+
+	return m, nil
+}
+
+// BalanceOf returns the balance of owner.
+func (t *Token) BalanceOf(opts *bind.CallOpts, owner common.Address) (*big.Int, error) {
+	return t.uint(opts, "balanceOf", owner)
+}
+
+// Allowance returns the amount spender may transfer on behalf of owner.
+func (t *Token) Allowance(opts *bind.CallOpts, owner, spender common.Address) (*big.Int, error) {
+	return t.uint(opts, "allowance", owner, spender)
+}
+
+// DetectTransfer finds the transfer quirks of the token by simulating a
+// transfer of zero tokens from holder to itself.
+func (t *Token) DetectTransfer(ctx context.Context, holder common.Address) (Quirk, error) {
+	out, err := t.simulate(ctx, holder, "transfer", holder, new(big.Int))
+	if err != nil {
+		return 0, err
+	}
+
+	if len(out) == 0 {
+		return NoReturnValue, nil
+	}
+
+	return 0, nil
+}
+
+// Transfer sends amount to the recipient. The transfer is simulated first and
+// fails with the decoded revert error or ErrFalseReturned before anything is
+// sent.
+func (t *Token) Transfer(opts *bind.TransactOpts, to common.Address, amount *big.Int) (*types.Transaction, error) {
+	return t.transact(opts, "transfer", to, amount)
+}
+
+// TransferFrom sends amount from an owner that approved the sender.
+func (t *Token) TransferFrom(opts *bind.TransactOpts, from, to common.Address, amount *big.Int) (*types.Transaction, error) {
+	return t.transact(opts, "transferFrom", from, to, amount)
+}
+
+// Approve sets the allowance of spender. Tokens like USDT refuse to change a
+// non-zero allowance, which must be set to zero first.
+func (t *Token) Approve(opts *bind.TransactOpts, spender common.Address, amount *big.Int) (*types.Transaction, error) {
+	return t.transact(opts, "approve", spender, amount)
+}
+
+// Wait waits for the transaction to be mined and explains failed receipts.
+// The simulation before sending may pass and the mined transfer still fail
+// when the state changed in between, which a token that returns false does
+// without reverting: a successful transfer or transferFrom without a Transfer
+// event of the token fails with ErrFalseReturned.
+func (t *Token) Wait(ctx context.Context, tx *types.Transaction) (*types.Receipt, error) {
+	receipt, err := bind.WaitMined(ctx, t.backend, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := revert.Receipt(ctx, t.backend, tx, receipt); err != nil {
+		return receipt, err
+	}
+
+	return receipt, t.checkTransferred(tx, receipt)
+}
+
+// checkTransferred returns ErrFalseReturned when tx is a transfer of the token
+// whose receipt has no Transfer event of the token.
+func (t *Token) checkTransferred(tx *types.Transaction, receipt *types.Receipt) error {
+	if tx.To() == nil || *tx.To() != t.Address || len(tx.Data()) < 4 {
+		return nil
+	}
+
+	method, err := erc20ABI().MethodById(tx.Data()[:4])
+	if err != nil || (method.Name != "transfer" && method.Name != "transferFrom") {
+		return nil
+	}
+
+	transfer := erc20ABI().Events["Transfer"].ID
+	for _, l := range receipt.Logs {
+		if l.Address == t.Address && len(l.Topics) > 0 && l.Topics[0] == transfer {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%s %s: %w", method.Name, tx.Hash().Hex(), ErrFalseReturned)
+}
+
+func (t *Token) transact(opts *bind.TransactOpts, method string, args ...any) (*types.Transaction, error) {
+	ctx := opts.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	out, err := t.simulate(ctx, opts.From, method, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkReturn(out); err != nil {
+		return nil, fmt.Errorf("%s: %w", method, err)
+	}
+
+	data, err := erc20ABI().Pack(method, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	return t.contract.RawTransact(opts, data)
+}
+
+// simulate runs a state-changing call with eth_call and returns its raw
+// result, with revert errors decoded.
+func (t *Token) simulate(ctx context.Context, from common.Address, method string, args ...any) ([]byte, error) {
+	data, err := erc20ABI().Pack(method, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := t.checkCode(&bind.CallOpts{Context: ctx}); err != nil {
+		return nil, err
+	}
+
+	out, err := t.backend.CallContract(ctx, ethereum.CallMsg{From: from, To: &t.Address, Data: data}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", method, revert.Decode(err))
+	}
+
+	return out, nil
+}
+
+// checkReturn applies the SafeERC20 rule: no return data or a true bool.
+func checkReturn(out []byte) error {
+	if len(out) == 0 {
+		return nil
+	}
+
+	value, err := decodeUint(out)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case value.Cmp(big.NewInt(1)) == 0:
+		return nil
+	case value.Sign() == 0:
+		return ErrFalseReturned
+	default:
+		return fmt.Errorf("unexpected return value %#x", out)
+	}
+}
+
+func (t *Token) checkCode(opts *bind.CallOpts) error {
+	var code []byte
+	var err error
+
+	if opts.BlockHash != (common.Hash{}) {
+		byHash, ok := t.backend.(bind.BlockHashContractCaller)
+		if !ok {
+			return bind.ErrNoBlockHashState
+		}
+
+		code, err = byHash.CodeAtHash(ctxOf(opts), t.Address, opts.BlockHash)
+	} else {
+		code, err = t.backend.CodeAt(ctxOf(opts), t.Address, opts.BlockNumber)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if len(code) == 0 {
+		return ErrNotContract
+	}
+
+	return nil
+}
+
+// call runs a view function and returns its raw result. Reverts and empty
+// results are errors.
+func (t *Token) call(opts *bind.CallOpts, method string, args ...any) ([]byte, error) {
+	data, err := erc20ABI().Pack(method, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	msg := ethereum.CallMsg{From: opts.From, To: &t.Address, Data: data}
+
+	var out []byte
+
+	if pending, ok := t.backend.(bind.PendingContractCaller); ok && opts.Pending {
+		out, err = pending.PendingCallContract(ctxOf(opts), msg)
+	} else if opts.BlockHash != (common.Hash{}) {
+		byHash, ok := t.backend.(bind.BlockHashContractCaller)
+		if !ok {
+			return nil, bind.ErrNoBlockHashState
+		}
+
+		out, err = byHash.CallContractAtHash(ctxOf(opts), msg, opts.BlockHash)
+	} else {
+		out, err = t.backend.CallContract(ctxOf(opts), msg, opts.BlockNumber)
+	}
+
+	if err != nil {
+		return nil, revert.Decode(err)
+	}
+
+	if len(out) == 0 {
+		return nil, fmt.Errorf("%s returned nothing", method)
+	}
+
+	return out, nil
+}
+
+func (t *Token) uint(opts *bind.CallOpts, method string, args ...any) (*big.Int, error) {
+	if opts == nil {
+		opts = new(bind.CallOpts)
+	}
+
+	out, err := t.call(opts, method, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeUint(out)
+}
+
+func ctxOf(opts *bind.CallOpts) context.Context {
+	if opts.Context == nil {
+		return context.Background()
+	}
+
+	return opts.Context
+}
+
+// decodeUint decodes the first word of a result.
+func decodeUint(out []byte) (*big.Int, error) {
+	if len(out) < 32 {
+		return nil, fmt.Errorf("result of %d bytes is not a word", len(out))
+	}
+
+	return new(big.Int).SetBytes(out[:32]), nil
+}
+
+// decodeString decodes an ABI string, or a bytes32 padded with zeros, and
+// normalizes it to trimmed, valid UTF-8.
+func decodeString(out []byte) (string, bool, error) {
+	if len(out) == 32 {
+		return normalize(string(bytes.TrimRight(out, "\x00"))), true, nil
+	}
+
+	values, err := abi.Arguments{{Type: stringType}}.Unpack(out)
+	if err != nil {
+		return "", false, err
+	}
+
+	return normalize(values[0].(string)), false, nil
+}
+
+func normalize(s string) string {
+	s = strings.TrimRight(s, "\x00")
+	if !utf8.ValidString(s) {
+		s = strings.ToValidUTF8(s, "\uFFFD")
+	}
+
+	return strings.TrimSpace(s)
+}
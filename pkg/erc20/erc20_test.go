@@ -0,0 +1,262 @@
+package erc20_test
+
+import (
+	"context"
+	"errors"
+	token "go-ethereum-example/gen"
+	"go-ethereum-example/gen/fixtures"
+	"go-ethereum-example/pkg/erc20"
+	"go-ethereum-example/pkg/multicall"
+	"go-ethereum-example/pkg/testchain"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+var supply = big.NewInt(1_000_000)
+
+// setup deploys MyToken and every quirky fixture token from the first
+// account.
+func setup(t *testing.T, chain *testchain.Chain) map[string]common.Address {
+	t.Helper()
+
+	opts := chain.Transactor(chain.Accounts[0])
+	addresses := make(map[string]common.Address)
+
+	deploy := func(name string, address common.Address, tx *types.Transaction, err error) {
+		if err != nil {
+			t.Fatalf("deploy %s: %v", name, err)
+		}
+
+		if receipt := chain.Receipt(tx); receipt.Status != types.ReceiptStatusSuccessful {
+			t.Fatalf("deploy %s failed", name)
+		}
+
+		addresses[name] = address
+	}
+
+	address, tx, _, err := token.DeployToken(opts, chain.Client, supply)
+	deploy("standard", address, tx, err)
+
+	address, tx, _, err = fixtures.DeployBytes32MetadataToken(opts, chain.Client, supply)
+	deploy("bytes32", address, tx, err)
+
+	address, tx, _, err = fixtures.DeployNoReturnToken(opts, chain.Client, supply)
+	deploy("no-return", address, tx, err)
+
+	address, tx, _, err = fixtures.DeployFalseReturnToken(opts, chain.Client, supply)
+	deploy("false-return", address, tx, err)
+
+	address, tx, _, err = fixtures.DeployNoMetadataToken(opts, chain.Client, supply)
+	deploy("no-metadata", address, tx, err)
+
+	return addresses
+}
+
+func TestMetadata(t *testing.T) {
+	chain := testchain.New(t)
+	addresses := setup(t, chain)
+
+	tests := []struct {
+		fixture string
+		want    erc20.Metadata
+	}{
+		{"standard", erc20.Metadata{Name: "MyToken", Symbol: "MTK", Decimals: 18, TotalSupply: supply}},
+		{"bytes32", erc20.Metadata{Name: "Maker", Symbol: "MKR", Decimals: 18, TotalSupply: supply, Quirks: erc20.Bytes32Metadata}},
+		{"no-return", erc20.Metadata{Name: "Tether USD", Symbol: "USDT", Decimals: 6, TotalSupply: supply}},
+		{"no-metadata", erc20.Metadata{TotalSupply: supply, Quirks: erc20.MissingMetadata}},
+	}
+
+	for _, tt := range tests {
+		m, err := erc20.New(addresses[tt.fixture], chain.Client).Metadata(nil)
+		if err != nil {
+			t.Errorf("%s: %v", tt.fixture, err)
+			continue
+		}
+
+		if m.Name != tt.want.Name || m.Symbol != tt.want.Symbol || m.Decimals != tt.want.Decimals ||
+			m.TotalSupply.Cmp(tt.want.TotalSupply) != 0 || m.Quirks != tt.want.Quirks {
+			t.Errorf("%s: got %+v, want %+v", tt.fixture, m, tt.want)
+		}
+	}
+
+	// An account without code and a contract that is not a token
+	if _, err := erc20.New(chain.Accounts[1].Address, chain.Client).Metadata(nil); !errors.Is(err, erc20.ErrNotContract) {
+		t.Errorf("metadata of an account: %v", err)
+	}
+
+	if _, err := erc20.New(multicall.Address, chain.Client).Metadata(nil); err == nil {
+		t.Error("metadata of Multicall3 succeeded")
+	}
+}
+
+func TestDetectTransfer(t *testing.T) {
+	chain := testchain.New(t)
+	addresses := setup(t, chain)
+	holder := chain.Accounts[0].Address
+
+	for fixture, want := range map[string]erc20.Quirk{
+		"standard":     0,
+		"bytes32":      0,
+		"no-return":    erc20.NoReturnValue,
+		"false-return": 0,
+	} {
+		quirks, err := erc20.New(addresses[fixture], chain.Client).DetectTransfer(context.Background(), holder)
+		if err != nil {
+			t.Errorf("%s: %v", fixture, err)
+			continue
+		}
+
+		if quirks != want {
+			t.Errorf("%s: quirks %q, want %q", fixture, quirks, want)
+		}
+	}
+}
+
+func TestSafeTransfer(t *testing.T) {
+	chain := testchain.New(t)
+	addresses := setup(t, chain)
+	ctx := context.Background()
+	owner, recipient := chain.Accounts[0], chain.Accounts[1]
+
+	for _, fixture := range []string{"standard", "bytes32", "no-return", "false-return", "no-metadata"} {
+		tok := erc20.New(addresses[fixture], chain.Client)
+
+		tx, err := tok.Transfer(chain.Transactor(owner), recipient.Address, big.NewInt(100))
+		if err != nil {
+			t.Errorf("%s: transfer: %v", fixture, err)
+			continue
+		}
+
+		if _, err := tok.Wait(ctx, tx); err != nil {
+			t.Errorf("%s: wait: %v", fixture, err)
+			continue
+		}
+
+		balance, err := tok.BalanceOf(nil, recipient.Address)
+		if err != nil || balance.Int64() != 100 {
+			t.Errorf("%s: recipient balance %v: %v", fixture, balance, err)
+		}
+
+		// The recipient only holds 100, so transferring more back must fail
+		// before anything is sent, whether the token reverts or returns false
+		nonce, _ := chain.Client.PendingNonceAt(ctx, recipient.Address)
+
+		if _, err := tok.Transfer(chain.Transactor(recipient), owner.Address, big.NewInt(101)); err == nil {
+			t.Errorf("%s: transfer above balance succeeded", fixture)
+		}
+
+		if after, _ := chain.Client.PendingNonceAt(ctx, recipient.Address); after != nonce {
+			t.Errorf("%s: failing transfer was sent", fixture)
+		}
+	}
+
+	_, err := erc20.New(addresses["false-return"], chain.Client).Transfer(chain.Transactor(recipient), owner.Address, big.NewInt(101))
+	if !errors.Is(err, erc20.ErrFalseReturned) {
+		t.Errorf("false-return: expected ErrFalseReturned, got %v", err)
+	}
+
+	_, err = erc20.New(addresses["standard"], chain.Client).Transfer(chain.Transactor(recipient), owner.Address, big.NewInt(101))
+
+	var insufficient *token.ERC20InsufficientBalance
+	if !errors.As(err, &insufficient) {
+		t.Errorf("standard: expected ERC20InsufficientBalance, got %v", err)
+	}
+}
+
+// TestWaitFalseReturned checks that a transfer that passed its simulation but
+// returned false once mined is reported by Wait.
+func TestWaitFalseReturned(t *testing.T) {
+	chain := testchain.New(t)
+	addresses := setup(t, chain)
+	ctx := context.Background()
+	owner, recipient := chain.Accounts[0], chain.Accounts[1]
+
+	tok := erc20.New(addresses["false-return"], chain.Client)
+
+	tx, err := tok.Transfer(chain.Transactor(owner), recipient.Address, big.NewInt(100))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := tok.Wait(ctx, tx); err != nil {
+		t.Fatal(err)
+	}
+
+	// Both transfers of the whole balance pass the simulation against the
+	// mined state, the second one returns false in the block
+	chain.SetAutoMine(false)
+
+	var txs []*types.Transaction
+	for i := 0; i < 2; i++ {
+		tx, err := tok.Transfer(chain.Transactor(recipient), owner.Address, big.NewInt(100))
+		if err != nil {
+			t.Fatal(err)
+		}
+		txs = append(txs, tx)
+	}
+
+	chain.Mine()
+
+	if _, err := tok.Wait(ctx, txs[0]); err != nil {
+		t.Errorf("first transfer: %v", err)
+	}
+
+	receipt, err := tok.Wait(ctx, txs[1])
+	if !errors.Is(err, erc20.ErrFalseReturned) {
+		t.Errorf("second transfer: expected ErrFalseReturned, got %v", err)
+	}
+
+	if receipt == nil || receipt.Status != types.ReceiptStatusSuccessful {
+		t.Errorf("second transfer receipt %+v", receipt)
+	}
+}
+
+func TestApproveNoReturn(t *testing.T) {
+	chain := testchain.New(t)
+	addresses := setup(t, chain)
+	ctx := context.Background()
+	owner, spender := chain.Accounts[0], chain.Accounts[1]
+
+	tok := erc20.New(addresses["no-return"], chain.Client)
+
+	tx, err := tok.Approve(chain.Transactor(owner), spender.Address, big.NewInt(50))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := tok.Wait(ctx, tx); err != nil {
+		t.Fatal(err)
+	}
+
+	// Changing a non-zero allowance reverts without a reason
+	if _, err := tok.Approve(chain.Transactor(owner), spender.Address, big.NewInt(60)); err == nil {
+		t.Error("changing a non-zero allowance succeeded")
+	}
+
+	tx, err = tok.TransferFrom(chain.Transactor(spender), owner.Address, spender.Address, big.NewInt(50))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := tok.Wait(ctx, tx); err != nil {
+		t.Fatal(err)
+	}
+
+	allowance, err := tok.Allowance(nil, owner.Address, spender.Address)
+	if err != nil || allowance.Sign() != 0 {
+		t.Errorf("allowance %v after transferFrom: %v", allowance, err)
+	}
+}
+
+func TestQuirkString(t *testing.T) {
+	if s := (erc20.Bytes32Metadata | erc20.NoReturnValue).String(); s != "bytes32 metadata, no return value" {
+		t.Errorf("got %q", s)
+	}
+
+	if s := erc20.Quirk(0).String(); s != "none" {
+		t.Errorf("got %q", s)
+	}
+}
@@ -0,0 +1,275 @@
+// Package multicall batches read-only contract calls into Multicall3
+// aggregate3 calls, so reading thousands of values takes a handful of eth_call
+// requests. Batches are split by gas and calldata size, and every call is
+// allowed to fail on its own:
+//
+//	caller, _ := multicall.New(multicall.Address, client)
+//	results, _ := caller.Do(nil, calls)
+//	balance, err := multicall.Value[*big.Int](results[0])
+package multicall
+
+import (
+	"errors"
+	"fmt"
+	token "go-ethereum-example/gen"
+	"math/big"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/vm/runtime"
+)
+
+// Address is where Multicall3 is deployed on mainnet, most testnets and L2s,
+// and on the local chains of this repository.
+var Address = common.HexToAddress("0xcA11bde05977b3631167028862bE2a173976CA11")
+
+// Defaults of the batch limits of a Caller.
+const (
+	DefaultMaxGas      = 25_000_000
+	DefaultMaxCalldata = 256 * 1024
+	DefaultGasPerCall  = 25_000
+)
+
+// ErrReverted is returned for calls that reverted without a known custom
+// error.
+var ErrReverted = errors.New("call reverted")
+
+// code returns the runtime code of Multicall3 by running its creation code.
+var code = sync.OnceValues(func() ([]byte, error) {
+	runtimeCode, _, _, err := runtime.Create(common.FromHex(token.Multicall3Bin), nil)
+	return runtimeCode, err
+})
+
+// Code returns the runtime code of Multicall3, to predeploy it at Address in
+// a genesis allocation.
+func Code() ([]byte, error) {
+	return code()
+}
+
+// Call is a read-only call of a batch.
+type Call struct {
+	Target common.Address
+	Data   []byte
+
+	// Gas is the expected gas use, zero means the GasPerCall of the caller
+	Gas uint64
+
+	// Unpack decodes the return data, nil keeps the raw bytes
+	Unpack func(data []byte) (any, error)
+}
+
+// Result is the outcome of a call: the decoded value, or the error of a call
+// that reverted or whose return data could not be decoded.
+type Result struct {
+	Value any
+	Err   error
+}
+
+// Value returns the value of a result as T.
+func Value[T any](r Result) (T, error) {
+	var zero T
+
+	if r.Err != nil {
+		return zero, r.Err
+	}
+
+	v, ok := r.Value.(T)
+	if !ok {
+		return zero, fmt.Errorf("result is %T, not %T", r.Value, zero)
+	}
+
+	return v, nil
+}
+
+// Caller sends calls through a Multicall3 contract. The limits bound each
+// aggregate3 request and may be changed before use.
+type Caller struct {
+	MaxGas      uint64 // sum of the expected gas of the calls of a batch
+	MaxCalldata int    // ABI encoded size of the calls of a batch
+	GasPerCall  uint64 // expected gas of calls that do not set it
+
+	contract *token.Multicall3
+}
+
+// New returns a caller for the Multicall3 contract at address.
+func New(address common.Address, backend bind.ContractBackend) (*Caller, error) {
+	contract, err := token.NewMulticall3(address, backend)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Caller{
+		MaxGas:      DefaultMaxGas,
+		MaxCalldata: DefaultMaxCalldata,
+		GasPerCall:  DefaultGasPerCall,
+		contract:    contract,
+	}, nil
+}
+
+// Batches splits the calls, in order, into the batches Do sends. A call that
+// exceeds a limit on its own gets a batch of its own.
+func (c *Caller) Batches(calls []Call) [][]Call {
+	var (
+		batches  [][]Call
+		start    int
+		gas      uint64
+		calldata int
+	)
+
+	for i, call := range calls {
+		callGas := call.Gas
+		if callGas == 0 {
+			callGas = c.GasPerCall
+		}
+
+		// A Call3 tuple is an offset, the target, allowFailure, the offset and
+		// length of the calldata and the padded calldata
+		callSize := 5*32 + (len(call.Data)+31)/32*32
+
+		if i > start && (gas+callGas > c.MaxGas || calldata+callSize > c.MaxCalldata) {
+			batches = append(batches, calls[start:i])
+			start, gas, calldata = i, 0, 0
+		}
+
+		gas += callGas
+		calldata += callSize
+	}
+
+	if start < len(calls) {
+		batches = append(batches, calls[start:])
+	}
+
+	return batches
+}
+
+// Do sends the calls in as few aggregate3 requests as the limits allow and
+// returns one result per call. Failed calls are reported in their result;
+// the error is only set when a request itself fails. Without a block in opts,
+// all batches read the same latest block.
+func (c *Caller) Do(opts *bind.CallOpts, calls []Call) ([]Result, error) {
+	if opts == nil {
+		opts = new(bind.CallOpts)
+	}
+
+	batches := c.Batches(calls)
+
+	if len(batches) > 1 && opts.BlockNumber == nil && opts.BlockHash == (common.Hash{}) && !opts.Pending {
+		number, err := c.contract.GetBlockNumber(opts)
+		if err != nil {
+			return nil, err
+		}
+
+		pinned := *opts
+		pinned.BlockNumber = number
+		opts = &pinned
+	}
+
+	results := make([]Result, 0, len(calls))
+
+	for i, batch := range batches {
+		returned, err := c.aggregate3(opts, batch)
+		if err != nil {
+			return nil, fmt.Errorf("batch %d of %d: %w", i+1, len(batches), err)
+		}
+
+		for j, r := range returned {
+			results = append(results, result(batch[j], r))
+		}
+	}
+
+	return results, nil
+}
+
+func (c *Caller) aggregate3(opts *bind.CallOpts, batch []Call) ([]token.Multicall3Result, error) {
+	calls := make([]token.Multicall3Call3, len(batch))
+	for i, call := range batch {
+		calls[i] = token.Multicall3Call3{Target: call.Target, AllowFailure: true, CallData: call.Data}
+	}
+
+	// aggregate3 is payable, so the binding only has a transactor for it
+	var out []any
+
+	raw := &token.Multicall3Raw{Contract: c.contract}
+	if err := raw.Call(opts, &out, "aggregate3", calls); err != nil {
+		return nil, err
+	}
+
+	returned := *abi.ConvertType(out[0], new([]token.Multicall3Result)).(*[]token.Multicall3Result)
+	if len(returned) != len(batch) {
+		return nil, fmt.Errorf("got %d results for %d calls", len(returned), len(batch))
+	}
+
+	return returned, nil
+}
+
+func result(call Call, r token.Multicall3Result) Result {
+	if !r.Success {
+		return Result{Err: callError(r.ReturnData)}
+	}
+
+	if call.Unpack == nil {
+		return Result{Value: r.ReturnData}
+	}
+
+	value, err := call.Unpack(r.ReturnData)
+	if err != nil {
+		return Result{Err: fmt.Errorf("decode result of %s: %w", call.Target.Hex(), err)}
+	}
+
+	return Result{Value: value}
+}
+
+// callError decodes the revert data of a failed call into a custom error of
+// the token package or a revert reason.
+func callError(data []byte) error {
+	if custom := token.UnpackError(data); custom != nil {
+		return fmt.Errorf("%w: %w", custom, ErrReverted)
+	}
+
+	if reason, err := abi.UnpackRevert(data); err == nil {
+		return fmt.Errorf("%w: %s", ErrReverted, reason)
+	}
+
+	return ErrReverted
+}
+
+// Metadata is the ERC-20 metadata of a token.
+type Metadata struct {
+	Name        string
+	Symbol      string
+	Decimals    uint8
+	TotalSupply *big.Int
+}
+
+// Metadata reads the name, symbol, decimals and total supply of a token in
+// one request.
+func (c *Caller) Metadata(opts *bind.CallOpts, tokenAddress common.Address) (*Metadata, error) {
+	t := Token{Address: tokenAddress}
+
+	results, err := c.Do(opts, []Call{t.Name(), t.Symbol(), t.Decimals(), t.TotalSupply()})
+	if err != nil {
+		return nil, err
+	}
+
+	var m Metadata
+
+	if m.Name, err = Value[string](results[0]); err != nil {
+		return nil, fmt.Errorf("name: %w", err)
+	}
+
+	if m.Symbol, err = Value[string](results[1]); err != nil {
+		return nil, fmt.Errorf("symbol: %w", err)
+	}
+
+	if m.Decimals, err = Value[uint8](results[2]); err != nil {
+		return nil, fmt.Errorf("decimals: %w", err)
+	}
+
+	if m.TotalSupply, err = Value[*big.Int](results[3]); err != nil {
+		return nil, fmt.Errorf("totalSupply: %w", err)
+	}
+
+	return &m, nil
+}
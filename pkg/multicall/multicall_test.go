@@ -0,0 +1,176 @@
+package multicall_test
+
+import (
+	"errors"
+	token "go-ethereum-example/gen"
+	"go-ethereum-example/pkg/multicall"
+	"go-ethereum-example/pkg/testchain"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var supply = new(big.Int).Mul(big.NewInt(1_000_000), big.NewInt(1e18))
+
+func TestBalances(t *testing.T) {
+	chain := testchain.New(t)
+	owner := chain.Accounts[0]
+
+	address, tokenInstance := chain.DeployToken(owner, supply)
+
+	for i, a := range chain.Accounts[1:] {
+		if _, err := tokenInstance.Transfer(chain.Transactor(owner), a.Address, big.NewInt(int64(i+1))); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	caller, err := multicall.New(multicall.Address, chain.Client)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Small limits force many batches
+	caller.MaxCalldata = 10_000
+
+	tok := multicall.Token{Address: address}
+
+	var calls []multicall.Call
+	for i := 0; i < 2000; i++ {
+		calls = append(calls, tok.BalanceOf(chain.Accounts[i%len(chain.Accounts)].Address))
+	}
+
+	if batches := caller.Batches(calls); len(batches) < 40 {
+		t.Fatalf("got %d batches, want at least 40", len(batches))
+	}
+
+	results, err := caller.Do(nil, calls)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(results) != len(calls) {
+		t.Fatalf("got %d results for %d calls", len(results), len(calls))
+	}
+
+	for i, result := range results {
+		balance, err := multicall.Value[*big.Int](result)
+		if err != nil {
+			t.Fatalf("call %d: %v", i, err)
+		}
+
+		want := big.NewInt(int64(i % len(chain.Accounts)))
+		if i%len(chain.Accounts) == 0 {
+			want = new(big.Int).Sub(supply, big.NewInt(45))
+		}
+
+		if balance.Cmp(want) != 0 {
+			t.Fatalf("call %d: balance %s, want %s", i, balance, want)
+		}
+	}
+}
+
+func TestMetadata(t *testing.T) {
+	chain := testchain.New(t)
+	address, _ := chain.DeployToken(chain.Accounts[0], supply)
+
+	caller, err := multicall.New(multicall.Address, chain.Client)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	metadata, err := caller.Metadata(nil, address)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if metadata.Name != "MyToken" || metadata.Symbol != "MTK" || metadata.Decimals != 18 || metadata.TotalSupply.Cmp(supply) != 0 {
+		t.Errorf("unexpected metadata %+v", metadata)
+	}
+}
+
+func TestFailures(t *testing.T) {
+	chain := testchain.New(t)
+	owner := chain.Accounts[0]
+
+	address, _ := chain.DeployToken(owner, supply)
+
+	caller, err := multicall.New(multicall.Address, chain.Client)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tokenABI, err := token.TokenMetaData.GetAbi()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Multicall3 is the sender of the calls and holds no tokens
+	transfer, err := tokenABI.Pack("transfer", owner.Address, big.NewInt(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tok := multicall.Token{Address: address}
+
+	results, err := caller.Do(nil, []multicall.Call{
+		tok.BalanceOf(owner.Address),
+		{Target: address, Data: transfer},
+		{Target: address, Data: []byte{0xde, 0xad, 0xbe, 0xef}},
+		multicall.Token{Address: owner.Address}.Symbol(),
+		tok.TotalSupply(),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if balance, err := multicall.Value[*big.Int](results[0]); err != nil || balance.Cmp(supply) != 0 {
+		t.Errorf("balance %v: %v", balance, err)
+	}
+
+	var insufficient *token.ERC20InsufficientBalance
+	if !errors.As(results[1].Err, &insufficient) || insufficient.Sender != multicall.Address {
+		t.Errorf("expected ERC20InsufficientBalance, got %v", results[1].Err)
+	}
+
+	if !errors.Is(results[2].Err, multicall.ErrReverted) {
+		t.Errorf("unknown selector: expected ErrReverted, got %v", results[2].Err)
+	}
+
+	// An account without code returns nothing, which cannot be decoded
+	if results[3].Err == nil {
+		t.Errorf("symbol of an account succeeded with %v", results[3].Value)
+	}
+
+	if total, err := multicall.Value[*big.Int](results[4]); err != nil || total.Cmp(supply) != 0 {
+		t.Errorf("total supply %v: %v", total, err)
+	}
+
+	if _, err := multicall.Value[string](results[4]); err == nil {
+		t.Error("total supply converted to string")
+	}
+}
+
+func TestBatches(t *testing.T) {
+	caller := &multicall.Caller{MaxGas: 100_000, MaxCalldata: 1_000, GasPerCall: 30_000}
+	target := common.HexToAddress("0x01")
+
+	calls := []multicall.Call{
+		{Target: target, Data: make([]byte, 36)},              // 224 bytes
+		{Target: target, Data: make([]byte, 36)},              // 448
+		{Target: target, Data: make([]byte, 36), Gas: 50_000}, // 110,000 gas, new batch
+		{Target: target, Data: make([]byte, 2_000)},           // larger than a batch on its own
+		{Target: target, Data: make([]byte, 4)},
+	}
+
+	batches := caller.Batches(calls)
+
+	sizes := make([]int, len(batches))
+	for i, b := range batches {
+		sizes[i] = len(b)
+	}
+
+	if len(sizes) != 4 || sizes[0] != 2 || sizes[1] != 1 || sizes[2] != 1 || sizes[3] != 1 {
+		t.Errorf("got batch sizes %v, want [2 1 1 1]", sizes)
+	}
+}
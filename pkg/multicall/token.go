@@ -0,0 +1,77 @@
+package multicall
+
+import (
+	token "go-ethereum-example/gen"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var tokenABI = sync.OnceValue(func() *abi.ABI {
+	parsed, err := token.TokenMetaData.GetAbi()
+	if err != nil {
+		panic(err)
+	}
+
+	return parsed
+})
+
+// Token builds calls to the ERC-20 functions of a token. The values of the
+// results have the types of the Token binding: *big.Int, string and uint8.
+type Token struct {
+	Address common.Address
+}
+
+// BalanceOf returns a balanceOf(owner) call.
+func (t Token) BalanceOf(owner common.Address) Call {
+	return t.call("balanceOf", owner)
+}
+
+// Allowance returns an allowance(owner, spender) call.
+func (t Token) Allowance(owner, spender common.Address) Call {
+	return t.call("allowance", owner, spender)
+}
+
+// Name returns a name() call.
+func (t Token) Name() Call {
+	return t.call("name")
+}
+
+// Symbol returns a symbol() call.
+func (t Token) Symbol() Call {
+	return t.call("symbol")
+}
+
+// Decimals returns a decimals() call.
+func (t Token) Decimals() Call {
+	return t.call("decimals")
+}
+
+// TotalSupply returns a totalSupply() call.
+func (t Token) TotalSupply() Call {
+	return t.call("totalSupply")
+}
+
+func (t Token) call(method string, args ...any) Call {
+	parsed := tokenABI()
+
+	// The arguments are typed by the methods above, so packing cannot fail
+	data, err := parsed.Pack(method, args...)
+	if err != nil {
+		panic(err)
+	}
+
+	return Call{
+		Target: t.Address,
+		Data:   data,
+		Unpack: func(data []byte) (any, error) {
+			out, err := parsed.Unpack(method, data)
+			if err != nil {
+				return nil, err
+			}
+
+			return out[0], nil
+		},
+	}
+}
@@ -0,0 +1,277 @@
+// Package offline splits a transaction into build, sign and broadcast steps so
+// that the private key never has to touch a networked machine.
+package offline
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"encoding/json"
+	"errors"
+	"fmt"
+	token "go-ethereum-example/gen"
+	"math/big"
+	"os"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// UnsignedTx is everything the signing machine needs to produce a transaction,
+// together with a human-readable decoding of the calldata for review.
+type UnsignedTx struct {
+	ChainID   *hexutil.Big    `json:"chainId"`
+	From      common.Address  `json:"from"`
+	Nonce     hexutil.Uint64  `json:"nonce"`
+	To        *common.Address `json:"to"` // nil for contract deployment
+	Value     *hexutil.Big    `json:"value"`
+	Gas       hexutil.Uint64  `json:"gas"`
+	GasPrice  *hexutil.Big    `json:"gasPrice,omitempty"`             // legacy transactions
+	GasTipCap *hexutil.Big    `json:"maxPriorityFeePerGas,omitempty"` // EIP-1559 transactions
+	GasFeeCap *hexutil.Big    `json:"maxFeePerGas,omitempty"`         // EIP-1559 transactions
+	Data      hexutil.Bytes   `json:"data"`
+	Decoded   *Call           `json:"decoded"`
+}
+
+// SignedTx is the output of the signing machine.
+type SignedTx struct {
+	ChainID         *hexutil.Big    `json:"chainId"`
+	From            common.Address  `json:"from"`
+	Hash            common.Hash     `json:"hash"`
+	ContractAddress *common.Address `json:"contractAddress,omitempty"` // set for contract deployment
+	Raw             hexutil.Bytes   `json:"raw"`                       // RLP (typed) transaction encoding
+}
+
+// Call is the calldata decoded against the Token ABI.
+type Call struct {
+	Method string            `json:"method"` // "constructor" for deployment
+	Args   map[string]string `json:"args"`
+}
+
+// TransferData returns the calldata of Token.transfer(to, value).
+func TransferData(to common.Address, value *big.Int) ([]byte, error) {
+	parsed, err := token.TokenMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+
+	return parsed.Pack("transfer", to, value)
+}
+
+// DeployData returns the creation code of Token with the initial supply appended.
+func DeployData(initialSupply *big.Int) ([]byte, error) {
+	parsed, err := token.TokenMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+
+	args, err := parsed.Pack("", initialSupply)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(common.FromHex(token.TokenMetaData.Bin), args...), nil
+}
+
+// Decode decodes calldata against the Token ABI. A nil to address means data is
+// creation code, in which case the constructor arguments are decoded.
+func Decode(to *common.Address, data []byte) (*Call, error) {
+	parsed, err := token.TokenMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+
+	if to == nil {
+		code := common.FromHex(token.TokenMetaData.Bin)
+		if !bytes.HasPrefix(data, code) {
+			return nil, errors.New("creation code does not match the Token binding")
+		}
+
+		return decodeArgs("constructor", parsed.Constructor.Inputs, data[len(code):])
+	}
+
+	if len(data) < 4 {
+		return nil, errors.New("calldata too short")
+	}
+
+	method, err := parsed.MethodById(data[:4])
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeArgs(method.Name, method.Inputs, data[4:])
+}
+
+func decodeArgs(name string, inputs abi.Arguments, data []byte) (*Call, error) {
+	values := make(map[string]interface{})
+	if err := inputs.UnpackIntoMap(values, data); err != nil {
+		return nil, err
+	}
+
+	args := make(map[string]string, len(values))
+	for k, v := range values {
+		switch v := v.(type) {
+		case common.Address:
+			args[k] = v.Hex()
+		default:
+			args[k] = fmt.Sprint(v)
+		}
+	}
+
+	return &Call{Method: name, Args: args}, nil
+}
+
+// Verify checks that the decoded section matches the calldata, so that a
+// tampered review section cannot hide what is actually being signed.
+func (tx *UnsignedTx) Verify() error {
+	if tx.ChainID == nil {
+		return errors.New("missing chain ID")
+	}
+
+	if tx.GasPrice == nil && (tx.GasTipCap == nil || tx.GasFeeCap == nil) {
+		return errors.New("missing fee fields")
+	}
+
+	decoded, err := Decode(tx.To, tx.Data)
+	if err != nil {
+		return err
+	}
+
+	if tx.Decoded == nil || tx.Decoded.Method != decoded.Method || len(tx.Decoded.Args) != len(decoded.Args) {
+		return errors.New("decoded call does not match calldata")
+	}
+
+	for k, v := range decoded.Args {
+		if tx.Decoded.Args[k] != v {
+			return fmt.Errorf("decoded argument %q does not match calldata", k)
+		}
+	}
+
+	return nil
+}
+
+// Transaction converts the file into an unsigned transaction. A dynamic fee
+// transaction is produced when the EIP-1559 fee fields are present.
+func (tx *UnsignedTx) Transaction() *types.Transaction {
+	value := new(big.Int)
+	if tx.Value != nil {
+		value = tx.Value.ToInt()
+	}
+
+	if tx.GasFeeCap != nil {
+		return types.NewTx(&types.DynamicFeeTx{
+			ChainID:   tx.ChainID.ToInt(),
+			Nonce:     uint64(tx.Nonce),
+			GasTipCap: tx.GasTipCap.ToInt(),
+			GasFeeCap: tx.GasFeeCap.ToInt(),
+			Gas:       uint64(tx.Gas),
+			To:        tx.To,
+			Value:     value,
+			Data:      tx.Data,
+		})
+	}
+
+	return types.NewTx(&types.LegacyTx{
+		Nonce:    uint64(tx.Nonce),
+		GasPrice: tx.GasPrice.ToInt(),
+		Gas:      uint64(tx.Gas),
+		To:       tx.To,
+		Value:    value,
+		Data:     tx.Data,
+	})
+}
+
+// MaxFee returns the most the transaction can pay in fees: the gas limit times
+// the max fee per gas, or times the gas price of a legacy transaction.
+func (tx *UnsignedTx) MaxFee() *big.Int {
+	price := tx.GasPrice
+	if tx.GasFeeCap != nil {
+		price = tx.GasFeeCap
+	}
+
+	if price == nil {
+		return new(big.Int)
+	}
+
+	return new(big.Int).Mul(price.ToInt(), new(big.Int).SetUint64(uint64(tx.Gas)))
+}
+
+// Sign verifies the unsigned transaction and signs it with the given key.
+func Sign(tx *UnsignedTx, privateKey *ecdsa.PrivateKey) (*SignedTx, error) {
+	if err := tx.Verify(); err != nil {
+		return nil, err
+	}
+
+	from := crypto.PubkeyToAddress(privateKey.PublicKey)
+	if from != tx.From {
+		return nil, fmt.Errorf("transaction is from %s but key is for %s", tx.From.Hex(), from.Hex())
+	}
+
+	signed, err := types.SignTx(tx.Transaction(), types.LatestSignerForChainID(tx.ChainID.ToInt()), privateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	raw, err := signed.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+
+	result := &SignedTx{
+		ChainID: tx.ChainID,
+		From:    from,
+		Hash:    signed.Hash(),
+		Raw:     raw,
+	}
+
+	if tx.To == nil {
+		contractAddress := crypto.CreateAddress(from, uint64(tx.Nonce))
+		result.ContractAddress = &contractAddress
+	}
+
+	return result, nil
+}
+
+// Transaction decodes the raw signed transaction and checks that it was signed
+// by the recorded sender.
+func (tx *SignedTx) Transaction() (*types.Transaction, error) {
+	signed := new(types.Transaction)
+	if err := signed.UnmarshalBinary(tx.Raw); err != nil {
+		return nil, err
+	}
+
+	from, err := types.Sender(types.LatestSignerForChainID(signed.ChainId()), signed)
+	if err != nil {
+		return nil, err
+	}
+
+	if from != tx.From {
+		return nil, fmt.Errorf("transaction signed by %s, expected %s", from.Hex(), tx.From.Hex())
+	}
+
+	return signed, nil
+}
+
+// ReadFile decodes a JSON transaction file into v.
+func ReadFile(path string, v interface{}) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+
+	defer f.Close()
+
+	return json.NewDecoder(f).Decode(v)
+}
+
+// WriteFile encodes v as an indented JSON transaction file.
+func WriteFile(path string, v interface{}) error {
+	b, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, append(b, '\n'), 0o644)
+}
@@ -0,0 +1,189 @@
+package offline_test
+
+import (
+	"context"
+	"go-ethereum-example/pkg/offline"
+	"go-ethereum-example/pkg/testchain"
+	"math/big"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+var supply = big.NewInt(1_000_000)
+
+// TestRoundTrip builds a transfer, signs it from its file as the offline
+// machine would, and broadcasts the signed file.
+func TestRoundTrip(t *testing.T) {
+	chain := testchain.New(t)
+	owner, alice := chain.Accounts[0], chain.Accounts[1]
+
+	address, tokenInstance := chain.DeployToken(owner, supply)
+
+	ctx := context.Background()
+
+	// Build
+	data, err := offline.TransferData(alice.Address, big.NewInt(1000))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	decoded, err := offline.Decode(&address, data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if decoded.Method != "transfer" || decoded.Args["to"] != alice.Address.Hex() || decoded.Args["value"] != "1000" {
+		t.Fatalf("decoded %+v", decoded)
+	}
+
+	nonce, err := chain.Client.PendingNonceAt(ctx, owner.Address)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	gas, err := chain.Client.EstimateGas(ctx, ethereum.CallMsg{From: owner.Address, To: &address, Data: data})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	header, err := chain.Client.HeaderByNumber(ctx, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tip, err := chain.Client.SuggestGasTipCap(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	feeCap := new(big.Int).Add(tip, new(big.Int).Mul(header.BaseFee, big.NewInt(2)))
+
+	unsigned := &offline.UnsignedTx{
+		ChainID:   (*hexutil.Big)(testchain.ChainID),
+		From:      owner.Address,
+		Nonce:     hexutil.Uint64(nonce),
+		To:        &address,
+		Value:     new(hexutil.Big),
+		Gas:       hexutil.Uint64(gas),
+		GasTipCap: (*hexutil.Big)(tip),
+		GasFeeCap: (*hexutil.Big)(feeCap),
+		Data:      data,
+		Decoded:   decoded,
+	}
+
+	if want := new(big.Int).Mul(feeCap, new(big.Int).SetUint64(gas)); unsigned.MaxFee().Cmp(want) != 0 {
+		t.Errorf("max fee %s, want %s", unsigned.MaxFee(), want)
+	}
+
+	dir := t.TempDir()
+	unsignedPath, signedPath := filepath.Join(dir, "unsigned.json"), filepath.Join(dir, "signed.json")
+
+	if err := offline.WriteFile(unsignedPath, unsigned); err != nil {
+		t.Fatal(err)
+	}
+
+	// Sign
+	var read offline.UnsignedTx
+	if err := offline.ReadFile(unsignedPath, &read); err != nil {
+		t.Fatal(err)
+	}
+
+	signed, err := offline.Sign(&read, owner.Key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := offline.WriteFile(signedPath, signed); err != nil {
+		t.Fatal(err)
+	}
+
+	// Broadcast
+	var readSigned offline.SignedTx
+	if err := offline.ReadFile(signedPath, &readSigned); err != nil {
+		t.Fatal(err)
+	}
+
+	tx, err := readSigned.Transaction()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if tx.Hash() != signed.Hash || tx.Type() != types.DynamicFeeTxType {
+		t.Fatalf("transaction %s of type %d, want %s", tx.Hash().Hex(), tx.Type(), signed.Hash.Hex())
+	}
+
+	if err := chain.Client.SendTransaction(ctx, tx); err != nil {
+		t.Fatal(err)
+	}
+
+	if receipt := chain.Receipt(tx); receipt.Status != types.ReceiptStatusSuccessful {
+		t.Fatalf("receipt status %d", receipt.Status)
+	}
+
+	if balance, _ := tokenInstance.BalanceOf(nil, alice.Address); balance.Int64() != 1000 {
+		t.Errorf("balance of alice %s, want 1000", balance)
+	}
+}
+
+func TestSignRejects(t *testing.T) {
+	chain := testchain.New(t)
+	owner, alice := chain.Accounts[0], chain.Accounts[1]
+
+	address, _ := chain.DeployToken(owner, supply)
+
+	data, err := offline.TransferData(alice.Address, big.NewInt(1000))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	unsigned := func() *offline.UnsignedTx {
+		decoded, err := offline.Decode(&address, data)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return &offline.UnsignedTx{
+			ChainID:  (*hexutil.Big)(testchain.ChainID),
+			From:     owner.Address,
+			To:       &address,
+			Gas:      100_000,
+			GasPrice: (*hexutil.Big)(big.NewInt(1e9)),
+			Data:     data,
+			Decoded:  decoded,
+		}
+	}
+
+	tampered := unsigned()
+	tampered.Decoded.Args["value"] = "1"
+
+	if _, err := offline.Sign(tampered, owner.Key); err == nil {
+		t.Error("signed a transaction whose decoded value does not match the calldata")
+	}
+
+	if _, err := offline.Sign(unsigned(), alice.Key); err == nil {
+		t.Error("signed a transaction from owner with the key of alice")
+	}
+
+	noFees := unsigned()
+	noFees.GasPrice = nil
+
+	if _, err := offline.Sign(noFees, owner.Key); err == nil {
+		t.Error("signed a transaction without fees")
+	}
+
+	// A signed file whose sender was edited is refused at broadcast
+	signed, err := offline.Sign(unsigned(), owner.Key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	signed.From = alice.Address
+
+	if _, err := signed.Transaction(); err == nil {
+		t.Error("accepted a signed transaction with an edited sender")
+	}
+}
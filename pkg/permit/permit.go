@@ -0,0 +1,183 @@
+// Package permit implements EIP-2612 permits: the Permit struct as EIP-712
+// typed data, hashed by package eip712, and signing it so a spender can submit
+// the approval.
+package permit
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	token "go-ethereum-example/gen"
+	"go-ethereum-example/pkg/eip712"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// Types are the EIP-712 types of a permit and its domain.
+var Types = eip712.Types{
+	eip712.DomainType: {
+		{Name: "name", Type: "string"},
+		{Name: "version", Type: "string"},
+		{Name: "chainId", Type: "uint256"},
+		{Name: "verifyingContract", Type: "address"},
+	},
+	"Permit": {
+		{Name: "owner", Type: "address"},
+		{Name: "spender", Type: "address"},
+		{Name: "value", Type: "uint256"},
+		{Name: "nonce", Type: "uint256"},
+		{Name: "deadline", Type: "uint256"},
+	},
+}
+
+// Domain is the EIP-712 domain of a permit token.
+type Domain struct {
+	Name              string
+	Version           string
+	ChainID           *big.Int
+	VerifyingContract common.Address
+}
+
+// Separator returns the EIP-712 domain separator.
+func (d Domain) Separator() (common.Hash, error) {
+	return Types.HashStruct(eip712.DomainType, d.message())
+}
+
+func (d Domain) message() map[string]interface{} {
+	return map[string]interface{}{
+		"name":              d.Name,
+		"version":           d.Version,
+		"chainId":           d.ChainID,
+		"verifyingContract": d.VerifyingContract,
+	}
+}
+
+// Permit is the EIP-2612 Permit struct.
+type Permit struct {
+	Owner    common.Address
+	Spender  common.Address
+	Value    *big.Int
+	Nonce    *big.Int
+	Deadline *big.Int
+}
+
+// TypedData returns the permit as the typed data the owner signs, as
+// eth_signTypedData_v4 takes it.
+func (p Permit) TypedData(domain Domain) *eip712.TypedData {
+	return &eip712.TypedData{
+		Types:       Types,
+		PrimaryType: "Permit",
+		Domain:      domain.message(),
+		Message: map[string]interface{}{
+			"owner":    p.Owner,
+			"spender":  p.Spender,
+			"value":    p.Value,
+			"nonce":    p.Nonce,
+			"deadline": p.Deadline,
+		},
+	}
+}
+
+// Signature is a permit signature split into the (v, r, s) arguments of permit().
+type Signature struct {
+	V uint8       `json:"v"`
+	R common.Hash `json:"r"`
+	S common.Hash `json:"s"`
+}
+
+// Bytes returns the 65-byte r || s || v encoding of the signature.
+func (s Signature) Bytes() []byte {
+	return append(append(s.R.Bytes(), s.S.Bytes()...), s.V)
+}
+
+// String returns the hex encoding of Bytes.
+func (s Signature) String() string {
+	return hexutil.Encode(s.Bytes())
+}
+
+// Sign signs the permit with the owner's key.
+func Sign(p Permit, domain Domain, privateKey *ecdsa.PrivateKey) (Signature, error) {
+	if crypto.PubkeyToAddress(privateKey.PublicKey) != p.Owner {
+		return Signature{}, errors.New("key does not belong to the permit owner")
+	}
+
+	sig, err := p.TypedData(domain).Sign(privateKey)
+	if err != nil {
+		return Signature{}, err
+	}
+
+	return Signature{
+		V: sig[64],
+		R: common.BytesToHash(sig[:32]),
+		S: common.BytesToHash(sig[32:64]),
+	}, nil
+}
+
+// Recover returns the address that produced the signature over the permit.
+func Recover(p Permit, domain Domain, sig Signature) (common.Address, error) {
+	if sig.V != 27 && sig.V != 28 {
+		return common.Address{}, fmt.Errorf("invalid signature v %d", sig.V)
+	}
+
+	return p.TypedData(domain).Recover(sig.Bytes())
+}
+
+// DomainOf reads the EIP-712 domain of a token through EIP-5267 eip712Domain()
+// and checks it against the token's DOMAIN_SEPARATOR().
+func DomainOf(ctx context.Context, tokenInstance *token.TokenPermit) (Domain, error) {
+	opts := &bind.CallOpts{Context: ctx}
+
+	fields, err := tokenInstance.Eip712Domain(opts)
+	if err != nil {
+		return Domain{}, err
+	}
+
+	// name, version, chainId and verifyingContract must be the only fields in use
+	if fields.Fields != [1]byte{0x0f} {
+		return Domain{}, fmt.Errorf("unsupported EIP-712 domain fields %#x", fields.Fields)
+	}
+
+	domain := Domain{
+		Name:              fields.Name,
+		Version:           fields.Version,
+		ChainID:           fields.ChainId,
+		VerifyingContract: fields.VerifyingContract,
+	}
+
+	separator, err := tokenInstance.DOMAINSEPARATOR(opts)
+	if err != nil {
+		return Domain{}, err
+	}
+
+	computed, err := domain.Separator()
+	if err != nil {
+		return Domain{}, err
+	}
+
+	if computed != separator {
+		return Domain{}, errors.New("domain separator does not match eip712Domain()")
+	}
+
+	return domain, nil
+}
+
+// New builds a permit for the owner's next nonce.
+func New(ctx context.Context, tokenInstance *token.TokenPermit, owner, spender common.Address, value, deadline *big.Int) (Permit, error) {
+	nonce, err := tokenInstance.Nonces(&bind.CallOpts{Context: ctx}, owner)
+	if err != nil {
+		return Permit{}, err
+	}
+
+	return Permit{
+		Owner:    owner,
+		Spender:  spender,
+		Value:    value,
+		Nonce:    nonce,
+		Deadline: deadline,
+	}, nil
+}
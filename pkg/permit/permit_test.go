@@ -0,0 +1,240 @@
+package permit
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"encoding/json"
+	token "go-ethereum-example/gen"
+	"go-ethereum-example/pkg/eip712"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/eth/ethconfig"
+	"github.com/ethereum/go-ethereum/ethclient/simulated"
+	"github.com/ethereum/go-ethereum/node"
+)
+
+type testChain struct {
+	sim     *simulated.Backend
+	token   *token.TokenPermit
+	owner   *ecdsa.PrivateKey
+	spender *ecdsa.PrivateKey
+}
+
+func newTestChain(t *testing.T) *testChain {
+	t.Helper()
+
+	owner, _ := crypto.GenerateKey()
+	spender, _ := crypto.GenerateKey()
+
+	funds := new(big.Int).Exp(big.NewInt(10), big.NewInt(20), nil)
+	alloc := types.GenesisAlloc{
+		crypto.PubkeyToAddress(owner.PublicKey):   {Balance: funds},
+		crypto.PubkeyToAddress(spender.PublicKey): {Balance: funds},
+	}
+
+	// Start post-merge so Shanghai opcodes (PUSH0) are enabled from genesis
+	sim := simulated.NewBackend(alloc, func(_ *node.Config, ethConf *ethconfig.Config) {
+		ethConf.Genesis.Difficulty = new(big.Int)
+	})
+	t.Cleanup(func() { sim.Close() })
+
+	_, _, tokenInstance, err := token.DeployTokenPermit(transactor(t, owner), sim.Client(), big.NewInt(1_000_000))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sim.Commit()
+
+	return &testChain{sim: sim, token: tokenInstance, owner: owner, spender: spender}
+}
+
+func transactor(t *testing.T, key *ecdsa.PrivateKey) *bind.TransactOpts {
+	t.Helper()
+
+	opts, err := bind.NewKeyedTransactorWithChainID(key, big.NewInt(1337))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return opts
+}
+
+func (c *testChain) signPermit(t *testing.T, value int64, deadline time.Time) (Permit, Signature) {
+	t.Helper()
+
+	ctx := context.Background()
+
+	domain, err := DomainOf(ctx, c.token)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	p, err := New(ctx, c.token, crypto.PubkeyToAddress(c.owner.PublicKey), crypto.PubkeyToAddress(c.spender.PublicKey), big.NewInt(value), big.NewInt(deadline.Unix()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sig, err := Sign(p, domain, c.owner)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return p, sig
+}
+
+func TestDomainOf(t *testing.T) {
+	c := newTestChain(t)
+
+	domain, err := DomainOf(context.Background(), c.token)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if domain.Name != "MyToken" || domain.Version != "1" || domain.ChainID.Int64() != 1337 {
+		t.Fatalf("unexpected domain %+v", domain)
+	}
+}
+
+func TestSubmitPermit(t *testing.T) {
+	c := newTestChain(t)
+	ctx := context.Background()
+
+	p, sig := c.signPermit(t, 500, time.Now().Add(time.Hour))
+
+	// The spender submits the owner's signature and pays for gas
+	tx, err := c.token.Permit(transactor(t, c.spender), p.Owner, p.Spender, p.Value, p.Deadline, sig.V, sig.R, sig.S)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c.sim.Commit()
+
+	receipt, err := c.sim.Client().TransactionReceipt(ctx, tx.Hash())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		t.Fatal("permit transaction failed")
+	}
+
+	allowance, err := c.token.Allowance(&bind.CallOpts{}, p.Owner, p.Spender)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if allowance.Cmp(p.Value) != 0 {
+		t.Fatalf("allowance = %s, want %s", allowance, p.Value)
+	}
+
+	nonce, err := c.token.Nonces(&bind.CallOpts{}, p.Owner)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if nonce.Int64() != 1 {
+		t.Fatalf("nonce = %s, want 1", nonce)
+	}
+
+	// The allowance is usable like one granted by approve
+	if _, err := c.token.TransferFrom(transactor(t, c.spender), p.Owner, p.Spender, big.NewInt(200)); err != nil {
+		t.Fatal(err)
+	}
+
+	c.sim.Commit()
+
+	balance, err := c.token.BalanceOf(&bind.CallOpts{}, p.Spender)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if balance.Int64() != 200 {
+		t.Fatalf("spender balance = %s, want 200", balance)
+	}
+
+	// A permit can only be used once
+	if _, err := c.token.Permit(transactor(t, c.spender), p.Owner, p.Spender, p.Value, p.Deadline, sig.V, sig.R, sig.S); err == nil {
+		t.Fatal("replayed permit was accepted")
+	}
+}
+
+func TestExpiredPermit(t *testing.T) {
+	c := newTestChain(t)
+
+	p, sig := c.signPermit(t, 500, time.Now().Add(-time.Hour))
+
+	if _, err := c.token.Permit(transactor(t, c.spender), p.Owner, p.Spender, p.Value, p.Deadline, sig.V, sig.R, sig.S); err == nil {
+		t.Fatal("expired permit was accepted")
+	}
+}
+
+func TestTamperedPermit(t *testing.T) {
+	c := newTestChain(t)
+
+	p, sig := c.signPermit(t, 500, time.Now().Add(time.Hour))
+
+	if _, err := c.token.Permit(transactor(t, c.spender), p.Owner, p.Spender, big.NewInt(501), p.Deadline, sig.V, sig.R, sig.S); err == nil {
+		t.Fatal("permit with tampered value was accepted")
+	}
+}
+
+func TestRecover(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	owner := crypto.PubkeyToAddress(key.PublicKey)
+
+	domain := Domain{Name: "MyToken", Version: "1", ChainID: big.NewInt(1), VerifyingContract: common.HexToAddress("0x5FbDB2315678afecb367f032d93F642f64180aa3")}
+	p := Permit{Owner: owner, Spender: common.HexToAddress("0x70997970C51812dc3A010C7d01b50e0d17dc79C8"), Value: big.NewInt(1), Nonce: big.NewInt(0), Deadline: big.NewInt(1)}
+
+	sig, err := Sign(p, domain, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	signer, err := Recover(p, domain, sig)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if signer != owner {
+		t.Fatalf("recovered %s, want %s", signer.Hex(), owner.Hex())
+	}
+
+	other, _ := crypto.GenerateKey()
+	if _, err := Sign(p, domain, other); err == nil {
+		t.Fatal("signed with a key that is not the owner's")
+	}
+}
+
+// TestTypedData checks that a wallet given the permit as eth_signTypedData_v4
+// JSON signs the same digest.
+func TestTypedData(t *testing.T) {
+	domain := Domain{Name: "MyToken", Version: "1", ChainID: big.NewInt(31337), VerifyingContract: common.HexToAddress("0x5FbDB2315678afecb367f032d93F642f64180aa3")}
+	p := Permit{Owner: common.HexToAddress("0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266"), Spender: common.HexToAddress("0x70997970C51812dc3A010C7d01b50e0d17dc79C8"), Value: big.NewInt(1000), Nonce: big.NewInt(3), Deadline: big.NewInt(1700000000)}
+
+	td := p.TypedData(domain)
+
+	data, err := json.Marshal(td)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	parsed, err := eip712.Parse(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want, err := td.Digest()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if digest, err := parsed.Digest(); err != nil || digest != want {
+		t.Fatalf("digest of %s = %s, %v, want %s", data, digest.Hex(), err, want.Hex())
+	}
+}
@@ -0,0 +1,169 @@
+// Package preflight simulates a token transfer at the pending block before it
+// is signed, so a revert costs no gas and the effects can be confirmed first:
+//
+//	report, err := preflight.Transfer(ctx, preflight.Client{Client: client}, tokenAddress, from, to, amount)
+//	handleError(err)
+//
+//	if report.Err != nil {
+//		// the transfer would revert, report.Err is the decoded custom error
+//	}
+//
+// The expected Transfer event and balance changes are those ERC-20 specifies
+// for a successful transfer; tokens that charge fees or rebase differ.
+package preflight
+
+import (
+	"context"
+	"fmt"
+	token "go-ethereum-example/gen"
+	"go-ethereum-example/pkg/erc20"
+	"go-ethereum-example/pkg/revert"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+// Backend simulates calls at the pending block. Gas is estimated at the
+// pending block too when the backend is a PendingGasEstimator, and at the
+// node's default block otherwise.
+type Backend interface {
+	bind.ContractCaller
+	bind.PendingContractCaller
+	ethereum.GasEstimator
+}
+
+// PendingGasEstimator estimates gas at the pending block.
+type PendingGasEstimator interface {
+	PendingEstimateGas(ctx context.Context, call ethereum.CallMsg) (uint64, error)
+}
+
+// Client is an ethclient.Client that estimates gas at the pending block,
+// which eth_estimateGas leaves to the node, and geth sets to latest.
+type Client struct {
+	*ethclient.Client
+}
+
+// PendingEstimateGas runs eth_estimateGas at the pending block.
+func (c Client) PendingEstimateGas(ctx context.Context, call ethereum.CallMsg) (uint64, error) {
+	arg := map[string]any{"from": call.From, "to": call.To}
+	if len(call.Data) > 0 {
+		arg["input"] = hexutil.Bytes(call.Data)
+	}
+
+	if call.Value != nil {
+		arg["value"] = (*hexutil.Big)(call.Value)
+	}
+
+	var gas hexutil.Uint64
+	if err := c.Client.Client().CallContext(ctx, &gas, "eth_estimateGas", arg, "pending"); err != nil {
+		return 0, err
+	}
+
+	return uint64(gas), nil
+}
+
+// Report is the outcome of a simulated transfer.
+type Report struct {
+	// Err is why the transfer would fail, with custom errors decoded, nil if
+	// it would succeed. The fields below are only set when it is nil.
+	Err error
+
+	// Gas is the estimated gas of the transaction.
+	Gas uint64
+
+	// Events are the expected Transfer events, without their raw logs.
+	Events []*token.TokenTransfer
+
+	// Balances are the expected balance changes of the sender and the
+	// recipient.
+	Balances []BalanceChange
+}
+
+// BalanceChange is a token balance before and after the transfer.
+type BalanceChange struct {
+	Holder common.Address
+	Before *big.Int
+	After  *big.Int
+}
+
+// Transfer simulates a transfer of amount from from to to. Errors are RPC
+// failures; reverts are reported in Report.Err.
+func Transfer(ctx context.Context, backend Backend, tokenAddress, from, to common.Address, amount *big.Int) (*Report, error) {
+	parsed, err := token.TokenMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := parsed.Pack("transfer", to, amount)
+	if err != nil {
+		return nil, err
+	}
+
+	call := ethereum.CallMsg{From: from, To: &tokenAddress, Data: data}
+
+	out, err := backend.PendingCallContract(ctx, call)
+	if err != nil {
+		if _, reverted := revert.Data(err); reverted {
+			return &Report{Err: revert.Decode(err)}, nil
+		}
+
+		return nil, fmt.Errorf("simulate transfer: %w", err)
+	}
+
+	// A token may return false instead of reverting
+	if len(out) > 0 && new(big.Int).SetBytes(out).Cmp(big.NewInt(1)) != 0 {
+		return &Report{Err: erc20.ErrFalseReturned}, nil
+	}
+
+	gas, err := estimateGas(ctx, backend, call)
+	if err != nil {
+		if _, reverted := revert.Data(err); reverted {
+			return &Report{Err: revert.Decode(err)}, nil
+		}
+
+		return nil, fmt.Errorf("estimate gas: %w", err)
+	}
+
+	report := &Report{
+		Gas:    gas,
+		Events: []*token.TokenTransfer{{From: from, To: to, Value: amount}},
+	}
+
+	caller, err := token.NewTokenCaller(tokenAddress, backend)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := &bind.CallOpts{Context: ctx, Pending: true}
+
+	// A transfer to oneself changes nothing
+	changes := map[common.Address]*big.Int{from: new(big.Int).Neg(amount), to: amount}
+	holders := []common.Address{from, to}
+	if to == from {
+		changes[from], holders = new(big.Int), holders[:1]
+	}
+
+	for _, holder := range holders {
+		before, err := caller.BalanceOf(opts, holder)
+		if err != nil {
+			return nil, fmt.Errorf("balance of %s: %w", holder.Hex(), err)
+		}
+
+		after := new(big.Int).Add(before, changes[holder])
+		report.Balances = append(report.Balances, BalanceChange{Holder: holder, Before: before, After: after})
+	}
+
+	return report, nil
+}
+
+func estimateGas(ctx context.Context, backend Backend, call ethereum.CallMsg) (uint64, error) {
+	if estimator, ok := backend.(PendingGasEstimator); ok {
+		return estimator.PendingEstimateGas(ctx, call)
+	}
+
+	return backend.EstimateGas(ctx, call)
+}
@@ -0,0 +1,157 @@
+package preflight_test
+
+import (
+	"context"
+	"errors"
+	token "go-ethereum-example/gen"
+	"go-ethereum-example/pkg/devnode"
+	"go-ethereum-example/pkg/preflight"
+	"go-ethereum-example/pkg/testchain"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+var supply = big.NewInt(1000)
+
+func TestTransfer(t *testing.T) {
+	chain := testchain.New(t)
+	owner, alice := chain.Accounts[0], chain.Accounts[1]
+
+	address, tokenInstance := chain.DeployToken(owner, supply)
+
+	if _, err := tokenInstance.Transfer(chain.Transactor(owner), alice.Address, big.NewInt(100)); err != nil {
+		t.Fatal(err)
+	}
+
+	report, err := preflight.Transfer(context.Background(), chain.Client, address, owner.Address, alice.Address, big.NewInt(40))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if report.Err != nil || report.Gas == 0 {
+		t.Fatalf("report %+v", report)
+	}
+
+	if len(report.Events) != 1 || report.Events[0].From != owner.Address || report.Events[0].To != alice.Address || report.Events[0].Value.Int64() != 40 {
+		t.Errorf("events %+v", report.Events)
+	}
+
+	want := []struct {
+		before, after int64
+	}{{900, 860}, {100, 140}}
+
+	if len(report.Balances) != len(want) {
+		t.Fatalf("balances %+v", report.Balances)
+	}
+
+	for i, change := range report.Balances {
+		if change.Before.Int64() != want[i].before || change.After.Int64() != want[i].after {
+			t.Errorf("balance of %s: %s -> %s, want %d -> %d", change.Holder.Hex(), change.Before, change.After, want[i].before, want[i].after)
+		}
+	}
+
+	// Nothing was sent
+	if balance, _ := tokenInstance.BalanceOf(nil, alice.Address); balance.Int64() != 100 {
+		t.Errorf("balance of alice %s after preflight", balance)
+	}
+
+	// A transfer to oneself changes no balance
+	report, err = preflight.Transfer(context.Background(), chain.Client, address, alice.Address, alice.Address, big.NewInt(40))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(report.Balances) != 1 || report.Balances[0].Before.Cmp(report.Balances[0].After) != 0 {
+		t.Errorf("balances of a transfer to oneself %+v", report.Balances)
+	}
+}
+
+func TestTransferReverts(t *testing.T) {
+	chain := testchain.New(t)
+	owner, alice := chain.Accounts[0], chain.Accounts[1]
+
+	address, _ := chain.DeployToken(owner, supply)
+
+	report, err := preflight.Transfer(context.Background(), chain.Client, address, alice.Address, owner.Address, big.NewInt(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var insufficient *token.ERC20InsufficientBalance
+	if !errors.As(report.Err, &insufficient) {
+		t.Fatalf("error %v, want ERC20InsufficientBalance", report.Err)
+	}
+
+	if insufficient.Sender != alice.Address || insufficient.Needed.Int64() != 1 {
+		t.Errorf("error %+v", insufficient)
+	}
+
+	if report.Gas != 0 || report.Events != nil || report.Balances != nil {
+		t.Errorf("effects of a reverting transfer %+v", report)
+	}
+}
+
+// TestTransferPending checks that a transfer funded by a transaction not yet
+// mined passes, as it will once mined after it.
+func TestTransferPending(t *testing.T) {
+	cfg := devnode.DefaultConfig()
+	cfg.Port = 0
+	cfg.BlockTime = time.Hour
+
+	node, err := devnode.Start(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { node.Close() })
+
+	client, err := ethclient.Dial(node.HTTPEndpoint())
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(client.Close)
+
+	owner, alice, bob := node.Accounts[0], node.Accounts[1], node.Accounts[2]
+
+	opts, err := bind.NewKeyedTransactorWithChainID(owner.Key, cfg.ChainID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	address, _, tokenInstance, err := token.DeployToken(opts, client, supply)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	node.Mine()
+
+	// Fund alice in the pending block only. The gas limit spares the binding
+	// a pending code check, which would have geth build and cache for 2s a
+	// pending block without the transfer
+	opts.GasLimit = 100_000
+
+	if _, err := tokenInstance.Transfer(opts, alice.Address, big.NewInt(100)); err != nil {
+		t.Fatal(err)
+	}
+
+	report, err := preflight.Transfer(context.Background(), preflight.Client{Client: client}, address, alice.Address, bob.Address, big.NewInt(60))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if report.Err != nil || report.Gas == 0 {
+		t.Fatalf("report %+v, want the pending transfer to fund it", report)
+	}
+
+	if change := report.Balances[0]; change.Before.Int64() != 100 || change.After.Int64() != 40 {
+		t.Errorf("balance of alice: %s -> %s, want 100 -> 40", change.Before, change.After)
+	}
+
+	// At the latest block, alice has nothing yet
+	if balance, _ := tokenInstance.BalanceOf(nil, alice.Address); balance.Sign() != 0 {
+		t.Errorf("balance of alice %s at the latest block", balance)
+	}
+}
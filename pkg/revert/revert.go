@@ -0,0 +1,94 @@
+// Package revert turns revert data carried by RPC errors into the typed
+// custom errors of the token package, so callers can branch with errors.As:
+//
+//	var insufficient *token.ERC20InsufficientBalance
+//	if errors.As(revert.Decode(err), &insufficient) {
+//		...
+//	}
+package revert
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	token "go-ethereum-example/gen"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// Data returns the revert data attached to an error by eth_call or
+// eth_estimateGas, if any.
+func Data(err error) ([]byte, bool) {
+	var dataErr rpc.DataError
+	if !errors.As(err, &dataErr) {
+		return nil, false
+	}
+
+	switch data := dataErr.ErrorData().(type) {
+	case string:
+		b, err := hexutil.Decode(data)
+		return b, err == nil
+	case []byte:
+		return data, true
+	default:
+		return nil, false
+	}
+}
+
+// Decode returns err wrapped together with the custom error decoded from its
+// revert data, so that both errors.As(err, &customErr) and checks against the
+// original error keep working. Errors without known revert data are returned
+// unchanged.
+func Decode(err error) error {
+	data, ok := Data(err)
+	if !ok {
+		return err
+	}
+
+	custom := token.UnpackError(data)
+	if custom == nil {
+		return err
+	}
+
+	return fmt.Errorf("%w: %w", custom, err)
+}
+
+// Backend replays transactions.
+type Backend interface {
+	CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error)
+}
+
+// Receipt explains a failed receipt: receipts carry no revert data, so the
+// transaction is replayed with eth_call on the state of the parent block and
+// the resulting error is decoded. Transactions mined before it in the same
+// block are not taken into account. It returns nil for successful receipts.
+func Receipt(ctx context.Context, backend Backend, tx *types.Transaction, receipt *types.Receipt) error {
+	if receipt.Status == types.ReceiptStatusSuccessful {
+		return nil
+	}
+
+	from, err := types.Sender(types.LatestSignerForChainID(tx.ChainId()), tx)
+	if err != nil {
+		return err
+	}
+
+	call := ethereum.CallMsg{
+		From:  from,
+		To:    tx.To(),
+		Gas:   tx.Gas(),
+		Value: tx.Value(),
+		Data:  tx.Data(),
+	}
+
+	parent := new(big.Int).Sub(receipt.BlockNumber, big.NewInt(1))
+
+	if _, err := backend.CallContract(ctx, call, parent); err != nil {
+		return fmt.Errorf("transaction %s reverted: %w", tx.Hash().Hex(), Decode(err))
+	}
+
+	return fmt.Errorf("transaction %s reverted, but succeeds when replayed", tx.Hash().Hex())
+}
@@ -0,0 +1,127 @@
+package revert
+
+import (
+	"context"
+	"errors"
+	token "go-ethereum-example/gen"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/eth/ethconfig"
+	"github.com/ethereum/go-ethereum/ethclient/simulated"
+	"github.com/ethereum/go-ethereum/node"
+)
+
+func newTestChain(t *testing.T) (*simulated.Backend, *token.AdminToken, *bind.TransactOpts, *bind.TransactOpts) {
+	t.Helper()
+
+	owner, _ := crypto.GenerateKey()
+	other, _ := crypto.GenerateKey()
+
+	funds := new(big.Int).Exp(big.NewInt(10), big.NewInt(20), nil)
+	alloc := types.GenesisAlloc{
+		crypto.PubkeyToAddress(owner.PublicKey): {Balance: funds},
+		crypto.PubkeyToAddress(other.PublicKey): {Balance: funds},
+	}
+
+	// Start post-merge so Shanghai opcodes (PUSH0) are enabled from genesis
+	sim := simulated.NewBackend(alloc, func(_ *node.Config, ethConf *ethconfig.Config) {
+		ethConf.Genesis.Difficulty = new(big.Int)
+	})
+	t.Cleanup(func() { sim.Close() })
+
+	ownerOpts, _ := bind.NewKeyedTransactorWithChainID(owner, big.NewInt(1337))
+	otherOpts, _ := bind.NewKeyedTransactorWithChainID(other, big.NewInt(1337))
+
+	_, _, tokenInstance, err := token.DeployAdminToken(ownerOpts, sim.Client(), big.NewInt(1000))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sim.Commit()
+
+	return sim, tokenInstance, ownerOpts, otherOpts
+}
+
+func TestDecodeGasEstimation(t *testing.T) {
+	_, tokenInstance, owner, _ := newTestChain(t)
+
+	// Transfer estimates gas first, which fails with the revert data
+	_, err := tokenInstance.Transfer(owner, common.HexToAddress("0x01"), big.NewInt(1001))
+	if err == nil {
+		t.Fatal("transfer above balance succeeded")
+	}
+
+	var insufficient *token.ERC20InsufficientBalance
+	if !errors.As(Decode(err), &insufficient) {
+		t.Fatalf("expected ERC20InsufficientBalance, got %v", Decode(err))
+	}
+
+	if insufficient.Sender != owner.From || insufficient.Balance.Int64() != 1000 || insufficient.Needed.Int64() != 1001 {
+		t.Errorf("unexpected error fields: %v", insufficient)
+	}
+}
+
+func TestDecodeCall(t *testing.T) {
+	_, tokenInstance, owner, other := newTestChain(t)
+
+	// Simulate mint from an account without MINTER_ROLE
+	raw := &token.AdminTokenRaw{Contract: tokenInstance}
+	err := raw.Call(&bind.CallOpts{From: other.From}, nil, "mint", owner.From, big.NewInt(1))
+
+	var unauthorized *token.AccessControlUnauthorizedAccount
+	if !errors.As(Decode(err), &unauthorized) {
+		t.Fatalf("expected AccessControlUnauthorizedAccount, got %v", Decode(err))
+	}
+
+	if unauthorized.Account != other.From || common.Hash(unauthorized.NeededRole) != crypto.Keccak256Hash([]byte("MINTER_ROLE")) {
+		t.Errorf("unexpected error fields: %v", unauthorized)
+	}
+}
+
+func TestReceipt(t *testing.T) {
+	sim, tokenInstance, owner, _ := newTestChain(t)
+	ctx := context.Background()
+
+	if _, err := tokenInstance.Pause(owner); err != nil {
+		t.Fatal(err)
+	}
+
+	sim.Commit()
+
+	// A fixed gas limit skips estimation, so the transfer is mined and reverts
+	owner.GasLimit = 100_000
+	tx, err := tokenInstance.Transfer(owner, common.HexToAddress("0x01"), big.NewInt(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sim.Commit()
+
+	receipt, err := bind.WaitMined(ctx, sim.Client(), tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if receipt.Status != types.ReceiptStatusFailed {
+		t.Fatal("transfer while paused succeeded")
+	}
+
+	err = Receipt(ctx, sim.Client(), tx, receipt)
+
+	var paused *token.EnforcedPause
+	if !errors.As(err, &paused) {
+		t.Fatalf("expected EnforcedPause, got %v", err)
+	}
+}
+
+func TestDecodeUnknown(t *testing.T) {
+	err := errors.New("connection refused")
+	if Decode(err) != err {
+		t.Error("error without revert data was changed")
+	}
+}
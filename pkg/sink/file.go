@@ -0,0 +1,139 @@
+package sink
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+)
+
+// DefaultMaxSize is the size at which files are rotated by default.
+const DefaultMaxSize = 100 << 20
+
+// File appends events as JSON lines to a file, rotating it when it grows past
+// MaxSize: path is renamed to path.1, path.1 to path.2 and so on, and files
+// beyond Keep are removed.
+type File struct {
+	Path    string
+	MaxSize int64
+	Keep    int
+
+	// Sync flushes every write to disk before Write returns.
+	Sync bool
+
+	mu   sync.Mutex
+	f    *os.File
+	size int64
+}
+
+// NewFile opens or creates the file at path, keeping 5 rotated files of
+// DefaultMaxSize.
+func NewFile(path string) (*File, error) {
+	s := &File{Path: path, MaxSize: DefaultMaxSize, Keep: 5}
+
+	if err := s.open(); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// Write appends the event, rotating the file first if the line does not fit.
+func (s *File) Write(ctx context.Context, event Event) error {
+	line, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	line = append(line, '\n')
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.f == nil {
+		return fmt.Errorf("%s is closed", s.Path)
+	}
+
+	if s.size > 0 && s.size+int64(len(line)) > s.MaxSize {
+		if err := s.rotate(); err != nil {
+			return err
+		}
+	}
+
+	n, err := s.f.Write(line)
+	s.size += int64(n)
+
+	if err != nil {
+		return err
+	}
+
+	if s.Sync {
+		return s.f.Sync()
+	}
+
+	return nil
+}
+
+// Close closes the current file.
+func (s *File) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.f == nil {
+		return nil
+	}
+
+	err := s.f.Close()
+	s.f = nil
+
+	return err
+}
+
+func (s *File) open() error {
+	f, err := os.OpenFile(s.Path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return err
+	}
+
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return err
+	}
+
+	s.f = f
+	s.size = info.Size()
+
+	return nil
+}
+
+func (s *File) rotate() error {
+	if err := s.f.Close(); err != nil {
+		return err
+	}
+
+	s.f = nil
+
+	// Drop the oldest file, then shift path.N-1 to path.N down to path to
+	// path.1. With Keep 0 the current file itself is dropped.
+	if err := os.Remove(s.rotated(s.Keep)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	for i := s.Keep - 1; i >= 0; i-- {
+		if err := os.Rename(s.rotated(i), s.rotated(i+1)); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+
+	return s.open()
+}
+
+func (s *File) rotated(i int) string {
+	if i == 0 {
+		return s.Path
+	}
+
+	return fmt.Sprintf("%s.%d", s.Path, i)
+}
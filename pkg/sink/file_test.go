@@ -0,0 +1,75 @@
+package sink
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFileRotates(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "transfers.jsonl")
+
+	s, err := NewFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	line, _ := json.Marshal(testEvent())
+
+	// Two events per file
+	s.MaxSize = int64(2 * (len(line) + 1))
+	s.Keep = 2
+
+	for i := 0; i < 7; i++ {
+		event := testEvent()
+		event.LogIndex = uint(i)
+
+		if err := s.Write(context.Background(), event); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := s.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	// 7 events: path.3 was dropped, path.2 has 2 and 3, path.1 has 4 and 5
+	for suffix, want := range map[string][]uint{"": {6}, ".1": {4, 5}, ".2": {2, 3}} {
+		if got := readIndexes(t, path+suffix); fmt.Sprint(got) != fmt.Sprint(want) {
+			t.Errorf("%s has log indexes %v, want %v", path+suffix, got, want)
+		}
+	}
+
+	if _, err := os.Stat(path + ".3"); !os.IsNotExist(err) {
+		t.Errorf("kept more than 2 rotated files: %v", err)
+	}
+}
+
+func readIndexes(t *testing.T, path string) []uint {
+	t.Helper()
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer f.Close()
+
+	var indexes []uint
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var event Event
+		if err := json.Unmarshal(scanner.Bytes(), &event); err != nil {
+			t.Fatal(err)
+		}
+
+		indexes = append(indexes, event.LogIndex)
+	}
+
+	return indexes
+}
@@ -0,0 +1,175 @@
+// Package sink delivers token events from the watcher to stdout, rotating
+// files or HTTP webhooks. Every event carries an idempotency key made of its
+// transaction hash and log index, so consumers can drop the duplicates that
+// at-least-once delivery and reconnects produce:
+//
+//	out := sink.Multi(sink.NewJSONL(os.Stdout), webhook)
+//	err := out.Write(ctx, sink.FromTransfer(transfer))
+package sink
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	token "go-ethereum-example/gen"
+	"io"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// Event is a token event as delivered to sinks. Events of all kinds share the
+// same envelope: ID, event name, token, block, transaction and log index.
+type Event struct {
+	// ID is the idempotency key, "<tx hash>:<log index>".
+	ID    string         `json:"id"`
+	Event string         `json:"event"`
+	Token common.Address `json:"token"`
+
+	// Symbol and Decimals are the token's metadata, empty and 0 for tokens
+	// that do not implement them. DecimalsUnknown is set when the metadata
+	// could not be read, and Decimals is 0 then too.
+	Symbol          string `json:"symbol,omitempty"`
+	Decimals        uint8  `json:"decimals"`
+	DecimalsUnknown bool   `json:"decimalsUnknown,omitempty"`
+
+	// From, To and Value are the sender, recipient and amount of transfers,
+	// and the owner, spender and allowance of approvals.
+	From  common.Address `json:"from"`
+	To    common.Address `json:"to"`
+	Value string         `json:"value"`
+
+	BlockNumber uint64      `json:"blockNumber"`
+	BlockHash   common.Hash `json:"blockHash"`
+	TxHash      common.Hash `json:"txHash"`
+	LogIndex    uint        `json:"logIndex"`
+
+	// BlockTime is the Unix time of the block, 0 when unknown.
+	BlockTime uint64 `json:"blockTime,omitempty"`
+
+	// Removed is set when a reorg dropped the log after it was delivered.
+	Removed bool `json:"removed,omitempty"`
+
+	// Alert is set on the events of alerts, whose ID is the ID of the event
+	// followed by the name of the rule.
+	Alert *Alert `json:"alert,omitempty"`
+}
+
+// Alert tells which rule an event raised an alert for, and why.
+type Alert struct {
+	Rule    string `json:"rule"`
+	Message string `json:"message"`
+}
+
+// ID returns the idempotency key of a log.
+func ID(log types.Log) string {
+	return fmt.Sprintf("%s:%d", log.TxHash.Hex(), log.Index)
+}
+
+// FromTransfer converts a Transfer event of the token binding.
+func FromTransfer(transfer *token.TokenTransfer) Event {
+	event := fromLog(transfer.Raw)
+	event.Event = "Transfer"
+	event.From = transfer.From
+	event.To = transfer.To
+	event.Value = transfer.Value.String()
+
+	return event
+}
+
+// FromApproval converts an Approval event of the token binding.
+func FromApproval(approval *token.TokenApproval) Event {
+	event := fromLog(approval.Raw)
+	event.Event = "Approval"
+	event.From = approval.Owner
+	event.To = approval.Spender
+	event.Value = approval.Value.String()
+
+	return event
+}
+
+func fromLog(log types.Log) Event {
+	return Event{
+		ID:          ID(log),
+		Token:       log.Address,
+		BlockNumber: log.BlockNumber,
+		BlockHash:   log.BlockHash,
+		TxHash:      log.TxHash,
+		LogIndex:    log.Index,
+		Removed:     log.Removed,
+	}
+}
+
+// Sink delivers events. Write returns once the event is stored or accepted,
+// so a watcher that stops on errors delivers every event at least once.
+type Sink interface {
+	Write(ctx context.Context, event Event) error
+	Close() error
+}
+
+// JSONL writes one JSON object per line, such as to os.Stdout.
+type JSONL struct {
+	mu  sync.Mutex
+	enc *json.Encoder
+}
+
+// NewJSONL returns a sink writing to w.
+func NewJSONL(w io.Writer) *JSONL {
+	return &JSONL{enc: json.NewEncoder(w)}
+}
+
+// Write encodes the event on its own line.
+func (s *JSONL) Write(ctx context.Context, event Event) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.enc.Encode(event)
+}
+
+// Close does nothing, the writer belongs to the caller.
+func (s *JSONL) Close() error {
+	return nil
+}
+
+type multi []Sink
+
+// Multi writes every event to all sinks in order, even after one of them
+// fails, and joins their errors. The joined error is ErrDeadLettered only when
+// every failed sink dead-lettered the event, so that a sink which lost it is
+// never taken for one which kept it.
+func Multi(sinks ...Sink) Sink {
+	return multi(sinks)
+}
+
+func (m multi) Write(ctx context.Context, event Event) error {
+	var errs []error
+	lost := false
+
+	for _, s := range m {
+		if err := s.Write(ctx, event); err != nil {
+			errs = append(errs, err)
+			lost = lost || !errors.Is(err, ErrDeadLettered)
+		}
+	}
+
+	if lost {
+		for i, err := range errs {
+			if errors.Is(err, ErrDeadLettered) {
+				errs[i] = errors.New(err.Error())
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (m multi) Close() error {
+	var errs []error
+	for _, s := range m {
+		errs = append(errs, s.Close())
+	}
+
+	return errors.Join(errs...)
+}
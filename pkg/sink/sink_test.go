@@ -0,0 +1,78 @@
+package sink
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// failing is a sink that counts its writes and fails them with err.
+type failing struct {
+	writes int
+	err    error
+}
+
+func (f *failing) Write(ctx context.Context, event Event) error {
+	f.writes++
+	return f.err
+}
+
+func (f *failing) Close() error {
+	return nil
+}
+
+func TestMulti(t *testing.T) {
+	deadLettered := fmt.Errorf("%w %s after 3 attempts: 503", ErrDeadLettered, testEvent().ID)
+	down := errors.New("disk full")
+
+	tests := []struct {
+		name         string
+		errs         []error
+		deadLettered bool
+	}{
+		{name: "delivered", errs: []error{nil, nil, nil}},
+		{name: "one failed", errs: []error{down, nil, nil}},
+		{name: "dead-lettered", errs: []error{nil, deadLettered, nil}, deadLettered: true},
+		{name: "dead-lettered and failed", errs: []error{deadLettered, nil, down}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var sinks []Sink
+			var want []error
+
+			for _, err := range tt.errs {
+				sinks = append(sinks, &failing{err: err})
+
+				if err != nil {
+					want = append(want, err)
+				}
+			}
+
+			err := Multi(sinks...).Write(context.Background(), testEvent())
+
+			// Every sink gets the event, whatever the others return
+			for i, s := range sinks {
+				if writes := s.(*failing).writes; writes != 1 {
+					t.Errorf("sink %d written %d times, want 1", i, writes)
+				}
+			}
+
+			if (err != nil) != (len(want) > 0) {
+				t.Fatalf("error %v, want %d errors", err, len(want))
+			}
+
+			for _, w := range want {
+				if err != nil && !strings.Contains(err.Error(), w.Error()) {
+					t.Errorf("error %v does not report %v", err, w)
+				}
+			}
+
+			if got := errors.Is(err, ErrDeadLettered); got != tt.deadLettered {
+				t.Errorf("dead-lettered %t, want %t: %v", got, tt.deadLettered, err)
+			}
+		})
+	}
+}
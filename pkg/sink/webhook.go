@@ -0,0 +1,215 @@
+package sink
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Headers of webhook requests.
+const (
+	// SignatureHeader is "sha256=" followed by the hex HMAC-SHA256 of the
+	// timestamp, a dot and the body, keyed with the shared secret.
+	SignatureHeader = "X-Signature-256"
+
+	// TimestampHeader is the Unix time of the attempt, so receivers can reject
+	// replayed requests.
+	TimestampHeader = "X-Timestamp"
+
+	// IdempotencyHeader repeats the event ID, which is the same for every
+	// attempt and every redelivery of the event.
+	IdempotencyHeader = "Idempotency-Key"
+)
+
+// ErrDeadLettered is returned when an event could not be delivered and was
+// written to the dead-letter file instead.
+var ErrDeadLettered = errors.New("dead-lettered")
+
+// Webhook posts every event as JSON to a URL. Network errors, timeouts, 429
+// and 5xx responses are retried with exponential backoff; other responses are
+// final. Events that still fail are written to DeadLetter, so they can be
+// replayed later.
+type Webhook struct {
+	URL    string
+	Secret []byte
+	Client *http.Client
+
+	MaxAttempts int
+	MinBackoff  time.Duration
+	MaxBackoff  time.Duration
+
+	// DeadLetter receives a JSON line per undelivered event. Without it, Write
+	// returns the delivery error and the caller must stop to lose nothing.
+	DeadLetter io.Writer
+
+	mu sync.Mutex
+}
+
+// DeadLetterRecord is a line of the dead-letter file.
+type DeadLetterRecord struct {
+	Event    Event     `json:"event"`
+	Error    string    `json:"error"`
+	Attempts int       `json:"attempts"`
+	Time     time.Time `json:"time"`
+}
+
+// NewWebhook returns a webhook sink that signs with secret and makes up to 8
+// attempts, waiting from 500ms up to 1m between them.
+func NewWebhook(url string, secret []byte) *Webhook {
+	return &Webhook{
+		URL:         url,
+		Secret:      secret,
+		Client:      &http.Client{Timeout: 10 * time.Second},
+		MaxAttempts: 8,
+		MinBackoff:  500 * time.Millisecond,
+		MaxBackoff:  time.Minute,
+	}
+}
+
+// Sign returns the signature header of a request body.
+func Sign(secret []byte, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify checks the signature header of a request body in constant time.
+func Verify(secret []byte, timestamp string, body []byte, signature string) bool {
+	return hmac.Equal([]byte(Sign(secret, timestamp, body)), []byte(signature))
+}
+
+// Write delivers the event, retrying until it is accepted, the attempts run
+// out or ctx is done.
+func (w *Webhook) Write(ctx context.Context, event Event) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	var attempt int
+	for attempt = 1; ; attempt++ {
+		var retry bool
+		var wait time.Duration
+
+		retry, wait, err = w.post(ctx, event.ID, body)
+		if err == nil {
+			return nil
+		}
+
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		if !retry || attempt >= w.MaxAttempts {
+			break
+		}
+
+		if wait == 0 {
+			wait = w.backoff(attempt)
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+
+	return w.deadLetter(event, attempt, err)
+}
+
+// Close does nothing, the dead-letter writer belongs to the caller.
+func (w *Webhook) Close() error {
+	return nil
+}
+
+// post makes one attempt, and tells whether a failure may be retried and how
+// long the server asked to wait.
+func (w *Webhook) post(ctx context.Context, id string, body []byte) (bool, time.Duration, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.URL, bytes.NewReader(body))
+	if err != nil {
+		return false, 0, err
+	}
+
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(TimestampHeader, timestamp)
+	req.Header.Set(SignatureHeader, Sign(w.Secret, timestamp, body))
+	req.Header.Set(IdempotencyHeader, id)
+
+	resp, err := w.Client.Do(req)
+	if err != nil {
+		return true, 0, err
+	}
+
+	// Drain the body so the connection is reused
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+	resp.Body.Close()
+
+	switch code := resp.StatusCode; {
+	case code >= 200 && code < 300:
+		return false, 0, nil
+	case code == http.StatusRequestTimeout || code == http.StatusTooManyRequests || code >= 500:
+		return true, w.retryAfter(resp), fmt.Errorf("webhook responded %s", resp.Status)
+	default:
+		return false, 0, fmt.Errorf("webhook responded %s", resp.Status)
+	}
+}
+
+// backoff doubles the wait after each attempt, with jitter so that restarted
+// watchers do not retry in lockstep.
+func (w *Webhook) backoff(attempt int) time.Duration {
+	d := w.MinBackoff << (attempt - 1)
+	if d > w.MaxBackoff || d <= 0 {
+		d = w.MaxBackoff
+	}
+
+	return d/2 + time.Duration(rand.Int63n(int64(d/2)+1))
+}
+
+// retryAfter reads the Retry-After seconds of 429 and 503 responses.
+func (w *Webhook) retryAfter(resp *http.Response) time.Duration {
+	seconds, err := strconv.Atoi(resp.Header.Get("Retry-After"))
+	if err != nil || seconds <= 0 {
+		return 0
+	}
+
+	return min(time.Duration(seconds)*time.Second, w.MaxBackoff)
+}
+
+func (w *Webhook) deadLetter(event Event, attempts int, cause error) error {
+	if w.DeadLetter == nil {
+		return fmt.Errorf("deliver %s after %d attempts: %w", event.ID, attempts, cause)
+	}
+
+	line, err := json.Marshal(DeadLetterRecord{Event: event, Error: cause.Error(), Attempts: attempts, Time: time.Now().UTC()})
+	if err != nil {
+		return err
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if _, err := w.DeadLetter.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("dead-letter %s: %w (delivery: %w)", event.ID, err, cause)
+	}
+
+	return fmt.Errorf("%w %s after %d attempts: %w", ErrDeadLettered, event.ID, attempts, cause)
+}
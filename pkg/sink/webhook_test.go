@@ -0,0 +1,181 @@
+package sink
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var secret = []byte("s3cret")
+
+func testEvent() Event {
+	txHash := common.HexToHash("0x13a4f6a593de5b460db04e418798133ae55b0f88ffc6ede345e89c8b6626feb9")
+
+	return Event{
+		ID:          txHash.Hex() + ":0",
+		Event:       "Transfer",
+		Token:       common.HexToAddress("0x5FbDB2315678afecb367f032d93F642f64180aa3"),
+		From:        common.HexToAddress("0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266"),
+		To:          common.HexToAddress("0x70997970C51812dc3A010C7d01b50e0d17dc79C8"),
+		Value:       "1500000000000000000",
+		BlockNumber: 2,
+		TxHash:      txHash,
+	}
+}
+
+// receiver records requests and answers with the given status codes in turn,
+// then 200.
+type receiver struct {
+	mu       sync.Mutex
+	statuses []int
+	requests []*http.Request
+	bodies   [][]byte
+}
+
+func (r *receiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	body, _ := io.ReadAll(req.Body)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.requests = append(r.requests, req)
+	r.bodies = append(r.bodies, body)
+
+	status := http.StatusOK
+	if len(r.statuses) > 0 {
+		status, r.statuses = r.statuses[0], r.statuses[1:]
+	}
+
+	w.WriteHeader(status)
+}
+
+func newWebhook(t *testing.T, statuses ...int) (*Webhook, *receiver) {
+	t.Helper()
+
+	r := &receiver{statuses: statuses}
+	server := httptest.NewServer(r)
+	t.Cleanup(server.Close)
+
+	w := NewWebhook(server.URL, secret)
+	w.MaxAttempts = 3
+	w.MinBackoff = time.Millisecond
+	w.MaxBackoff = 5 * time.Millisecond
+
+	return w, r
+}
+
+func TestWebhookSigned(t *testing.T) {
+	w, r := newWebhook(t)
+
+	event := testEvent()
+	if err := w.Write(context.Background(), event); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(r.requests) != 1 {
+		t.Fatalf("got %d requests, want 1", len(r.requests))
+	}
+
+	req, body := r.requests[0], r.bodies[0]
+
+	if !Verify(secret, req.Header.Get(TimestampHeader), body, req.Header.Get(SignatureHeader)) {
+		t.Errorf("signature %q does not verify", req.Header.Get(SignatureHeader))
+	}
+
+	if Verify([]byte("other"), req.Header.Get(TimestampHeader), body, req.Header.Get(SignatureHeader)) {
+		t.Error("signature verifies with another secret")
+	}
+
+	if got := req.Header.Get(IdempotencyHeader); got != event.ID {
+		t.Errorf("idempotency key %q, want %q", got, event.ID)
+	}
+
+	var got Event
+	if err := json.Unmarshal(body, &got); err != nil {
+		t.Fatal(err)
+	}
+
+	if got != event {
+		t.Errorf("body %+v, want %+v", got, event)
+	}
+}
+
+func TestWebhookRetries(t *testing.T) {
+	w, r := newWebhook(t, http.StatusInternalServerError, http.StatusTooManyRequests)
+
+	if err := w.Write(context.Background(), testEvent()); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(r.requests) != 3 {
+		t.Fatalf("got %d requests, want 3", len(r.requests))
+	}
+
+	// Every attempt carries the same key, so the receiver can deduplicate
+	for _, req := range r.requests {
+		if req.Header.Get(IdempotencyHeader) != testEvent().ID {
+			t.Errorf("idempotency key changed to %q", req.Header.Get(IdempotencyHeader))
+		}
+	}
+}
+
+func TestWebhookDeadLetter(t *testing.T) {
+	w, r := newWebhook(t, 503, 503, 503)
+
+	var deadLetter bytes.Buffer
+	w.DeadLetter = &deadLetter
+
+	err := w.Write(context.Background(), testEvent())
+	if !errors.Is(err, ErrDeadLettered) {
+		t.Fatalf("Write: %v, want dead-lettered", err)
+	}
+
+	if len(r.requests) != 3 {
+		t.Errorf("got %d requests, want 3", len(r.requests))
+	}
+
+	var record DeadLetterRecord
+	if err := json.Unmarshal(deadLetter.Bytes(), &record); err != nil {
+		t.Fatal(err)
+	}
+
+	if record.Event != testEvent() || record.Attempts != 3 || record.Error == "" {
+		t.Errorf("dead-letter record %+v", record)
+	}
+}
+
+func TestWebhookPermanentFailure(t *testing.T) {
+	w, r := newWebhook(t, http.StatusBadRequest)
+
+	// Without a dead-letter file the error stops the caller
+	err := w.Write(context.Background(), testEvent())
+	if err == nil || errors.Is(err, ErrDeadLettered) {
+		t.Fatalf("Write: %v, want delivery error", err)
+	}
+
+	if len(r.requests) != 1 {
+		t.Errorf("retried a 400 response: %d requests", len(r.requests))
+	}
+}
+
+func TestWebhookCanceled(t *testing.T) {
+	w, _ := newWebhook(t, 500, 500, 500)
+	w.MinBackoff = time.Hour
+	w.MaxBackoff = time.Hour
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	if err := w.Write(ctx, testEvent()); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Write: %v, want deadline exceeded", err)
+	}
+}
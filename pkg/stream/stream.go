@@ -0,0 +1,391 @@
+// Package stream serves token events over gRPC, as typed streams for
+// consumers in other languages. The TokenEvents service and its messages are
+// defined in stream.proto, and the Go code is generated with protoc,
+// protoc-gen-go and protoc-gen-go-grpc:
+//
+//	go generate ./pkg/stream
+//
+// One watcher delivers the events of every stream over a single connection.
+// The server holds them in memory, so a stream starts at any block since the
+// first one watched, and a client that lost its stream resumes from the
+// cursor of the last event it received:
+//
+//	server := &stream.Server{Dial: dial, Tokens: tokens, FromBlock: 1}
+//	go server.Run(ctx)
+//
+//	srv := grpc.NewServer()
+//	stream.RegisterTokenEventsServer(srv, server)
+package stream
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative stream.proto
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-ethereum-example/pkg/sink"
+	"go-ethereum-example/pkg/watcher"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Server serves the events of Tokens, once Run runs its watcher.
+type Server struct {
+	UnimplementedTokenEventsServer
+
+	// Dial connects the watcher to the node, see watcher.Watcher.
+	Dial func(ctx context.Context) (watcher.Client, error)
+
+	// Tokens are the tokens served, subscriptions may pick a subset.
+	Tokens []common.Address
+
+	// FromBlock is the first block watched, 1 by default. Streams cannot
+	// start before it.
+	FromBlock uint64
+
+	// Confirmations and PollInterval configure the watcher, see
+	// watcher.Watcher.
+	Confirmations uint64
+	PollInterval  time.Duration
+
+	// Logf reports the connections and retries of the watcher.
+	Logf func(format string, args ...any)
+
+	hub hub
+}
+
+// Run watches the events of all streams until ctx is canceled, as
+// watcher.Watcher.Run does. The streams fail with Unavailable once it returns.
+func (s *Server) Run(ctx context.Context) error {
+	w := &watcher.Watcher{
+		Dial:          s.Dial,
+		Tokens:        s.Tokens,
+		Sink:          &s.hub,
+		Events:        watcher.Events,
+		FromBlock:     s.firstBlock(),
+		Confirmations: s.Confirmations,
+		PollInterval:  s.PollInterval,
+		Logf:          s.Logf,
+	}
+
+	s.hub.setWatcher(w)
+
+	err := w.Run(ctx)
+	if err != nil {
+		s.hub.stop(err)
+	} else {
+		s.hub.stop(errors.New("server stopped"))
+	}
+
+	return err
+}
+
+// SubscribeTransfers streams the Transfer events matching req.
+func (s *Server) SubscribeTransfers(req *SubscribeRequest, stream grpc.ServerStreamingServer[Transfer]) error {
+	return s.subscribe(stream.Context(), req, "Transfer", func(event sink.Event) error {
+		return stream.Send(&Transfer{
+			From:  event.From.Hex(),
+			To:    event.To.Hex(),
+			Value: event.Value,
+			Raw:   rawLog(event),
+		})
+	})
+}
+
+// SubscribeApprovals streams the Approval events matching req.
+func (s *Server) SubscribeApprovals(req *SubscribeRequest, stream grpc.ServerStreamingServer[Approval]) error {
+	return s.subscribe(stream.Context(), req, "Approval", func(event sink.Event) error {
+		return stream.Send(&Approval{
+			Owner:   event.From.Hex(),
+			Spender: event.To.Hex(),
+			Value:   event.Value,
+			Raw:     rawLog(event),
+		})
+	})
+}
+
+// subscribe sends the events of the watcher that match req, from the position
+// req asks for, until the client cancels. Each stream reads the events held by
+// the server at its own pace, so a slow client holds back no other.
+func (s *Server) subscribe(ctx context.Context, req *SubscribeRequest, name string, send func(sink.Event) error) error {
+	f, err := s.filter(req, name)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	next, err := s.start(req)
+	if err != nil {
+		return err
+	}
+
+	for {
+		events, wake, err := s.hub.read(next)
+		if err != nil {
+			return status.Error(codes.Unavailable, err.Error())
+		}
+
+		for _, event := range events {
+			if !f.matches(event) {
+				continue
+			}
+
+			if err := send(event); err != nil {
+				return err
+			}
+		}
+
+		next += len(events)
+
+		if wake == nil {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return status.FromContextError(ctx.Err()).Err()
+		case <-wake:
+		}
+	}
+}
+
+// start returns the index of the first event held that a stream reads: the
+// first one after the cursor of req, or from its block, or from the first
+// block not yet confirmed.
+func (s *Server) start(req *SubscribeRequest) (int, error) {
+	first := s.firstBlock()
+
+	if after := req.GetAfter(); after != nil {
+		if after.GetBlockNumber() < first {
+			return 0, status.Errorf(codes.OutOfRange, "cursor in block %d, before the first block watched %d", after.GetBlockNumber(), first)
+		}
+
+		return s.hub.index(func(event sink.Event) bool {
+			if event.BlockNumber != after.GetBlockNumber() {
+				return event.BlockNumber > after.GetBlockNumber()
+			}
+
+			return event.LogIndex > uint(after.GetLogIndex())
+		}), nil
+	}
+
+	from := req.GetFromBlock()
+	if from == 0 {
+		// As a watcher of its own would, which needs the head
+		head := s.hub.status().Head
+		if head == 0 {
+			return 0, status.Error(codes.Unavailable, "not connected to the node yet")
+		}
+
+		from = head + 1 - min(s.Confirmations, head)
+	}
+
+	if from < first {
+		return 0, status.Errorf(codes.OutOfRange, "block %d is before the first block watched %d", from, first)
+	}
+
+	return s.hub.index(func(event sink.Event) bool { return event.BlockNumber >= from }), nil
+}
+
+func (s *Server) firstBlock() uint64 {
+	return max(s.FromBlock, 1)
+}
+
+// filter maps a request onto the events it streams, or returns why it is
+// invalid.
+func (s *Server) filter(req *SubscribeRequest, name string) (*filter, error) {
+	tokens, err := parseAddresses("token", req.GetTokens())
+	if err != nil {
+		return nil, err
+	}
+
+	for _, address := range tokens {
+		if !slices.Contains(s.Tokens, address) {
+			return nil, fmt.Errorf("token %s is not served", address.Hex())
+		}
+	}
+
+	if len(tokens) == 0 {
+		tokens = s.Tokens
+	}
+
+	f := &filter{event: name, tokens: tokens}
+
+	if f.from, err = parseAddresses("from", req.GetFrom()); err != nil {
+		return nil, err
+	}
+
+	if f.to, err = parseAddresses("to", req.GetTo()); err != nil {
+		return nil, err
+	}
+
+	if f.involving, err = parseAddresses("involving", req.GetInvolving()); err != nil {
+		return nil, err
+	}
+
+	if len(f.involving) > 0 && (len(f.from) > 0 || len(f.to) > 0) {
+		return nil, errors.New("involving cannot be combined with from and to")
+	}
+
+	return f, nil
+}
+
+// filter selects the events of a stream, as the watcher does with From, To and
+// Involving.
+type filter struct {
+	event     string
+	tokens    []common.Address
+	from      []common.Address
+	to        []common.Address
+	involving []common.Address
+}
+
+func (f *filter) matches(event sink.Event) bool {
+	switch {
+	case event.Event != f.event || !slices.Contains(f.tokens, event.Token):
+		return false
+	case len(f.from) > 0 && !slices.Contains(f.from, event.From):
+		return false
+	case len(f.to) > 0 && !slices.Contains(f.to, event.To):
+		return false
+	case len(f.involving) > 0 && !slices.Contains(f.involving, event.From) && !slices.Contains(f.involving, event.To):
+		return false
+	}
+
+	return true
+}
+
+// hub is the sink of the watcher. It holds every event delivered, in order,
+// for the streams to read.
+type hub struct {
+	mu      sync.Mutex
+	watcher *watcher.Watcher
+	events  []sink.Event
+	wake    chan struct{} // closed when events are added or the watcher stops
+	stopped error
+}
+
+func (h *hub) Write(ctx context.Context, event sink.Event) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.events = append(h.events, event)
+	h.notify()
+
+	return nil
+}
+
+func (h *hub) Close() error {
+	return nil
+}
+
+func (h *hub) setWatcher(w *watcher.Watcher) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.watcher = w
+}
+
+// status returns the status of the watcher, empty before Run.
+func (h *hub) status() watcher.Status {
+	h.mu.Lock()
+	w := h.watcher
+	h.mu.Unlock()
+
+	if w == nil {
+		return watcher.Status{}
+	}
+
+	return w.Status()
+}
+
+// stop records why the watcher stopped and wakes the streams.
+func (h *hub) stop(err error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.stopped = err
+	h.notify()
+}
+
+// notify wakes the streams waiting for events. h.mu must be held.
+func (h *hub) notify() {
+	if h.wake != nil {
+		close(h.wake)
+		h.wake = nil
+	}
+}
+
+// read returns the events from index next on. When there are none yet, it
+// returns a channel closed once there are, or the error the watcher stopped
+// with.
+func (h *hub) read(next int) ([]sink.Event, <-chan struct{}, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if next < len(h.events) {
+		return h.events[next:len(h.events):len(h.events)], nil, nil
+	}
+
+	if h.stopped != nil {
+		return nil, nil, h.stopped
+	}
+
+	if h.wake == nil {
+		h.wake = make(chan struct{})
+	}
+
+	return nil, h.wake, nil
+}
+
+// index returns the index of the first event held for which from is true, or
+// the number of events when there is none.
+func (h *hub) index(from func(sink.Event) bool) int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for i, event := range h.events {
+		if from(event) {
+			return i
+		}
+	}
+
+	return len(h.events)
+}
+
+// Cursor returns the position of the log, to resume a stream after it.
+func (l *Log) Cursor() *Cursor {
+	return &Cursor{BlockNumber: l.GetBlockNumber(), LogIndex: l.GetLogIndex()}
+}
+
+func rawLog(event sink.Event) *Log {
+	return &Log{
+		Id:          event.ID,
+		Token:       event.Token.Hex(),
+		Symbol:      event.Symbol,
+		Decimals:    uint32(event.Decimals),
+		BlockNumber: event.BlockNumber,
+		BlockHash:   event.BlockHash.Hex(),
+		TxHash:      event.TxHash.Hex(),
+		LogIndex:    uint32(event.LogIndex),
+		Removed:     event.Removed,
+	}
+}
+
+func parseAddresses(name string, list []string) ([]common.Address, error) {
+	var addresses []common.Address
+	for _, s := range list {
+		if !common.IsHexAddress(s) {
+			return nil, fmt.Errorf("invalid %s address %q", name, s)
+		}
+
+		addresses = append(addresses, common.HexToAddress(s))
+	}
+
+	return addresses, nil
+}
@@ -0,0 +1,179 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: stream.proto
+
+// Token events as streamed by the watcher. Addresses and hashes are 0x-prefixed
+// hex strings, and amounts decimal strings in the token's smallest unit, as
+// they do not fit in 64 bits.
+
+package stream
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	TokenEvents_SubscribeTransfers_FullMethodName = "/tokenevents.v1.TokenEvents/SubscribeTransfers"
+	TokenEvents_SubscribeApprovals_FullMethodName = "/tokenevents.v1.TokenEvents/SubscribeApprovals"
+)
+
+// TokenEventsClient is the client API for TokenEvents service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// TokenEvents streams the events of the tokens a server watches, in chain
+// order. Streams do not end: they follow the chain until the client cancels.
+type TokenEventsClient interface {
+	// SubscribeTransfers streams Transfer events.
+	SubscribeTransfers(ctx context.Context, in *SubscribeRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Transfer], error)
+	// SubscribeApprovals streams Approval events.
+	SubscribeApprovals(ctx context.Context, in *SubscribeRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Approval], error)
+}
+
+type tokenEventsClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewTokenEventsClient(cc grpc.ClientConnInterface) TokenEventsClient {
+	return &tokenEventsClient{cc}
+}
+
+func (c *tokenEventsClient) SubscribeTransfers(ctx context.Context, in *SubscribeRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Transfer], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &TokenEvents_ServiceDesc.Streams[0], TokenEvents_SubscribeTransfers_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[SubscribeRequest, Transfer]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type TokenEvents_SubscribeTransfersClient = grpc.ServerStreamingClient[Transfer]
+
+func (c *tokenEventsClient) SubscribeApprovals(ctx context.Context, in *SubscribeRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Approval], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &TokenEvents_ServiceDesc.Streams[1], TokenEvents_SubscribeApprovals_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[SubscribeRequest, Approval]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type TokenEvents_SubscribeApprovalsClient = grpc.ServerStreamingClient[Approval]
+
+// TokenEventsServer is the server API for TokenEvents service.
+// All implementations must embed UnimplementedTokenEventsServer
+// for forward compatibility.
+//
+// TokenEvents streams the events of the tokens a server watches, in chain
+// order. Streams do not end: they follow the chain until the client cancels.
+type TokenEventsServer interface {
+	// SubscribeTransfers streams Transfer events.
+	SubscribeTransfers(*SubscribeRequest, grpc.ServerStreamingServer[Transfer]) error
+	// SubscribeApprovals streams Approval events.
+	SubscribeApprovals(*SubscribeRequest, grpc.ServerStreamingServer[Approval]) error
+	mustEmbedUnimplementedTokenEventsServer()
+}
+
+// UnimplementedTokenEventsServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedTokenEventsServer struct{}
+
+func (UnimplementedTokenEventsServer) SubscribeTransfers(*SubscribeRequest, grpc.ServerStreamingServer[Transfer]) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeTransfers not implemented")
+}
+func (UnimplementedTokenEventsServer) SubscribeApprovals(*SubscribeRequest, grpc.ServerStreamingServer[Approval]) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeApprovals not implemented")
+}
+func (UnimplementedTokenEventsServer) mustEmbedUnimplementedTokenEventsServer() {}
+func (UnimplementedTokenEventsServer) testEmbeddedByValue()                     {}
+
+// UnsafeTokenEventsServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to TokenEventsServer will
+// result in compilation errors.
+type UnsafeTokenEventsServer interface {
+	mustEmbedUnimplementedTokenEventsServer()
+}
+
+func RegisterTokenEventsServer(s grpc.ServiceRegistrar, srv TokenEventsServer) {
+	// If the following call pancis, it indicates UnimplementedTokenEventsServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&TokenEvents_ServiceDesc, srv)
+}
+
+func _TokenEvents_SubscribeTransfers_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(TokenEventsServer).SubscribeTransfers(m, &grpc.GenericServerStream[SubscribeRequest, Transfer]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type TokenEvents_SubscribeTransfersServer = grpc.ServerStreamingServer[Transfer]
+
+func _TokenEvents_SubscribeApprovals_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(TokenEventsServer).SubscribeApprovals(m, &grpc.GenericServerStream[SubscribeRequest, Approval]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type TokenEvents_SubscribeApprovalsServer = grpc.ServerStreamingServer[Approval]
+
+// TokenEvents_ServiceDesc is the grpc.ServiceDesc for TokenEvents service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var TokenEvents_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "tokenevents.v1.TokenEvents",
+	HandlerType: (*TokenEventsServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "SubscribeTransfers",
+			Handler:       _TokenEvents_SubscribeTransfers_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "SubscribeApprovals",
+			Handler:       _TokenEvents_SubscribeApprovals_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "stream.proto",
+}
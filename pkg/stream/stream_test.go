@@ -0,0 +1,294 @@
+package stream_test
+
+import (
+	"context"
+	"go-ethereum-example/pkg/stream"
+	"go-ethereum-example/pkg/testchain"
+	"go-ethereum-example/pkg/watcher"
+	"math/big"
+	"net"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+var supply = new(big.Int).Mul(big.NewInt(1_000_000), big.NewInt(1e18))
+
+// serve serves the events of the token from fromBlock over an in-memory
+// connection, and counts the connections to the chain.
+func serve(t *testing.T, chain *testchain.Chain, address common.Address, fromBlock uint64) (stream.TokenEventsClient, *atomic.Int32) {
+	t.Helper()
+
+	dials := new(atomic.Int32)
+
+	srv := &stream.Server{
+		Dial: func(ctx context.Context) (watcher.Client, error) {
+			dials.Add(1)
+			return chain.Client, nil
+		},
+		Tokens:       []common.Address{address},
+		FromBlock:    fromBlock,
+		PollInterval: 50 * time.Millisecond,
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go srv.Run(ctx)
+	t.Cleanup(cancel)
+
+	server := grpc.NewServer()
+	stream.RegisterTokenEventsServer(server, srv)
+
+	listener := bufconn.Listen(1 << 20)
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	return stream.NewTokenEventsClient(conn), dials
+}
+
+// subscribe opens a transfer stream, canceled at the end of the test.
+func subscribe(t *testing.T, client stream.TokenEventsClient, req *stream.SubscribeRequest) grpc.ServerStreamingClient[stream.Transfer] {
+	t.Helper()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	t.Cleanup(cancel)
+
+	transfers, err := client.SubscribeTransfers(ctx, req)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return transfers
+}
+
+// recv receives the values of the next n transfers.
+func recv(t *testing.T, transfers grpc.ServerStreamingClient[stream.Transfer], n int) []*stream.Transfer {
+	t.Helper()
+
+	var received []*stream.Transfer
+	for range n {
+		transfer, err := transfers.Recv()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		received = append(received, transfer)
+	}
+
+	return received
+}
+
+func values(transfers []*stream.Transfer) []string {
+	var values []string
+	for _, transfer := range transfers {
+		values = append(values, transfer.GetValue())
+	}
+
+	return values
+}
+
+func TestSubscribeTransfers(t *testing.T) {
+	chain := testchain.New(t)
+	owner, alice := chain.Accounts[0], chain.Accounts[1]
+
+	address, tok := chain.DeployToken(owner, supply)
+	client, _ := serve(t, chain, address, 1)
+
+	chain.Transfer(tok, owner, alice.Address, big.NewInt(1))
+	chain.Transfer(tok, owner, chain.Accounts[2].Address, big.NewInt(2))
+
+	// The mint of the deployment comes first
+	received := recv(t, subscribe(t, client, &stream.SubscribeRequest{FromBlock: 1}), 3)
+
+	if got := values(received); got[0] != supply.String() || got[1] != "1" || got[2] != "2" {
+		t.Fatalf("values %v", got)
+	}
+
+	transfer := received[1]
+	raw := transfer.GetRaw()
+
+	if transfer.GetFrom() != owner.Address.Hex() || transfer.GetTo() != alice.Address.Hex() {
+		t.Errorf("transfer from %s to %s", transfer.GetFrom(), transfer.GetTo())
+	}
+
+	if raw.GetToken() != address.Hex() || raw.GetSymbol() != "MTK" || raw.GetDecimals() != 18 || raw.GetBlockNumber() == 0 || raw.GetRemoved() {
+		t.Errorf("raw log %v", raw)
+	}
+
+	if want := raw.GetTxHash() + ":0"; raw.GetId() != want {
+		t.Errorf("id %s, want %s", raw.GetId(), want)
+	}
+
+	// Resuming after the first transfer skips the events up to it, then
+	// follows the chain
+	resumed := subscribe(t, client, &stream.SubscribeRequest{After: raw.Cursor()})
+
+	if got := values(recv(t, resumed, 1)); got[0] != "2" {
+		t.Fatalf("resumed with %v, want the second transfer", got)
+	}
+
+	chain.Transfer(tok, owner, alice.Address, big.NewInt(3))
+
+	if got := values(recv(t, resumed, 1)); got[0] != "3" {
+		t.Errorf("live transfer %v, want 3", got)
+	}
+}
+
+func TestSubscribeFilters(t *testing.T) {
+	chain := testchain.New(t)
+	owner, alice := chain.Accounts[0], chain.Accounts[1]
+
+	address, tok := chain.DeployToken(owner, supply)
+	client, _ := serve(t, chain, address, 1)
+
+	chain.Transfer(tok, owner, alice.Address, big.NewInt(1))
+	chain.Transfer(tok, owner, chain.Accounts[2].Address, big.NewInt(2))
+	chain.Transfer(tok, owner, alice.Address, big.NewInt(3))
+
+	for _, tt := range []struct {
+		name string
+		req  *stream.SubscribeRequest
+		want []string
+	}{
+		{"to", &stream.SubscribeRequest{To: []string{alice.Address.Hex()}}, []string{"1", "3"}},
+		{"involving", &stream.SubscribeRequest{Involving: []string{alice.Address.Hex()}}, []string{"1", "3"}},
+		{"from", &stream.SubscribeRequest{From: []string{owner.Address.Hex()}}, []string{"1", "2", "3"}},
+		{"token", &stream.SubscribeRequest{Tokens: []string{address.Hex()}, From: []string{owner.Address.Hex()}}, []string{"1", "2", "3"}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.req.FromBlock = 2
+
+			got := values(recv(t, subscribe(t, client, tt.req), len(tt.want)))
+			for i := range tt.want {
+				if got[i] != tt.want[i] {
+					t.Errorf("values %v, want %v", got, tt.want)
+					break
+				}
+			}
+		})
+	}
+}
+
+func TestSubscribeApprovals(t *testing.T) {
+	chain := testchain.New(t)
+	owner, alice := chain.Accounts[0], chain.Accounts[1]
+
+	address, tok := chain.DeployToken(owner, supply)
+	client, _ := serve(t, chain, address, 1)
+
+	chain.Transfer(tok, owner, alice.Address, big.NewInt(1))
+
+	if _, err := tok.Approve(chain.Transactor(owner), alice.Address, big.NewInt(5)); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	approvals, err := client.SubscribeApprovals(ctx, &stream.SubscribeRequest{FromBlock: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The transfers before it are not streamed
+	approval, err := approvals.Recv()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if approval.GetOwner() != owner.Address.Hex() || approval.GetSpender() != alice.Address.Hex() || approval.GetValue() != "5" {
+		t.Errorf("approval %v", approval)
+	}
+}
+
+func TestSubscribeInvalid(t *testing.T) {
+	chain := testchain.New(t)
+	alice := chain.Accounts[1].Address.Hex()
+
+	address, _ := chain.DeployToken(chain.Accounts[0], supply)
+	client, _ := serve(t, chain, address, 1)
+
+	for _, tt := range []struct {
+		name string
+		req  *stream.SubscribeRequest
+	}{
+		{"bad token", &stream.SubscribeRequest{Tokens: []string{"0x1234"}}},
+		{"token not served", &stream.SubscribeRequest{Tokens: []string{alice}}},
+		{"bad address", &stream.SubscribeRequest{To: []string{"alice"}}},
+		{"involving and from", &stream.SubscribeRequest{Involving: []string{alice}, From: []string{alice}}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := subscribe(t, client, tt.req).Recv()
+			if status.Code(err) != codes.InvalidArgument {
+				t.Errorf("error %v, want InvalidArgument", err)
+			}
+		})
+	}
+}
+
+// TestSubscribeShared checks that the streams share the watcher of the server
+// and its connection.
+func TestSubscribeShared(t *testing.T) {
+	chain := testchain.New(t)
+	owner := chain.Accounts[0]
+
+	address, tok := chain.DeployToken(owner, supply)
+	client, dials := serve(t, chain, address, 1)
+
+	chain.Transfer(tok, owner, chain.Accounts[1].Address, big.NewInt(1))
+
+	var streams []grpc.ServerStreamingClient[stream.Transfer]
+	for range 3 {
+		streams = append(streams, subscribe(t, client, &stream.SubscribeRequest{FromBlock: 2}))
+	}
+
+	chain.Transfer(tok, owner, chain.Accounts[2].Address, big.NewInt(2))
+
+	for _, transfers := range streams {
+		if got := values(recv(t, transfers, 2)); got[0] != "1" || got[1] != "2" {
+			t.Errorf("values %v, want [1 2]", got)
+		}
+	}
+
+	if dials := dials.Load(); dials != 1 {
+		t.Errorf("%d connections for 3 streams, want 1", dials)
+	}
+}
+
+// TestSubscribeOutOfRange checks that streams cannot start before the first
+// block watched.
+func TestSubscribeOutOfRange(t *testing.T) {
+	chain := testchain.New(t)
+	address, _ := chain.DeployToken(chain.Accounts[0], supply)
+	client, _ := serve(t, chain, address, 2)
+
+	for _, tt := range []struct {
+		name string
+		req  *stream.SubscribeRequest
+	}{
+		{"from block", &stream.SubscribeRequest{FromBlock: 1}},
+		{"cursor", &stream.SubscribeRequest{After: &stream.Cursor{BlockNumber: 1}}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := subscribe(t, client, tt.req).Recv()
+			if status.Code(err) != codes.OutOfRange {
+				t.Errorf("error %v, want OutOfRange", err)
+			}
+		})
+	}
+}
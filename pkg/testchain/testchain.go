@@ -0,0 +1,325 @@
+// Package testchain is an in-process Ethereum chain for tests, built on
+// go-ethereum's simulated backend. It provides funded deterministic accounts,
+// one-call token deployment, Multicall3 at its canonical address, control over
+// block production and time travel, so services can be tested without an
+// outside node:
+//
+//	chain := testchain.New(t)
+//	tokenAddress, tokenInstance := chain.DeployToken(chain.Accounts[0], supply)
+package testchain
+
+import (
+	"context"
+	"crypto/ecdsa"
+	token "go-ethereum-example/gen"
+	"go-ethereum-example/pkg/multicall"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/eth/ethconfig"
+	"github.com/ethereum/go-ethereum/ethclient/simulated"
+	"github.com/ethereum/go-ethereum/node"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// ChainID is the chain ID of the simulated backend.
+var ChainID = big.NewInt(1337)
+
+// DevKeys are the private keys of the default anvil and hardhat accounts,
+// derived from the mnemonic "test test test test test test test test test
+// test test junk".
+var DevKeys = []string{
+	"ac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80",
+	"59c6995e998f97a5a0044966f0945389dc9e86dae88c7a8412f4603b6b78690d",
+	"5de4111afa1a4b94908f83103eb1f1706367c2e68ca870fc3fb9a804cdab365a",
+	"7c852118294e51e653712a81e05800f419141751be58f605c371e15141b007a6",
+	"47e179ec197488593b187f80a00eb0da91f1b9d0b13f8733639f19c30a34926a",
+	"8b3a350cf5c34c9194ca85829a2df0ec3153be0318b5e2d3348e872092edffba",
+	"92db14e403b83dfe3df233f83dfa3a0d7096f21ca9b0d6d6b8d88b2b4ec1564e",
+	"4bbbf85ce3377467afe5d46f804f221813b2bb87f24d81f60f1fcdbf7cbf4356",
+	"dbda1821b80551c9d65939329250298aa3472ba22feea921c0cf5d620ea67b97",
+	"2a871d0798f97d79848a013d4936a73bf4cc922c825d33c1cf7073dff6d409c6",
+}
+
+// DefaultBalance is the genesis balance of every account: 10,000 ether.
+var DefaultBalance = new(big.Int).Mul(big.NewInt(10_000), big.NewInt(params.Ether))
+
+// Account is a funded account of the chain.
+type Account struct {
+	Key     *ecdsa.PrivateKey
+	Address common.Address
+}
+
+// DevAccounts returns the accounts of DevKeys.
+func DevAccounts() []Account {
+	accounts := make([]Account, len(DevKeys))
+	for i, k := range DevKeys {
+		key, err := crypto.HexToECDSA(k)
+		if err != nil {
+			panic(err)
+		}
+		accounts[i] = Account{Key: key, Address: crypto.PubkeyToAddress(key.PublicKey)}
+	}
+
+	return accounts
+}
+
+// Option configures a chain.
+type Option func(*config)
+
+type config struct {
+	balance  *big.Int
+	alloc    types.GenesisAlloc
+	autoMine bool
+}
+
+// WithBalance sets the genesis balance of the accounts.
+func WithBalance(balance *big.Int) Option {
+	return func(c *config) { c.balance = balance }
+}
+
+// WithAlloc adds genesis accounts, e.g. contracts with predeployed code.
+func WithAlloc(alloc types.GenesisAlloc) Option {
+	return func(c *config) {
+		for addr, account := range alloc {
+			c.alloc[addr] = account
+		}
+	}
+}
+
+// WithAutoMine sets whether every sent transaction is mined in its own block
+// right away. It is on by default.
+func WithAutoMine(autoMine bool) Option {
+	return func(c *config) { c.autoMine = autoMine }
+}
+
+// Chain is a simulated chain with funded accounts.
+type Chain struct {
+	Backend  *simulated.Backend
+	Client   *Client
+	Accounts []Account
+
+	t        testing.TB
+	mu       sync.Mutex
+	autoMine bool
+}
+
+// New starts a chain that is closed when the test ends. The accounts are
+// DevAccounts, funded with DefaultBalance unless configured otherwise, and
+// Multicall3 is deployed at multicall.Address.
+func New(t testing.TB, opts ...Option) *Chain {
+	t.Helper()
+
+	cfg := &config{balance: DefaultBalance, alloc: types.GenesisAlloc{}, autoMine: true}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	accounts := DevAccounts()
+
+	multicallCode, err := multicall.Code()
+	if err != nil {
+		t.Fatalf("multicall code: %v", err)
+	}
+
+	alloc := types.GenesisAlloc{multicall.Address: {Code: multicallCode}}
+	for _, a := range accounts {
+		alloc[a.Address] = types.Account{Balance: cfg.balance}
+	}
+	for addr, account := range cfg.alloc {
+		alloc[addr] = account
+	}
+
+	// Start post-merge so Shanghai opcodes (PUSH0) are enabled from genesis
+	backend := simulated.NewBackend(alloc, func(_ *node.Config, ethConf *ethconfig.Config) {
+		ethConf.Genesis.Difficulty = new(big.Int)
+	})
+	t.Cleanup(func() { backend.Close() })
+
+	chain := &Chain{Backend: backend, Accounts: accounts, t: t, autoMine: cfg.autoMine}
+	chain.Client = &Client{Client: backend.Client(), chain: chain}
+
+	return chain
+}
+
+// Client is the chain's RPC client. With auto mining on, each transaction it
+// sends is mined before SendTransaction returns.
+type Client struct {
+	simulated.Client
+	chain *Chain
+}
+
+// SendTransaction sends the transaction and mines it when auto mining is on.
+func (c *Client) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	if err := c.Client.SendTransaction(ctx, tx); err != nil {
+		return err
+	}
+
+	if c.chain.AutoMine() {
+		c.chain.Mine()
+	}
+
+	return nil
+}
+
+// CodeAtHash returns the code of an account at a block hash, for call
+// options pinned by hash.
+func (c *Client) CodeAtHash(ctx context.Context, account common.Address, hash common.Hash) ([]byte, error) {
+	return c.Client.(bind.BlockHashContractCaller).CodeAtHash(ctx, account, hash)
+}
+
+// CallContractAtHash runs a call at a block hash, for call options pinned by
+// hash.
+func (c *Client) CallContractAtHash(ctx context.Context, msg ethereum.CallMsg, hash common.Hash) ([]byte, error) {
+	return c.Client.(bind.BlockHashContractCaller).CallContractAtHash(ctx, msg, hash)
+}
+
+// AutoMine reports whether sent transactions are mined right away.
+func (c *Chain) AutoMine() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.autoMine
+}
+
+// SetAutoMine turns auto mining on or off. With it off, transactions stay
+// pending until Mine is called.
+func (c *Chain) SetAutoMine(autoMine bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.autoMine = autoMine
+}
+
+// Mine seals a block with the pending transactions and returns its hash.
+func (c *Chain) Mine() common.Hash {
+	return c.Backend.Commit()
+}
+
+// MineBlocks seals n blocks.
+func (c *Chain) MineBlocks(n int) {
+	for i := 0; i < n; i++ {
+		c.Backend.Commit()
+	}
+}
+
+// AdjustTime seals an empty block whose timestamp is d after the latest one,
+// e.g. to let a permit deadline pass. Pending transactions must be mined first.
+func (c *Chain) AdjustTime(d time.Duration) {
+	c.t.Helper()
+
+	if err := c.Backend.AdjustTime(d); err != nil {
+		c.t.Fatalf("adjust time: %v", err)
+	}
+}
+
+// Now returns the timestamp of the latest block.
+func (c *Chain) Now() time.Time {
+	c.t.Helper()
+
+	header, err := c.Client.HeaderByNumber(context.Background(), nil)
+	if err != nil {
+		c.t.Fatal(err)
+	}
+
+	return time.Unix(int64(header.Time), 0)
+}
+
+// Head returns the number of the latest block.
+func (c *Chain) Head() uint64 {
+	c.t.Helper()
+
+	head, err := c.Client.BlockNumber(context.Background())
+	if err != nil {
+		c.t.Fatal(err)
+	}
+
+	return head
+}
+
+// Transactor returns transaction options signing with the account's key.
+func (c *Chain) Transactor(a Account) *bind.TransactOpts {
+	c.t.Helper()
+
+	opts, err := bind.NewKeyedTransactorWithChainID(a.Key, ChainID)
+	if err != nil {
+		c.t.Fatal(err)
+	}
+
+	return opts
+}
+
+// Balance returns the ether balance of an address at the latest block.
+func (c *Chain) Balance(addr common.Address) *big.Int {
+	c.t.Helper()
+
+	balance, err := c.Client.BalanceAt(context.Background(), addr, nil)
+	if err != nil {
+		c.t.Fatal(err)
+	}
+
+	return balance
+}
+
+// Receipt mines the transaction if it is still pending and returns its
+// receipt.
+func (c *Chain) Receipt(tx *types.Transaction) *types.Receipt {
+	c.t.Helper()
+
+	ctx := context.Background()
+
+	receipt, err := c.Client.TransactionReceipt(ctx, tx.Hash())
+	if err != nil {
+		c.Mine()
+
+		receipt, err = c.Client.TransactionReceipt(ctx, tx.Hash())
+		if err != nil {
+			c.t.Fatalf("receipt of %s: %v", tx.Hash().Hex(), err)
+		}
+	}
+
+	return receipt
+}
+
+// Transfer sends value of the token from the account to an address in its
+// smallest unit, mines it and returns the receipt. A failed transfer fails
+// the test.
+func (c *Chain) Transfer(tokenInstance *token.Token, from Account, to common.Address, value *big.Int) *types.Receipt {
+	c.t.Helper()
+
+	tx, err := tokenInstance.Transfer(c.Transactor(from), to, value)
+	if err != nil {
+		c.t.Fatalf("transfer: %v", err)
+	}
+
+	receipt := c.Receipt(tx)
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		c.t.Fatalf("transfer: transaction %s failed", tx.Hash().Hex())
+	}
+
+	return receipt
+}
+
+// DeployToken deploys MyToken from the account with the initial supply in its
+// smallest unit, mines it and returns the address and the binding.
+func (c *Chain) DeployToken(from Account, initialSupply *big.Int) (common.Address, *token.Token) {
+	c.t.Helper()
+
+	address, tx, tokenInstance, err := token.DeployToken(c.Transactor(from), c.Client, initialSupply)
+	if err != nil {
+		c.t.Fatalf("deploy token: %v", err)
+	}
+
+	if receipt := c.Receipt(tx); receipt.Status != types.ReceiptStatusSuccessful {
+		c.t.Fatalf("deploy token: transaction %s failed", tx.Hash().Hex())
+	}
+
+	return address, tokenInstance
+}
@@ -0,0 +1,201 @@
+package testchain
+
+import (
+	"context"
+	"errors"
+	token "go-ethereum-example/gen"
+	"go-ethereum-example/pkg/revert"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+var supply = new(big.Int).Mul(big.NewInt(1_000_000), big.NewInt(1e18))
+
+func TestDevAccounts(t *testing.T) {
+	accounts := DevAccounts()
+
+	// The well-known first and last anvil accounts
+	if accounts[0].Address != common.HexToAddress("0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266") {
+		t.Errorf("account 0 is %s", accounts[0].Address.Hex())
+	}
+
+	if accounts[9].Address != common.HexToAddress("0xa0Ee7A142d267C1f36714E4a8F75612F20a79720") {
+		t.Errorf("account 9 is %s", accounts[9].Address.Hex())
+	}
+
+	chain := New(t)
+	if balance := chain.Balance(accounts[5].Address); balance.Cmp(DefaultBalance) != 0 {
+		t.Errorf("account 5 has %s wei", balance)
+	}
+}
+
+func TestDeployToken(t *testing.T) {
+	chain := New(t)
+	owner := chain.Accounts[0]
+
+	address, tokenInstance := chain.DeployToken(owner, supply)
+
+	code, err := chain.Client.CodeAt(context.Background(), address, nil)
+	if err != nil || len(code) == 0 {
+		t.Fatalf("no code at %s: %v", address.Hex(), err)
+	}
+
+	balance, err := tokenInstance.BalanceOf(nil, owner.Address)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if balance.Cmp(supply) != 0 {
+		t.Errorf("owner balance %s, want %s", balance, supply)
+	}
+
+	symbol, err := tokenInstance.Symbol(nil)
+	if err != nil || symbol != "MTK" {
+		t.Errorf("symbol %q: %v", symbol, err)
+	}
+}
+
+func TestTransfer(t *testing.T) {
+	chain := New(t)
+	owner, recipient := chain.Accounts[0], chain.Accounts[1]
+
+	_, tokenInstance := chain.DeployToken(owner, supply)
+
+	chain.Transfer(tokenInstance, owner, recipient.Address, big.NewInt(1000))
+
+	balance, err := tokenInstance.BalanceOf(nil, recipient.Address)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if balance.Int64() != 1000 {
+		t.Errorf("recipient balance %s, want 1000", balance)
+	}
+}
+
+func TestRevertDecoding(t *testing.T) {
+	chain := New(t)
+	owner, stranger := chain.Accounts[0], chain.Accounts[1]
+
+	_, tokenInstance := chain.DeployToken(owner, supply)
+
+	// The stranger holds no tokens, so gas estimation reverts
+	_, err := tokenInstance.Transfer(chain.Transactor(stranger), owner.Address, big.NewInt(1))
+
+	var insufficient *token.ERC20InsufficientBalance
+	if !errors.As(revert.Decode(err), &insufficient) {
+		t.Fatalf("expected ERC20InsufficientBalance, got %v", err)
+	}
+
+	if insufficient.Sender != stranger.Address || insufficient.Balance.Sign() != 0 || insufficient.Needed.Int64() != 1 {
+		t.Errorf("unexpected error fields: %v", insufficient)
+	}
+
+	// With a fixed gas limit the transfer is mined and the receipt fails
+	opts := chain.Transactor(stranger)
+	opts.GasLimit = 100_000
+
+	tx, err := tokenInstance.Transfer(opts, owner.Address, big.NewInt(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	receipt := chain.Receipt(tx)
+	if receipt.Status != types.ReceiptStatusFailed {
+		t.Fatal("transfer without balance succeeded")
+	}
+
+	if err := revert.Receipt(context.Background(), chain.Client, tx, receipt); !errors.As(err, &insufficient) {
+		t.Errorf("expected ERC20InsufficientBalance from receipt, got %v", err)
+	}
+}
+
+func TestEvents(t *testing.T) {
+	chain := New(t)
+	owner, recipient := chain.Accounts[0], chain.Accounts[1]
+
+	_, tokenInstance := chain.DeployToken(owner, supply)
+
+	sink := make(chan *token.TokenTransfer, 1)
+
+	sub, err := tokenInstance.WatchTransfer(nil, sink, nil, []common.Address{recipient.Address})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sub.Unsubscribe()
+
+	if _, err := tokenInstance.Transfer(chain.Transactor(owner), recipient.Address, big.NewInt(42)); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case event := <-sink:
+		if event.From != owner.Address || event.Value.Int64() != 42 {
+			t.Errorf("unexpected event %+v", event)
+		}
+	case err := <-sub.Err():
+		t.Fatal(err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("no transfer event")
+	}
+
+	// The mint in the constructor and the transfer are both in the history
+	it, err := tokenInstance.FilterTransfer(&bind.FilterOpts{Start: 0}, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer it.Close()
+
+	var count int
+	for it.Next() {
+		count++
+	}
+
+	if count != 2 {
+		t.Errorf("got %d transfer events, want 2", count)
+	}
+}
+
+func TestMiningControl(t *testing.T) {
+	chain := New(t, WithAutoMine(false))
+	ctx := context.Background()
+	owner := chain.Accounts[0]
+
+	address, tx, tokenInstance, err := token.DeployToken(chain.Transactor(owner), chain.Client, supply)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, pending, err := chain.Client.TransactionByHash(ctx, tx.Hash()); err != nil || !pending {
+		t.Fatalf("deployment not pending: %v", err)
+	}
+
+	before, _ := chain.Client.BlockNumber(ctx)
+	chain.Mine()
+	chain.MineBlocks(3)
+
+	after, _ := chain.Client.BlockNumber(ctx)
+	if after != before+4 {
+		t.Errorf("block number %d, want %d", after, before+4)
+	}
+
+	if _, err := tokenInstance.TotalSupply(nil); err != nil {
+		t.Errorf("token at %s not deployed: %v", address.Hex(), err)
+	}
+}
+
+func TestAdjustTime(t *testing.T) {
+	chain := New(t)
+
+	before := chain.Now()
+	chain.AdjustTime(24 * time.Hour)
+
+	if elapsed := chain.Now().Sub(before); elapsed != 24*time.Hour {
+		t.Errorf("time moved by %s, want 24h", elapsed)
+	}
+}
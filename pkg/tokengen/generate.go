@@ -0,0 +1,51 @@
+package tokengen
+
+import (
+	"context"
+	"go-ethereum-example/pkg/compile"
+	"os"
+	"path"
+	"path/filepath"
+)
+
+// Generate renders the spec to contracts/<Contract>.sol under root, registers
+// it as a target in the compiler configuration and rebuilds all artifacts and
+// bindings with the pinned settings. specPath is recorded in the generated
+// header. It returns the files written.
+func Generate(ctx context.Context, root string, spec *Spec, specPath string) (compile.Files, error) {
+	source, err := Render(spec, specPath)
+	if err != nil {
+		return nil, err
+	}
+
+	cfgPath := filepath.Join(root, compile.ConfigFile)
+
+	cfg, err := compile.LoadConfig(cfgPath)
+	if err != nil {
+		return nil, err
+	}
+
+	target := compile.Target{
+		Source:   path.Join("contracts", spec.Contract+".sol"),
+		Contract: spec.Contract,
+		Binding:  path.Join("gen", spec.BindingFile()),
+		Type:     spec.BindingType(),
+	}
+
+	if err := os.WriteFile(filepath.Join(root, filepath.FromSlash(target.Source)), source, 0o644); err != nil {
+		return nil, err
+	}
+
+	cfg.AddTarget(target)
+
+	files, err := compile.Build(ctx, root, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := cfg.Save(cfgPath); err != nil {
+		return nil, err
+	}
+
+	return files, files.Write(root)
+}
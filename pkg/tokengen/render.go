@@ -0,0 +1,190 @@
+package tokengen
+
+import (
+	"bytes"
+	"strings"
+	"text/template"
+)
+
+var contractTemplate = template.Must(template.New("token").Parse(`// SPDX-License-Identifier: GPL-3.0
+// Code generated by cmd/tokengen from {{.SpecPath}} - DO NOT EDIT.
+
+pragma solidity ^0.8.20;
+
+{{range .Imports}}import "@openzeppelin/contracts/{{.}}";
+{{end}}
+contract {{.Contract}} is {{.Bases}} {
+{{- if and .Roles .Mintable}}
+    bytes32 public constant MINTER_ROLE = keccak256("MINTER_ROLE");
+{{- end}}
+{{- if and .Roles .Pausable}}
+    bytes32 public constant PAUSER_ROLE = keccak256("PAUSER_ROLE");
+{{- end}}
+{{- if and .Roles (or .Mintable .Pausable)}}
+{{end}}
+    constructor(uint256 initialSupply)
+        ERC20("{{.Name}}", "{{.Symbol}}")
+{{- if .Capped}}
+        ERC20Capped({{.Cap}} * 10 ** {{.TokenDecimals}})
+{{- end}}
+{{- if .Ownable}}
+        Ownable(msg.sender)
+{{- end}}
+    {
+{{- if .Roles}}
+        _grantRole(DEFAULT_ADMIN_ROLE, msg.sender);
+{{- if .Mintable}}
+        _grantRole(MINTER_ROLE, msg.sender);
+{{- end}}
+{{- if .Pausable}}
+        _grantRole(PAUSER_ROLE, msg.sender);
+{{- end}}
+{{- range .Grants}}
+        _grantRole({{.Role}}, {{.Account}});
+{{- end}}
+{{- end}}
+        _mint(msg.sender, initialSupply);
+    }
+{{- if ne .TokenDecimals 18}}
+
+    function decimals() public pure override returns (uint8) {
+        return {{.TokenDecimals}};
+    }
+{{- end}}
+{{- if .Mintable}}
+
+    function mint(address to, uint256 amount) public {{.Guard "MINTER_ROLE"}} {
+        _mint(to, amount);
+    }
+{{- end}}
+{{- if .Pausable}}
+
+    function pause() public {{.Guard "PAUSER_ROLE"}} {
+        _pause();
+    }
+
+    function unpause() public {{.Guard "PAUSER_ROLE"}} {
+        _unpause();
+    }
+{{- end}}
+{{- if .UpdateOverrides}}
+
+    // The following functions are overrides required by Solidity.
+
+    function _update(address from, address to, uint256 value) internal override({{.UpdateOverrides}}) {
+        super._update(from, to, value);
+    }
+{{- end}}
+}
+`))
+
+type view struct {
+	*Spec
+	SpecPath string
+	Mintable bool
+	Burnable bool
+	Pausable bool
+	Capped   bool
+	Roles    bool
+	Ownable  bool
+	Grants   []grant
+}
+
+// Guard returns the function modifier restricting an admin function.
+func (v view) Guard(role string) string {
+	if v.Roles {
+		return "onlyRole(" + role + ")"
+	}
+
+	return "onlyOwner"
+}
+
+// Imports lists the OpenZeppelin sources the contract needs.
+func (v view) Imports() []string {
+	imports := []string{"token/ERC20/ERC20.sol"}
+	if v.Burnable {
+		imports = append(imports, "token/ERC20/extensions/ERC20Burnable.sol")
+	}
+	if v.Pausable {
+		imports = append(imports, "token/ERC20/extensions/ERC20Pausable.sol")
+	}
+	if v.Capped {
+		imports = append(imports, "token/ERC20/extensions/ERC20Capped.sol")
+	}
+	if v.Roles {
+		imports = append(imports, "access/AccessControl.sol")
+	}
+	if v.Ownable {
+		imports = append(imports, "access/Ownable.sol")
+	}
+
+	return imports
+}
+
+// Bases is the inheritance list of the contract.
+func (v view) Bases() string {
+	bases := []string{"ERC20"}
+	if v.Burnable {
+		bases = append(bases, "ERC20Burnable")
+	}
+	if v.Pausable {
+		bases = append(bases, "ERC20Pausable")
+	}
+	if v.Capped {
+		bases = append(bases, "ERC20Capped")
+	}
+	if v.Roles {
+		bases = append(bases, "AccessControl")
+	}
+	if v.Ownable {
+		bases = append(bases, "Ownable")
+	}
+
+	return strings.Join(bases, ", ")
+}
+
+// UpdateOverrides lists the bases whose _update must be overridden together.
+func (v view) UpdateOverrides() string {
+	if !v.Pausable && !v.Capped {
+		return ""
+	}
+
+	bases := []string{"ERC20"}
+	if v.Pausable {
+		bases = append(bases, "ERC20Pausable")
+	}
+	if v.Capped {
+		bases = append(bases, "ERC20Capped")
+	}
+
+	return strings.Join(bases, ", ")
+}
+
+// Render returns the Solidity source of the token. specPath is recorded in the
+// generated header.
+func Render(spec *Spec, specPath string) ([]byte, error) {
+	if err := spec.Validate(); err != nil {
+		return nil, err
+	}
+
+	v := view{
+		Spec:     spec,
+		SpecPath: specPath,
+		Mintable: spec.Has(Mintable),
+		Burnable: spec.Has(Burnable),
+		Pausable: spec.Has(Pausable),
+		Capped:   spec.Has(Capped),
+		Roles:    spec.Access == AccessRoles,
+		Grants:   spec.grants(),
+	}
+
+	// An owner is only needed when there is something to administer
+	v.Ownable = !v.Roles && (v.Mintable || v.Pausable)
+
+	var b bytes.Buffer
+	if err := contractTemplate.Execute(&b, v); err != nil {
+		return nil, err
+	}
+
+	return b.Bytes(), nil
+}
@@ -0,0 +1,208 @@
+// Package tokengen renders ERC20 token contracts from a spec using OpenZeppelin 5
+// building blocks, so token variants do not have to be forked by hand.
+package tokengen
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Features that can be enabled in a spec.
+const (
+	Mintable = "mintable"
+	Burnable = "burnable"
+	Pausable = "pausable"
+	Capped   = "capped"
+)
+
+// Access control modes.
+const (
+	AccessOwnable = "ownable"
+	AccessRoles   = "roles"
+)
+
+// Roles that can be granted in a spec, keyed by their spec name.
+var Roles = map[string]string{
+	"admin":  "DEFAULT_ADMIN_ROLE",
+	"minter": "MINTER_ROLE",
+	"pauser": "PAUSER_ROLE",
+}
+
+// roleFeature is the feature a role administers; the role only exists in the
+// contract when the feature is enabled.
+var roleFeature = map[string]string{
+	"minter": Mintable,
+	"pauser": Pausable,
+}
+
+// DefaultDecimals are the decimals of a token whose spec does not set them, as
+// for OpenZeppelin's ERC20.
+const DefaultDecimals = 18
+
+// Spec describes a token variant.
+type Spec struct {
+	Contract string              `json:"contract"` // Solidity contract name, e.g. MyAdminToken
+	Name     string              `json:"name"`
+	Symbol   string              `json:"symbol"`
+	Decimals *uint8              `json:"decimals,omitempty"` // DefaultDecimals when omitted
+	Features []string            `json:"features"`
+	Cap      string              `json:"cap,omitempty"`    // maximum supply in token units, for capped tokens
+	Access   string              `json:"access,omitempty"` // ownable (default) or roles
+	Roles    map[string][]string `json:"roles,omitempty"`  // extra holders per role, besides the deployer
+}
+
+// LoadSpec reads and validates a JSON spec.
+func LoadSpec(path string) (*Spec, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var spec Spec
+	if err := json.Unmarshal(b, &spec); err != nil {
+		return nil, err
+	}
+
+	if err := spec.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	return &spec, nil
+}
+
+var identifier = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// Validate checks the spec for values that would not render or compile.
+func (s *Spec) Validate() error {
+	if !identifier.MatchString(s.Contract) {
+		return fmt.Errorf("invalid contract name %q", s.Contract)
+	}
+
+	if s.Name == "" || s.Symbol == "" || strings.ContainsAny(s.Name+s.Symbol, "\"\\\n") {
+		return fmt.Errorf("invalid name %q or symbol %q", s.Name, s.Symbol)
+	}
+
+	for _, f := range s.Features {
+		switch f {
+		case Mintable, Burnable, Pausable, Capped:
+		default:
+			return fmt.Errorf("unknown feature %q", f)
+		}
+	}
+
+	if s.Has(Capped) {
+		cap, ok := new(big.Int).SetString(s.Cap, 10)
+		if !ok || cap.Sign() <= 0 {
+			return fmt.Errorf("capped token needs a positive integer cap, got %q", s.Cap)
+		}
+	} else if s.Cap != "" {
+		return fmt.Errorf("cap set without the %q feature", Capped)
+	}
+
+	switch s.Access {
+	case "", AccessOwnable:
+		if len(s.Roles) > 0 {
+			return fmt.Errorf("roles require access %q", AccessRoles)
+		}
+	case AccessRoles:
+	default:
+		return fmt.Errorf("unknown access %q", s.Access)
+	}
+
+	for role, holders := range s.Roles {
+		if _, ok := Roles[role]; !ok {
+			return fmt.Errorf("unknown role %q", role)
+		}
+
+		if f, ok := roleFeature[role]; ok && !s.Has(f) {
+			return fmt.Errorf("role %q requires the %q feature", role, f)
+		}
+
+		for _, h := range holders {
+			if !common.IsHexAddress(h) {
+				return fmt.Errorf("invalid %s address %q", role, h)
+			}
+		}
+	}
+
+	return nil
+}
+
+// Has reports whether a feature is enabled.
+func (s *Spec) Has(feature string) bool {
+	for _, f := range s.Features {
+		if f == feature {
+			return true
+		}
+	}
+
+	return false
+}
+
+// TokenDecimals returns the decimals of the token, DefaultDecimals unless the
+// spec sets them.
+func (s *Spec) TokenDecimals() uint8 {
+	if s.Decimals == nil {
+		return DefaultDecimals
+	}
+
+	return *s.Decimals
+}
+
+// BindingType is the Go type of the binding: the contract name without its
+// "My" prefix, as Token is for MyToken.
+func (s *Spec) BindingType() string {
+	if t := strings.TrimPrefix(s.Contract, "My"); t != "" && t[0] >= 'A' && t[0] <= 'Z' {
+		return t
+	}
+
+	return s.Contract
+}
+
+// BindingFile is the file name of the binding, e.g. admin_token.go.
+func (s *Spec) BindingFile() string {
+	var b strings.Builder
+	for i, r := range s.BindingType() {
+		if r >= 'A' && r <= 'Z' {
+			if i > 0 {
+				b.WriteByte('_')
+			}
+			r += 'a' - 'A'
+		}
+		b.WriteRune(r)
+	}
+
+	return b.String() + ".go"
+}
+
+// grants lists the extra role grants in a stable order.
+func (s *Spec) grants() []grant {
+	var grants []grant
+
+	for role, holders := range s.Roles {
+		for _, h := range holders {
+			grants = append(grants, grant{Role: Roles[role], Account: common.HexToAddress(h).Hex()})
+		}
+	}
+
+	sort.Slice(grants, func(i, j int) bool {
+		if grants[i].Role != grants[j].Role {
+			return grants[i].Role < grants[j].Role
+		}
+		return grants[i].Account < grants[j].Account
+	})
+
+	return grants
+}
+
+type grant struct {
+	Role    string
+	Account string
+}
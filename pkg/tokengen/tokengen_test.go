@@ -0,0 +1,196 @@
+package tokengen_test
+
+import (
+	"go-ethereum-example/pkg/tokengen"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func decimals(d uint8) *uint8 {
+	return &d
+}
+
+func TestValidate(t *testing.T) {
+	minter := map[string][]string{"minter": {"0x70997970C51812dc3A010C7d01b50e0d17dc79C8"}}
+
+	tests := []struct {
+		name string
+		spec tokengen.Spec
+		err  string // substring of the error, empty when valid
+	}{
+		{name: "plain", spec: tokengen.Spec{Contract: "MyToken", Name: "MyToken", Symbol: "MTK"}},
+		{name: "zero decimals", spec: tokengen.Spec{Contract: "MyToken", Name: "MyToken", Symbol: "MTK", Decimals: decimals(0)}},
+		{name: "every feature", spec: tokengen.Spec{Contract: "My_Token2", Name: "MyToken", Symbol: "MTK", Features: []string{"mintable", "burnable", "pausable", "capped"}, Cap: "1000", Access: "roles", Roles: minter}},
+		{name: "contract name", spec: tokengen.Spec{Contract: "2Token", Name: "MyToken", Symbol: "MTK"}, err: "invalid contract name"},
+		{name: "no name", spec: tokengen.Spec{Contract: "MyToken", Symbol: "MTK"}, err: "invalid name"},
+		{name: "quote in symbol", spec: tokengen.Spec{Contract: "MyToken", Name: "MyToken", Symbol: `M"TK`}, err: "invalid name"},
+		{name: "unknown feature", spec: tokengen.Spec{Contract: "MyToken", Name: "MyToken", Symbol: "MTK", Features: []string{"flashmint"}}, err: `unknown feature "flashmint"`},
+		{name: "capped without cap", spec: tokengen.Spec{Contract: "MyToken", Name: "MyToken", Symbol: "MTK", Features: []string{"capped"}}, err: "positive integer cap"},
+		{name: "decimal cap", spec: tokengen.Spec{Contract: "MyToken", Name: "MyToken", Symbol: "MTK", Features: []string{"capped"}, Cap: "1.5"}, err: "positive integer cap"},
+		{name: "cap without capped", spec: tokengen.Spec{Contract: "MyToken", Name: "MyToken", Symbol: "MTK", Cap: "1000"}, err: "cap set without"},
+		{name: "unknown access", spec: tokengen.Spec{Contract: "MyToken", Name: "MyToken", Symbol: "MTK", Access: "multisig"}, err: "unknown access"},
+		{name: "roles when ownable", spec: tokengen.Spec{Contract: "MyToken", Name: "MyToken", Symbol: "MTK", Features: []string{"mintable"}, Roles: minter}, err: "roles require access"},
+		{name: "role without feature", spec: tokengen.Spec{Contract: "MyToken", Name: "MyToken", Symbol: "MTK", Access: "roles", Roles: minter}, err: `requires the "mintable" feature`},
+		{name: "unknown role", spec: tokengen.Spec{Contract: "MyToken", Name: "MyToken", Symbol: "MTK", Access: "roles", Roles: map[string][]string{"burner": nil}}, err: `unknown role "burner"`},
+		{name: "role holder", spec: tokengen.Spec{Contract: "MyToken", Name: "MyToken", Symbol: "MTK", Features: []string{"mintable"}, Access: "roles", Roles: map[string][]string{"minter": {"0x1234"}}}, err: "invalid minter address"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.spec.Validate()
+
+			switch {
+			case tt.err == "" && err != nil:
+				t.Errorf("error %v", err)
+			case tt.err != "" && (err == nil || !strings.Contains(err.Error(), tt.err)):
+				t.Errorf("error %v, want %q", err, tt.err)
+			}
+		})
+	}
+}
+
+func TestRender(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    tokengen.Spec
+		want    []string
+		notWant []string
+	}{
+		{
+			name:    "plain",
+			spec:    tokengen.Spec{Contract: "MyToken", Name: "MyToken", Symbol: "MTK"},
+			want:    []string{"contract MyToken is ERC20 {", `ERC20("MyToken", "MTK")`},
+			notWant: []string{"decimals()", "Ownable", "_update"},
+		},
+		{
+			name:    "decimals omitted",
+			spec:    tokengen.Spec{Contract: "MyToken", Name: "MyToken", Symbol: "MTK", Features: []string{"capped"}, Cap: "1000"},
+			want:    []string{"ERC20Capped(1000 * 10 ** 18)", "override(ERC20, ERC20Capped)"},
+			notWant: []string{"decimals()"},
+		},
+		{
+			name: "six decimals",
+			spec: tokengen.Spec{Contract: "MyToken", Name: "MyToken", Symbol: "MTK", Decimals: decimals(6), Features: []string{"capped"}, Cap: "1000"},
+			want: []string{"ERC20Capped(1000 * 10 ** 6)", "function decimals() public pure override returns (uint8) {\n        return 6;"},
+		},
+		{
+			name: "zero decimals",
+			spec: tokengen.Spec{Contract: "MyToken", Name: "MyToken", Symbol: "MTK", Decimals: decimals(0)},
+			want: []string{"return 0;"},
+		},
+		{
+			name:    "ownable",
+			spec:    tokengen.Spec{Contract: "MyToken", Name: "MyToken", Symbol: "MTK", Features: []string{"mintable", "pausable"}},
+			want:    []string{"is ERC20, ERC20Pausable, Ownable", "Ownable(msg.sender)", "function mint(address to, uint256 amount) public onlyOwner", "function pause() public onlyOwner"},
+			notWant: []string{"AccessControl", "MINTER_ROLE"},
+		},
+		{
+			name:    "burnable only",
+			spec:    tokengen.Spec{Contract: "MyToken", Name: "MyToken", Symbol: "MTK", Features: []string{"burnable"}},
+			want:    []string{"is ERC20, ERC20Burnable {", "extensions/ERC20Burnable.sol"},
+			notWant: []string{"Ownable", "_update"},
+		},
+		{
+			name: "roles",
+			spec: tokengen.Spec{
+				Contract: "MyToken", Name: "MyToken", Symbol: "MTK",
+				Features: []string{"mintable"},
+				Access:   "roles",
+				Roles:    map[string][]string{"minter": {"0x70997970c51812dc3a010c7d01b50e0d17dc79c8"}, "admin": {"0x3C44CdDdB6a900fa2b585dd299e03d12FA4293BC"}},
+			},
+			want: []string{
+				"is ERC20, AccessControl",
+				`bytes32 public constant MINTER_ROLE = keccak256("MINTER_ROLE");`,
+				"_grantRole(DEFAULT_ADMIN_ROLE, 0x3C44CdDdB6a900fa2b585dd299e03d12FA4293BC);\n        _grantRole(MINTER_ROLE, 0x70997970C51812dc3A010C7d01b50e0d17dc79C8);",
+				"public onlyRole(MINTER_ROLE)",
+			},
+			notWant: []string{"PAUSER_ROLE", "Ownable"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			source, err := tokengen.Render(&tt.spec, "spec.json")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			for _, want := range tt.want {
+				if !strings.Contains(string(source), want) {
+					t.Errorf("source does not contain %q:\n%s", want, source)
+				}
+			}
+
+			for _, notWant := range tt.notWant {
+				if strings.Contains(string(source), notWant) {
+					t.Errorf("source contains %q:\n%s", notWant, source)
+				}
+			}
+		})
+	}
+
+	if _, err := tokengen.Render(&tokengen.Spec{Contract: "MyToken"}, "spec.json"); err == nil {
+		t.Error("rendered an invalid spec")
+	}
+}
+
+// TestRenderAdminToken checks that the committed MyAdminToken is what its spec
+// renders to.
+func TestRenderAdminToken(t *testing.T) {
+	spec, err := tokengen.LoadSpec("../../contracts/specs/MyAdminToken.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	source, err := tokengen.Render(spec, "contracts/specs/MyAdminToken.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want, err := os.ReadFile("../../contracts/MyAdminToken.sol")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(source) != string(want) {
+		t.Errorf("rendered:\n%s\nwant:\n%s", source, want)
+	}
+}
+
+func TestLoadSpecDecimals(t *testing.T) {
+	tests := []struct {
+		json string
+		want uint8
+	}{
+		{json: `{"contract":"MyToken","name":"MyToken","symbol":"MTK"}`, want: 18},
+		{json: `{"contract":"MyToken","name":"MyToken","symbol":"MTK","decimals":6}`, want: 6},
+		{json: `{"contract":"MyToken","name":"MyToken","symbol":"MTK","decimals":0}`, want: 0},
+	}
+
+	for _, tt := range tests {
+		path := filepath.Join(t.TempDir(), "spec.json")
+		if err := os.WriteFile(path, []byte(tt.json), 0o644); err != nil {
+			t.Fatal(err)
+		}
+
+		spec, err := tokengen.LoadSpec(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if got := spec.TokenDecimals(); got != tt.want {
+			t.Errorf("%s: decimals %d, want %d", tt.json, got, tt.want)
+		}
+	}
+
+	path := filepath.Join(t.TempDir(), "spec.json")
+	if err := os.WriteFile(path, []byte(`{"contract":"MyToken","name":"MyToken","symbol":"MTK","decimals":256}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := tokengen.LoadSpec(path); err == nil {
+		t.Error("loaded a spec with 256 decimals")
+	}
+}
@@ -0,0 +1,178 @@
+// Package trace fetches the call tree of a transaction with the callTracer of
+// debug_traceTransaction, which geth and anvil support, and renders it with
+// calls, events and reverts decoded by a decoder.Registry, so a revert points
+// at the internal call that failed:
+//
+//	root, err := trace.Transaction(ctx, client.Client(), hash)
+//	handleError(err)
+//
+//	trace.Render(os.Stdout, registry, root)
+package trace
+
+import (
+	"context"
+	"fmt"
+	"go-ethereum-example/pkg/decoder"
+	"io"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// Caller makes JSON-RPC calls, such as rpc.Client.
+type Caller interface {
+	CallContext(ctx context.Context, result any, method string, args ...any) error
+}
+
+// Frame is a call of the callTracer output, with the calls it made.
+type Frame struct {
+	Type    string          `json:"type"`
+	From    common.Address  `json:"from"`
+	To      *common.Address `json:"to,omitempty"`
+	Value   *hexutil.Big    `json:"value,omitempty"`
+	Gas     hexutil.Uint64  `json:"gas"`
+	GasUsed hexutil.Uint64  `json:"gasUsed"`
+	Input   hexutil.Bytes   `json:"input"`
+	Output  hexutil.Bytes   `json:"output,omitempty"`
+
+	// Error is set when the call failed, with the revert data in Output.
+	Error        string `json:"error,omitempty"`
+	RevertReason string `json:"revertReason,omitempty"`
+
+	Calls []Frame `json:"calls,omitempty"`
+
+	// Logs are the events the call emitted itself, none if it failed.
+	Logs []Log `json:"logs,omitempty"`
+}
+
+// Log is an event emitted by a call.
+type Log struct {
+	Address common.Address `json:"address"`
+	Topics  []common.Hash  `json:"topics"`
+	Data    hexutil.Bytes  `json:"data"`
+
+	// Position is the number of calls of the frame made before the event.
+	Position hexutil.Uint `json:"position"`
+}
+
+// Transaction returns the call tree of a mined transaction, events included.
+func Transaction(ctx context.Context, client Caller, hash common.Hash) (*Frame, error) {
+	config := map[string]any{
+		"tracer":       "callTracer",
+		"tracerConfig": map[string]any{"withLog": true},
+	}
+
+	var root Frame
+	if err := client.CallContext(ctx, &root, "debug_traceTransaction", hash, config); err != nil {
+		return nil, fmt.Errorf("trace %s: %w", hash.Hex(), err)
+	}
+
+	return &root, nil
+}
+
+// Failed returns the deepest failed call that made the frame fail, the frame
+// itself if none of its calls did, or nil if it succeeded.
+func (f *Frame) Failed() *Frame {
+	if f.Error == "" {
+		return nil
+	}
+
+	for i := range f.Calls {
+		// A failed call the frame went on after, as with try/catch, is not
+		// the cause; the last one is
+		call := &f.Calls[len(f.Calls)-1-i]
+		if call.Error != "" {
+			return call.Failed()
+		}
+	}
+
+	return f
+}
+
+// Render writes the call tree, one call, event or revert per line.
+func Render(w io.Writer, registry *decoder.Registry, root *Frame) {
+	fmt.Fprintln(w, describeFrame(registry, root))
+	renderChildren(w, registry, root, "")
+}
+
+func renderChildren(w io.Writer, registry *decoder.Registry, f *Frame, indent string) {
+	type child struct {
+		line  string
+		frame *Frame
+	}
+
+	// Interleave the events with the calls in the order they happened
+	var children []child
+	logs := f.Logs
+	for i := range f.Calls {
+		for len(logs) > 0 && int(logs[0].Position) <= i {
+			children = append(children, child{line: describeLog(registry, &logs[0])})
+			logs = logs[1:]
+		}
+
+		children = append(children, child{line: describeFrame(registry, &f.Calls[i]), frame: &f.Calls[i]})
+	}
+
+	for i := range logs {
+		children = append(children, child{line: describeLog(registry, &logs[i])})
+	}
+
+	if f.Error != "" {
+		children = append(children, child{line: describeRevert(registry, f)})
+	}
+
+	for i, c := range children {
+		branch, next := "├─ ", "│  "
+		if i == len(children)-1 {
+			branch, next = "└─ ", "   "
+		}
+
+		fmt.Fprintln(w, indent+branch+c.line)
+
+		if c.frame != nil {
+			renderChildren(w, registry, c.frame, indent+next)
+		}
+	}
+}
+
+// describeFrame formats a call as "TYPE to input", with its value and gas.
+func describeFrame(registry *decoder.Registry, f *Frame) string {
+	var to string
+	if f.To != nil {
+		to = f.To.Hex()
+	}
+
+	var input string
+	switch {
+	case strings.HasPrefix(f.Type, "CREATE"):
+		input = fmt.Sprintf("%d bytes of creation code", len(f.Input))
+	case len(f.Input) == 0:
+		input = "no input"
+	default:
+		input = decoder.Describe(registry.DecodeCall(f.Input))
+	}
+
+	line := fmt.Sprintf("%s %s %s", f.Type, to, input)
+
+	if f.Value != nil && f.Value.ToInt().Sign() > 0 {
+		line += fmt.Sprintf(", value %s wei", f.Value.ToInt())
+	}
+
+	return line + fmt.Sprintf(", gas used %d", f.GasUsed)
+}
+
+func describeLog(registry *decoder.Registry, log *Log) string {
+	decoded, err := registry.DecodeLog(&types.Log{Address: log.Address, Topics: log.Topics, Data: log.Data})
+	return fmt.Sprintf("emit %s from %s", decoder.Describe(decoded, err), log.Address.Hex())
+}
+
+// describeRevert formats why a call failed, with its revert data decoded.
+func describeRevert(registry *decoder.Registry, f *Frame) string {
+	if len(f.Output) == 0 {
+		return "FAILED: " + f.Error
+	}
+
+	return fmt.Sprintf("FAILED: %s: %s", f.Error, decoder.Describe(registry.DecodeError(f.Output)))
+}
@@ -0,0 +1,207 @@
+package trace_test
+
+import (
+	"context"
+	token "go-ethereum-example/gen"
+	"go-ethereum-example/pkg/decoder"
+	"go-ethereum-example/pkg/devnode"
+	"go-ethereum-example/pkg/multicall"
+	"go-ethereum-example/pkg/trace"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+func registry(t *testing.T) *decoder.Registry {
+	t.Helper()
+
+	r, err := decoder.Load("../../build")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return r
+}
+
+func render(t *testing.T, root *trace.Frame) string {
+	t.Helper()
+
+	var out strings.Builder
+	trace.Render(&out, registry(t), root)
+
+	return out.String()
+}
+
+func TestTransaction(t *testing.T) {
+	cfg := devnode.DefaultConfig()
+	cfg.Port = 0
+	cfg.DeployToken = true
+
+	node, err := devnode.Start(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { node.Close() })
+
+	client, err := ethclient.Dial(node.HTTPEndpoint())
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(client.Close)
+
+	owner, alice := node.Accounts[0], node.Accounts[1]
+
+	opts, err := bind.NewKeyedTransactorWithChainID(owner.Key, cfg.ChainID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mine := func(tx *types.Transaction, err error) *trace.Frame {
+		t.Helper()
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		if _, err := bind.WaitMined(ctx, client, tx); err != nil {
+			t.Fatal(err)
+		}
+
+		root, err := trace.Transaction(ctx, client.Client(), tx.Hash())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return root
+	}
+
+	t.Run("transfer", func(t *testing.T) {
+		tokenInstance, err := token.NewToken(node.Token, client)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		root := mine(tokenInstance.Transfer(opts, alice.Address, big.NewInt(1000)))
+
+		if root.Error != "" || len(root.Calls) != 0 || len(root.Logs) != 1 || root.Failed() != nil {
+			t.Fatalf("trace %+v", root)
+		}
+
+		out := render(t, root)
+
+		want := "└─ emit Transfer(from: " + owner.Address.Hex() + ", to: " + alice.Address.Hex() + ", value: 1000)"
+		if !strings.Contains(out, want) {
+			t.Errorf("render:\n%s\nwant %s", out, want)
+		}
+	})
+
+	t.Run("nested revert", func(t *testing.T) {
+		multicall3, err := token.NewMulticall3(multicall.Address, client)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		parsed, err := token.TokenMetaData.GetAbi()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// Multicall3 holds no tokens, so both transfers fail; the first one
+		// is allowed to
+		transfer, err := parsed.Pack("transfer", alice.Address, big.NewInt(1000))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// A gas limit skips the estimation, which would fail
+		failing := *opts
+		failing.GasLimit = 200_000
+
+		root := mine(multicall3.Aggregate3(&failing, []token.Multicall3Call3{
+			{Target: node.Token, AllowFailure: true, CallData: transfer},
+			{Target: node.Token, CallData: transfer},
+		}))
+
+		if root.Error == "" || len(root.Calls) != 2 {
+			t.Fatalf("trace %+v", root)
+		}
+
+		if failed := root.Failed(); failed != &root.Calls[1] {
+			t.Errorf("failed in %+v, want the second transfer", failed)
+		}
+
+		out := render(t, root)
+
+		for _, want := range []string{
+			"├─ CALL " + node.Token.Hex() + " transfer(to: " + alice.Address.Hex() + ", value: 1000)",
+			"│  └─ FAILED: execution reverted: ERC20InsufficientBalance(sender: " + multicall.Address.Hex() + ", balance: 0, needed: 1000)",
+			`└─ FAILED: execution reverted: Error(reason: "Multicall3: call failed")`,
+		} {
+			if !strings.Contains(out, want) {
+				t.Errorf("render:\n%s\nwant %s", out, want)
+			}
+		}
+	})
+}
+
+func TestRender(t *testing.T) {
+	from, to := common.HexToAddress("0x01"), common.HexToAddress("0x02")
+
+	transfer := func(position uint) trace.Log {
+		return trace.Log{
+			Address:  to,
+			Topics:   []common.Hash{transferTopic, common.BytesToHash(from.Bytes()), common.BytesToHash(to.Bytes())},
+			Data:     common.LeftPadBytes([]byte{byte(position + 1)}, 32),
+			Position: hexutil.Uint(position),
+		}
+	}
+
+	// Events before, between and after two calls, the second of which fails
+	root := &trace.Frame{
+		Type:  "CALL",
+		From:  from,
+		To:    &to,
+		Error: "execution reverted",
+		Calls: []trace.Frame{
+			{Type: "STATICCALL", From: to, To: &from, Input: hexutil.MustDecode("0x70a08231" + strings.Repeat("0", 64))},
+			{Type: "CALL", From: to, To: &from, Input: hexutil.MustDecode("0xdeadbeef"), Error: "out of gas"},
+		},
+		Logs: []trace.Log{transfer(0), transfer(1), transfer(2)},
+	}
+
+	var lines []string
+	for _, line := range strings.Split(strings.TrimSpace(render(t, root)), "\n") {
+		lines = append(lines, strings.SplitN(line, "(", 2)[0])
+	}
+
+	want := []string{
+		"CALL " + to.Hex() + " no input, gas used 0",
+		"├─ emit Transfer",
+		"├─ STATICCALL " + from.Hex() + " balanceOf",
+		"├─ emit Transfer",
+		"├─ CALL " + from.Hex() + " UNKNOWN ",
+		"│  └─ FAILED: out of gas",
+		"├─ emit Transfer",
+		"└─ FAILED: execution reverted",
+	}
+
+	if strings.Join(lines, "\n") != strings.Join(want, "\n") {
+		t.Errorf("render:\n%s\nwant:\n%s", strings.Join(lines, "\n"), strings.Join(want, "\n"))
+	}
+
+	if failed := root.Failed(); failed != &root.Calls[1] {
+		t.Errorf("failed in %+v, want the second call", failed)
+	}
+}
+
+var transferTopic = common.HexToHash("0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef")
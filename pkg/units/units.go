@@ -0,0 +1,58 @@
+// Package units converts between decimal token amounts and base units.
+package units
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// Parse converts a decimal amount such as "1.5" into base units of a token with
+// the given number of decimals.
+func Parse(s string, decimals uint8) (*big.Int, error) {
+	s = strings.TrimSpace(s)
+	whole, frac, hasFrac := strings.Cut(s, ".")
+
+	if whole == "" && (!hasFrac || frac == "") {
+		return nil, fmt.Errorf("invalid amount %q", s)
+	}
+
+	if len(frac) > int(decimals) {
+		return nil, fmt.Errorf("amount %q has more than %d decimals", s, decimals)
+	}
+
+	digits := whole + frac + strings.Repeat("0", int(decimals)-len(frac))
+	for _, c := range digits {
+		if c < '0' || c > '9' {
+			return nil, fmt.Errorf("invalid amount %q", s)
+		}
+	}
+
+	amount, _ := new(big.Int).SetString(digits, 10)
+
+	return amount, nil
+}
+
+// Format renders base units as a decimal amount, trimming trailing zeros.
+func Format(amount *big.Int, decimals uint8) string {
+	if amount == nil {
+		return "0"
+	}
+
+	sign := ""
+	if amount.Sign() < 0 {
+		sign = "-"
+	}
+
+	digits := new(big.Int).Abs(amount).String()
+	if len(digits) <= int(decimals) {
+		digits = strings.Repeat("0", int(decimals)-len(digits)+1) + digits
+	}
+
+	whole, frac := digits[:len(digits)-int(decimals)], strings.TrimRight(digits[len(digits)-int(decimals):], "0")
+	if frac == "" {
+		return sign + whole
+	}
+
+	return sign + whole + "." + frac
+}
@@ -0,0 +1,81 @@
+package watcher
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// Health serves the liveness and readiness checks of a watcher.
+type Health struct {
+	Watcher *Watcher
+
+	// MaxLag is the number of blocks behind head, confirmations included,
+	// beyond which the watcher is not ready.
+	MaxLag uint64
+
+	// Grace is how long the watcher may stay disconnected or lag without
+	// progress before it is reported dead and restarted.
+	Grace time.Duration
+}
+
+// Healthz fails when the watcher has made no progress for Grace while
+// disconnected or lagging, which a restart may fix. A watcher that has made no
+// progress yet gets Grace from its start.
+func (h *Health) Healthz(w http.ResponseWriter, r *http.Request) {
+	status := h.Watcher.Status()
+
+	since := status.Progress
+	if since.IsZero() {
+		since = status.Started
+	}
+
+	stalled := !since.IsZero() && time.Since(since) > h.Grace
+
+	switch {
+	case stalled && !status.Connected && status.Progress.IsZero():
+		h.write(w, status, fmt.Errorf("not connected since the start at %s", since.Format(time.RFC3339)))
+	case stalled && !status.Connected:
+		h.write(w, status, fmt.Errorf("disconnected since %s", since.Format(time.RFC3339)))
+	case stalled && status.Lag() > h.MaxLag:
+		h.write(w, status, fmt.Errorf("%d blocks behind, no progress since %s", status.Lag(), since.Format(time.RFC3339)))
+	default:
+		h.write(w, status, nil)
+	}
+}
+
+// Readyz fails while the watcher is disconnected, draining, or more than
+// MaxLag blocks behind head.
+func (h *Health) Readyz(w http.ResponseWriter, r *http.Request) {
+	status := h.Watcher.Status()
+
+	switch {
+	case status.Draining:
+		h.write(w, status, fmt.Errorf("draining"))
+	case !status.Connected:
+		h.write(w, status, fmt.Errorf("not connected"))
+	case status.Lag() > h.MaxLag:
+		h.write(w, status, fmt.Errorf("%d blocks behind, more than %d", status.Lag(), h.MaxLag))
+	default:
+		h.write(w, status, nil)
+	}
+}
+
+func (h *Health) write(w http.ResponseWriter, status Status, err error) {
+	body := struct {
+		Status
+		Lag   uint64 `json:"lag"`
+		Error string `json:"error,omitempty"`
+	}{Status: status, Lag: status.Lag()}
+
+	code := http.StatusOK
+	if err != nil {
+		code = http.StatusServiceUnavailable
+		body.Error = err.Error()
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(body)
+}
@@ -0,0 +1,93 @@
+package watcher
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// DefaultReloadInterval is how often WatchManifest reads the manifest.
+const DefaultReloadInterval = 5 * time.Second
+
+// Manifest lists the tokens to watch, as JSON:
+//
+//	{"tokens": ["0x5FbDB2315678afecb367f032d93F642f64180aa3"]}
+type Manifest struct {
+	Tokens []common.Address `json:"tokens"`
+}
+
+// LoadManifest reads a manifest and drops duplicate tokens. A manifest
+// without tokens is an error.
+func LoadManifest(path string) (*Manifest, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var m Manifest
+	if err := json.Unmarshal(b, &m); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	var tokens []common.Address
+	for _, token := range m.Tokens {
+		if !slices.Contains(tokens, token) {
+			tokens = append(tokens, token)
+		}
+	}
+
+	if len(tokens) == 0 {
+		return nil, fmt.Errorf("%s: no tokens", path)
+	}
+
+	m.Tokens = tokens
+
+	return &m, nil
+}
+
+// WatchManifest reads the manifest at path every interval until ctx is
+// canceled, and passes its tokens to SetTokens when they change, so the list
+// can be edited without a restart. A manifest that fails to load is reported
+// and the current tokens are kept.
+func (w *Watcher) WatchManifest(ctx context.Context, path string, interval time.Duration) {
+	logf := w.Logf
+	if logf == nil {
+		logf = func(string, ...any) {}
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	var lastErr string
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		m, err := LoadManifest(path)
+		if err != nil {
+			// Once per error, not at every interval
+			if err.Error() != lastErr {
+				logf("Keeping the current tokens, cannot load manifest: %v", err)
+				lastErr = err.Error()
+			}
+
+			continue
+		}
+
+		lastErr = ""
+
+		if !slices.Equal(m.Tokens, w.tokens()) {
+			w.SetTokens(m.Tokens)
+			logf("Manifest changed, watching %s from the next block", describeTokens(m.Tokens))
+		}
+	}
+}
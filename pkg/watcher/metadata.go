@@ -0,0 +1,44 @@
+package watcher
+
+import (
+	"context"
+	"go-ethereum-example/pkg/erc20"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// metadata is the part of a token's metadata events are enriched with.
+type metadata struct {
+	symbol   string
+	decimals uint8
+	unknown  bool // not read
+}
+
+// tokenMetadata returns the symbol and decimals of a token, read once over
+// the watcher's connection and cached. A token whose metadata cannot be read
+// is delivered without, and read again at its next event.
+func (w *Watcher) tokenMetadata(ctx context.Context, client Client, address common.Address) (metadata, error) {
+	if m, ok := w.metadata[address]; ok {
+		return m, nil
+	}
+
+	backend, ok := client.(erc20.Backend)
+	if !ok {
+		return metadata{unknown: true}, nil
+	}
+
+	m, err := erc20.New(address, backend).Metadata(&bind.CallOpts{Context: ctx})
+	if err != nil {
+		if ctx.Err() != nil {
+			return metadata{}, ctx.Err()
+		}
+
+		w.Logf("Cannot read metadata of token %s: %v", address.Hex(), err)
+		return metadata{unknown: true}, nil
+	}
+
+	w.metadata[address] = metadata{symbol: m.Symbol, decimals: m.Decimals}
+
+	return w.metadata[address], nil
+}
@@ -0,0 +1,64 @@
+package watcher
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Metrics are the Prometheus metrics of a watcher, named watcher_*.
+type Metrics struct {
+	Events       *prometheus.CounterVec
+	DeadLettered prometheus.Counter
+	Head         prometheus.Gauge
+	LastBlock    prometheus.Gauge
+	Lag          prometheus.Gauge
+	Reconnects   prometheus.Counter
+	RPCErrors    prometheus.Counter
+}
+
+// NewMetrics creates the metrics and registers them with reg, unless it is
+// nil.
+func NewMetrics(reg prometheus.Registerer) *Metrics {
+	m := &Metrics{
+		Events: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "watcher",
+			Name:      "events_processed_total",
+			Help:      "Events delivered to the sink, by event name.",
+		}, []string{"event"}),
+		DeadLettered: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "watcher",
+			Name:      "events_dead_lettered_total",
+			Help:      "Events the sink failed to deliver and wrote to the dead-letter file.",
+		}),
+		Head: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: "watcher",
+			Name:      "head_block",
+			Help:      "Latest block number seen on the node.",
+		}),
+		LastBlock: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: "watcher",
+			Name:      "last_block_seen",
+			Help:      "Latest block number whose events were delivered.",
+		}),
+		Lag: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: "watcher",
+			Name:      "lag_blocks",
+			Help:      "Blocks between the head and the last block whose events were delivered.",
+		}),
+		Reconnects: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "watcher",
+			Name:      "reconnects_total",
+			Help:      "Connections to the node dialed again after one was dropped or failed.",
+		}),
+		RPCErrors: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "watcher",
+			Name:      "rpc_errors_total",
+			Help:      "Failed RPC requests and subscriptions.",
+		}),
+	}
+
+	if reg != nil {
+		reg.MustRegister(m.Events, m.DeadLettered, m.Head, m.LastBlock, m.Lag, m.Reconnects, m.RPCErrors)
+	}
+
+	return m
+}
@@ -0,0 +1,215 @@
+package watcher
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// poll follows the chain by polling every PollInterval, for nodes reached
+// over HTTP. Log filters installed with eth_newFilter return the logs of the
+// blocks mined since the previous poll. When the node drops them, or the
+// token list changes, the blocks since the last delivered one are fetched
+// with eth_getLogs and the filters are installed again. Nodes without filters
+// are polled with eth_getLogs.
+func (w *Watcher) poll(ctx, deliverCtx context.Context, client Client) error {
+	filters, _ := client.(LogFilters)
+
+	var ids []string
+	var installed uint64 // generation of the tokens of the filters
+	defer func() { uninstall(filters, ids) }()
+
+	// pending holds polled logs until their block is confirmed, rangeEnd is
+	// the last block fetched with eth_getLogs
+	var pending []types.Log
+	var rangeEnd uint64
+
+	started := false
+
+	ticker := time.NewTicker(w.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		queries, generation := w.current()
+		if ids != nil && generation != installed {
+			uninstall(filters, ids)
+			ids = nil
+		}
+
+		// Install the filters before reading head, so that they match every
+		// block after the range fetched with eth_getLogs
+		catchUp := ids == nil
+
+		if filters != nil && ids == nil {
+			newIDs, err := installFilters(ctx, filters, queries)
+			if err != nil {
+				w.failed(err)
+			}
+
+			switch {
+			case isMethodNotFound(err):
+				w.Logf("Node has no log filters, polling with eth_getLogs")
+				filters = nil
+			case err != nil:
+				return err
+			default:
+				ids, installed = newIDs, generation
+			}
+		}
+
+		head, err := client.BlockNumber(ctx)
+		if err != nil {
+			return w.failed(err)
+		}
+
+		if !started {
+			w.start(head, "by polling")
+			started = true
+		}
+
+		if catchUp {
+			if err := w.process(ctx, deliverCtx, client, head); err != nil {
+				return err
+			}
+
+			// The filters miss the blocks still waiting for confirmations
+			pending, rangeEnd = nil, w.next-1
+
+			if w.next <= head {
+				pending, err = filterLogs(ctx, client, queries, w.next, head)
+				if err != nil {
+					return w.failed(err)
+				}
+			}
+		} else {
+			changes, err := filterChanges(ctx, filters, ids)
+			if err != nil {
+				w.failed(err)
+			}
+
+			if isFilterNotFound(err) {
+				w.Logf("Log filter expired, catching up with eth_getLogs from block %d", w.next)
+
+				uninstall(filters, ids)
+				ids = nil
+
+				continue
+			}
+
+			if err != nil {
+				return err
+			}
+
+			pending, err = w.deliverPending(deliverCtx, client, append(pending, changes...), head, rangeEnd)
+			if err != nil {
+				return err
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// deliverPending delivers the polled logs of confirmed blocks in chain order,
+// and returns the logs still waiting for confirmations. Logs of blocks up to
+// rangeEnd were already fetched with eth_getLogs. Logs removed by a reorg are
+// delivered as removed if they were delivered, and dropped otherwise.
+func (w *Watcher) deliverPending(ctx context.Context, client Client, pending []types.Log, head, rangeEnd uint64) ([]types.Log, error) {
+	w.setHead(head)
+
+	var safe uint64
+	if head >= w.Confirmations {
+		safe = head - w.Confirmations
+	}
+
+	pending = sortLogs(pending)
+
+	removed := make(map[string]bool)
+	for _, log := range pending {
+		if log.Removed && log.BlockNumber >= w.next {
+			removed[logKey(log)] = true
+		}
+	}
+
+	var rest []types.Log
+	for _, log := range pending {
+		switch {
+		case removed[logKey(log)]:
+			// Never delivered
+		case log.BlockNumber <= rangeEnd && !log.Removed:
+			// Delivered from eth_getLogs
+		case log.BlockNumber > safe:
+			rest = append(rest, log)
+		default:
+			if err := w.deliver(ctx, client, log); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if safe >= w.next {
+		w.next = safe + 1
+		w.setLast(safe)
+	}
+
+	w.update(func(s *Status) { s.Progress = time.Now() })
+
+	return rest, nil
+}
+
+// logKey identifies a log by block hash and index, whether it was removed or
+// not.
+func logKey(log types.Log) string {
+	return fmt.Sprintf("%s:%d", log.BlockHash.Hex(), log.Index)
+}
+
+func installFilters(ctx context.Context, filters LogFilters, queries []ethereum.FilterQuery) ([]string, error) {
+	var ids []string
+	for _, q := range queries {
+		id, err := filters.NewFilter(ctx, q)
+		if err != nil {
+			uninstall(filters, ids)
+			return nil, err
+		}
+
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
+
+func filterChanges(ctx context.Context, filters LogFilters, ids []string) ([]types.Log, error) {
+	var logs []types.Log
+	for _, id := range ids {
+		changes, err := filters.FilterChanges(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+
+		logs = append(logs, changes...)
+	}
+
+	return logs, nil
+}
+
+// uninstall removes filters on a best-effort basis: nodes drop unused
+// filters anyway.
+func uninstall(filters LogFilters, ids []string) {
+	if filters == nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	for _, id := range ids {
+		filters.UninstallFilter(ctx, id)
+	}
+}
@@ -0,0 +1,176 @@
+package watcher_test
+
+import (
+	"context"
+	token "go-ethereum-example/gen"
+	"go-ethereum-example/pkg/devnode"
+	"go-ethereum-example/pkg/sink"
+	"go-ethereum-example/pkg/watcher"
+	"math/big"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+// expiringClient drops its log filters on the node every few polls, as nodes
+// do with filters that are not polled for a while.
+type expiringClient struct {
+	*watcher.RPCClient
+	polls *atomic.Int32
+}
+
+func (c expiringClient) FilterChanges(ctx context.Context, id string) ([]types.Log, error) {
+	if c.polls.Add(1)%3 == 0 {
+		c.RPCClient.UninstallFilter(ctx, id)
+	}
+
+	return c.RPCClient.FilterChanges(ctx, id)
+}
+
+// noFiltersClient is served by a node without eth_newFilter.
+type noFiltersClient struct {
+	*watcher.RPCClient
+}
+
+func (noFiltersClient) NewFilter(ctx context.Context, q ethereum.FilterQuery) (string, error) {
+	return "", methodNotFound{}
+}
+
+type methodNotFound struct{}
+
+func (methodNotFound) Error() string {
+	return "the method eth_newFilter does not exist/is not available"
+}
+
+func (methodNotFound) ErrorCode() int {
+	return -32601
+}
+
+func TestWatcherPolls(t *testing.T) {
+	cfg := devnode.DefaultConfig()
+	cfg.Port = 0
+	cfg.DeployToken = true
+
+	node, err := devnode.Start(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { node.Close() })
+
+	client, err := watcher.Dial(context.Background(), node.HTTPEndpoint())
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(client.Close)
+
+	tokenInstance, err := token.NewToken(node.Token, client)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	owner, alice := node.Accounts[0], node.Accounts[1]
+
+	tests := []struct {
+		name      string
+		wrap      func(c *watcher.RPCClient) watcher.Client
+		rpcErrors bool // expired or missing filters
+	}{
+		{
+			name: "filters",
+			wrap: func(c *watcher.RPCClient) watcher.Client { return c },
+		},
+		{
+			name: "expired filters",
+			wrap: func(c *watcher.RPCClient) watcher.Client {
+				return expiringClient{RPCClient: c, polls: new(atomic.Int32)}
+			},
+			rpcErrors: true,
+		},
+		{
+			name:      "no filters",
+			wrap:      func(c *watcher.RPCClient) watcher.Client { return noFiltersClient{RPCClient: c} },
+			rpcErrors: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			head, err := client.BlockNumber(context.Background())
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			out := &recorder{events: make(chan sink.Event, 100)}
+			metrics := watcher.NewMetrics(prometheus.NewRegistry())
+
+			w := &watcher.Watcher{
+				Dial: func(ctx context.Context) (watcher.Client, error) {
+					c, err := watcher.Dial(ctx, node.HTTPEndpoint())
+					if err != nil {
+						return nil, err
+					}
+
+					return tt.wrap(c), nil
+				},
+				Tokens:       []common.Address{node.Token},
+				Sink:         out,
+				FromBlock:    head + 1,
+				PollInterval: 20 * time.Millisecond,
+				Metrics:      metrics,
+				Logf:         t.Logf,
+			}
+
+			stop := start(t, w)
+
+			// Each transfer is mined across several polls, so filters expire
+			// between them
+			for i := int64(1); i <= 4; i++ {
+				opts, err := bind.NewKeyedTransactorWithChainID(owner.Key, cfg.ChainID)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				tx, err := tokenInstance.Transfer(opts, alice.Address, big.NewInt(i))
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				if _, err := bind.WaitMined(context.Background(), client, tx); err != nil {
+					t.Fatal(err)
+				}
+
+				if event := out.next(t); event.To != alice.Address || event.Value != big.NewInt(i).String() || event.TxHash != tx.Hash() {
+					t.Errorf("transfer %d: %+v", i, event)
+				}
+
+				time.Sleep(5 * w.PollInterval)
+			}
+
+			if err := stop(); err != nil {
+				t.Fatal(err)
+			}
+
+			select {
+			case event := <-out.events:
+				t.Errorf("duplicate event %+v", event)
+			default:
+			}
+
+			// Failed filter requests are retried on the same connection
+			if got := testutil.ToFloat64(metrics.Reconnects); got != 0 {
+				t.Errorf("reconnects %v, want 0", got)
+			}
+
+			if got := testutil.ToFloat64(metrics.RPCErrors); (got > 0) != tt.rpcErrors {
+				t.Errorf("RPC errors %v", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package watcher
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// LogFilters installs log filters on the node and polls their changes, with
+// eth_newFilter, eth_getFilterChanges and eth_uninstallFilter.
+type LogFilters interface {
+	NewFilter(ctx context.Context, q ethereum.FilterQuery) (string, error)
+	FilterChanges(ctx context.Context, id string) ([]types.Log, error)
+	UninstallFilter(ctx context.Context, id string) error
+}
+
+// RPCClient is an ethclient.Client that also implements LogFilters, which
+// ethclient leaves out.
+type RPCClient struct {
+	*ethclient.Client
+	rpc *rpc.Client
+}
+
+// Dial connects to an HTTP or WebSocket endpoint.
+func Dial(ctx context.Context, url string) (*RPCClient, error) {
+	c, err := rpc.DialContext(ctx, url)
+	if err != nil {
+		return nil, err
+	}
+
+	return &RPCClient{Client: ethclient.NewClient(c), rpc: c}, nil
+}
+
+// NewFilter installs a filter for the logs of blocks mined from now on. The
+// block range of q is ignored.
+func (c *RPCClient) NewFilter(ctx context.Context, q ethereum.FilterQuery) (string, error) {
+	arg := map[string]any{
+		"address": q.Addresses,
+		"topics":  q.Topics,
+	}
+
+	var id string
+	err := c.rpc.CallContext(ctx, &id, "eth_newFilter", arg)
+
+	return id, err
+}
+
+// FilterChanges returns the logs matched since the last poll, including logs
+// removed by reorgs.
+func (c *RPCClient) FilterChanges(ctx context.Context, id string) ([]types.Log, error) {
+	var logs []types.Log
+	err := c.rpc.CallContext(ctx, &logs, "eth_getFilterChanges", id)
+
+	return logs, err
+}
+
+// UninstallFilter removes a filter.
+func (c *RPCClient) UninstallFilter(ctx context.Context, id string) error {
+	var ok bool
+	return c.rpc.CallContext(ctx, &ok, "eth_uninstallFilter", id)
+}
+
+// isFilterNotFound reports whether the node dropped a filter, which nodes do
+// when it is not polled for a while (5 minutes for geth) or after a restart.
+func isFilterNotFound(err error) bool {
+	return err != nil && strings.Contains(err.Error(), "filter not found")
+}
+
+// isMethodNotFound reports whether the node does not serve filters at all.
+func isMethodNotFound(err error) bool {
+	var rpcErr rpc.Error
+	return errors.As(err, &rpcErr) && rpcErr.ErrorCode() == -32601
+}
@@ -0,0 +1,661 @@
+// Package watcher follows the Transfer and Approval events of a list of
+// tokens and delivers them to a sink, as a long-running service: it
+// reconnects after RPC errors, resumes from the last processed block, reports
+// its progress as Prometheus metrics and health checks, and drains on
+// shutdown.
+//
+//	w := &watcher.Watcher{Dial: dial, Tokens: tokenAddresses, Sink: out}
+//	err := w.Run(ctx)
+//
+// Each new head triggers an eth_getLogs from the block after the last one
+// processed, for all tokens at once, so events of all kinds and tokens are
+// delivered in one stream in chain order, none is skipped across reconnects,
+// and the lag to head counts blocks whose events are not yet delivered.
+//
+// Over HTTP, where heads cannot be subscribed to, the watcher polls log
+// filters every PollInterval instead, and catches up with eth_getLogs when
+// the node drops them.
+package watcher
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	token "go-ethereum-example/gen"
+	"go-ethereum-example/pkg/sink"
+	"math/big"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// Client is the part of ethclient.Client the watcher uses. Following heads
+// also needs a HeadSubscriber, and polling is faster with LogFilters.
+type Client interface {
+	ethereum.BlockNumberReader
+	FilterLogs(ctx context.Context, q ethereum.FilterQuery) ([]types.Log, error)
+	HeaderByHash(ctx context.Context, hash common.Hash) (*types.Header, error)
+}
+
+// HeadSubscriber subscribes to new heads, over WebSocket.
+type HeadSubscriber interface {
+	SubscribeNewHead(ctx context.Context, ch chan<- *types.Header) (ethereum.Subscription, error)
+}
+
+// Defaults of the Watcher fields.
+const (
+	DefaultMaxRange     = 1000
+	DefaultMinBackoff   = time.Second
+	DefaultMaxBackoff   = time.Minute
+	DefaultDrainTimeout = 30 * time.Second
+	DefaultPollInterval = 2 * time.Second
+)
+
+// Events are the names of the events a watcher can follow.
+var Events = []string{"Transfer", "Approval"}
+
+// Watcher delivers the events of Tokens to Sink.
+type Watcher struct {
+	// Dial connects to the node, and is called again after every failure. A
+	// client with a Close method is closed when it is dropped. Events are
+	// enriched with token metadata when the client is an erc20.Backend.
+	Dial func(ctx context.Context) (Client, error)
+
+	// Tokens are the contracts to watch. Use SetTokens to change them while
+	// the watcher runs.
+	Tokens []common.Address
+	Sink   sink.Sink
+
+	// Events are the names of the events to deliver, Transfer by default.
+	Events []string
+
+	// From and To filter events by their indexed addresses: the sender and
+	// recipient of transfers, the owner and spender of approvals. Involving
+	// matches either of them, and cannot be combined with From and To. Events
+	// match if their address is any of the list.
+	From      []common.Address
+	To        []common.Address
+	Involving []common.Address
+
+	// FromBlock is the first block to process, 0 for the first block not yet
+	// confirmed at start.
+	FromBlock uint64
+
+	// Confirmations is how many blocks to stay behind head, so that events of
+	// short reorgs are not delivered.
+	Confirmations uint64
+
+	// MaxRange caps the blocks of each eth_getLogs request.
+	MaxRange uint64
+
+	// PollInterval polls the node over HTTP instead of following heads over
+	// WebSocket, when set.
+	PollInterval time.Duration
+
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+
+	// DrainTimeout bounds how long events in flight keep being delivered after
+	// the context of Run is canceled.
+	DrainTimeout time.Duration
+
+	Metrics *Metrics
+
+	// Logf reports connections, retries and dead-lettered events.
+	Logf func(format string, args ...any)
+
+	mu         sync.Mutex
+	status     Status
+	generation uint64 // of Tokens, incremented by SetTokens
+
+	dialed   bool // once, later dials are reconnects
+	next     uint64
+	queries  []ethereum.FilterQuery // without addresses, see current
+	metadata map[common.Address]metadata
+	block    blockTime // of the last event delivered
+}
+
+// blockTime is the time of a block, by hash.
+type blockTime struct {
+	hash common.Hash
+	time uint64
+}
+
+// Status is a snapshot of the watcher's progress.
+type Status struct {
+	Connected bool `json:"connected"`
+	Draining  bool `json:"draining"`
+
+	// Head is the latest block seen, Last the latest block whose events are
+	// delivered.
+	Head uint64 `json:"head"`
+	Last uint64 `json:"lastBlock"`
+
+	// Progress is when Last last advanced or the watcher was found caught up.
+	Progress time.Time `json:"progress"`
+
+	// Started is when Run was called.
+	Started time.Time `json:"started"`
+}
+
+// Lag is the number of blocks behind head.
+func (s Status) Lag() uint64 {
+	if s.Head <= s.Last {
+		return 0
+	}
+
+	return s.Head - s.Last
+}
+
+// errSink marks failures of the sink, which are not retried: the event would
+// be delivered out of order or lost.
+var errSink = errors.New("sink")
+
+// Status returns the current progress.
+func (w *Watcher) Status() Status {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return w.status
+}
+
+// SetTokens replaces the tokens to watch, from the next block the watcher
+// processes on. Earlier events of added tokens are not delivered.
+func (w *Watcher) SetTokens(tokens []common.Address) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.Tokens = slices.Clone(tokens)
+	w.generation++
+}
+
+// Run watches until ctx is canceled, then finishes delivering the events of
+// the range in flight, for up to DrainTimeout, and returns nil. Connection and
+// RPC errors are retried with backoff; sink errors other than dead-lettered
+// events stop the watcher.
+func (w *Watcher) Run(ctx context.Context) error {
+	w.setDefaults()
+
+	queries, err := w.filterQueries()
+	if err != nil {
+		return err
+	}
+
+	if len(w.tokens()) == 0 {
+		return errors.New("no tokens to watch")
+	}
+
+	w.queries = queries
+	w.update(func(s *Status) { s.Started = time.Now() })
+
+	// Deliveries outlive ctx by up to DrainTimeout
+	deliverCtx, cancelDeliver := context.WithCancel(context.WithoutCancel(ctx))
+	defer cancelDeliver()
+
+	stop := context.AfterFunc(ctx, func() {
+		w.update(func(s *Status) { s.Draining = true })
+		time.AfterFunc(w.DrainTimeout, cancelDeliver)
+	})
+	defer stop()
+
+	for attempt := 0; ; attempt++ {
+		err := w.connect(ctx, deliverCtx)
+
+		// Start over from the shortest wait once a connection worked
+		if w.Status().Connected {
+			attempt = 0
+		}
+
+		w.update(func(s *Status) { s.Connected = false })
+
+		if ctx.Err() != nil {
+			if errors.Is(err, errSink) {
+				if deliverCtx.Err() == nil {
+					return err
+				}
+
+				w.Logf("Drain timed out: %v", err)
+			}
+
+			return nil
+		}
+
+		if errors.Is(err, errSink) {
+			return err
+		}
+
+		wait := min(w.MinBackoff<<min(attempt, 16), w.MaxBackoff)
+		w.Logf("Watcher disconnected: %v, reconnecting in %s", err, wait)
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(wait):
+		}
+	}
+}
+
+func (w *Watcher) setDefaults() {
+	if w.MaxRange == 0 {
+		w.MaxRange = DefaultMaxRange
+	}
+
+	if w.MinBackoff == 0 {
+		w.MinBackoff = DefaultMinBackoff
+	}
+
+	if w.MaxBackoff == 0 {
+		w.MaxBackoff = DefaultMaxBackoff
+	}
+
+	if w.DrainTimeout == 0 {
+		w.DrainTimeout = DefaultDrainTimeout
+	}
+
+	if w.Metrics == nil {
+		w.Metrics = NewMetrics(nil)
+	}
+
+	if w.Logf == nil {
+		w.Logf = func(string, ...any) {}
+	}
+
+	if len(w.Events) == 0 {
+		w.Events = []string{"Transfer"}
+	}
+
+	if w.metadata == nil {
+		w.metadata = make(map[common.Address]metadata)
+	}
+}
+
+// filterQueries maps the events and address filters onto topics. Addresses
+// that may be in either topic need one query per topic. The tokens are added
+// by current.
+func (w *Watcher) filterQueries() ([]ethereum.FilterQuery, error) {
+	var ids []common.Hash
+	for _, name := range w.Events {
+		id, ok := eventIDs[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown event %q, want one of %s", name, strings.Join(Events, ", "))
+		}
+
+		ids = append(ids, id)
+	}
+
+	if len(w.Involving) > 0 && (len(w.From) > 0 || len(w.To) > 0) {
+		return nil, errors.New("involving cannot be combined with from and to")
+	}
+
+	query := func(topics ...[]common.Hash) ethereum.FilterQuery {
+		return ethereum.FilterQuery{Topics: append([][]common.Hash{ids}, topics...)}
+	}
+
+	if len(w.Involving) > 0 {
+		involving := addressTopics(w.Involving)
+		return []ethereum.FilterQuery{query(involving), query(nil, involving)}, nil
+	}
+
+	return []ethereum.FilterQuery{query(addressTopics(w.From), addressTopics(w.To))}, nil
+}
+
+func (w *Watcher) tokens() []common.Address {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return w.Tokens
+}
+
+// current returns the queries for the tokens watched now, and the generation
+// of the token list. There are none without tokens, as a query without
+// addresses would match every contract.
+func (w *Watcher) current() ([]ethereum.FilterQuery, uint64) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if len(w.Tokens) == 0 {
+		return nil, w.generation
+	}
+
+	queries := make([]ethereum.FilterQuery, len(w.queries))
+	for i, q := range w.queries {
+		q.Addresses = w.Tokens
+		queries[i] = q
+	}
+
+	return queries, w.generation
+}
+
+// addressTopics returns the topics of indexed addresses, nil to match any.
+func addressTopics(addresses []common.Address) []common.Hash {
+	var topics []common.Hash
+	for _, address := range addresses {
+		topics = append(topics, common.BytesToHash(address.Bytes()))
+	}
+
+	return topics
+}
+
+// connect runs one connection until it fails or ctx is canceled.
+func (w *Watcher) connect(ctx, deliverCtx context.Context) error {
+	if w.dialed {
+		w.Metrics.Reconnects.Inc()
+	}
+
+	w.dialed = true
+
+	client, err := w.Dial(ctx)
+	if err != nil {
+		return w.failed(err)
+	}
+
+	if closer, ok := client.(interface{ Close() }); ok {
+		defer closer.Close()
+	}
+
+	if w.PollInterval > 0 {
+		return w.poll(ctx, deliverCtx, client)
+	}
+
+	return w.subscribe(ctx, deliverCtx, client)
+}
+
+// start sets the first block to process on the first connection, and marks
+// the watcher connected.
+func (w *Watcher) start(head uint64, transport string) {
+	if w.next == 0 {
+		w.next = w.FromBlock
+		if w.next == 0 {
+			w.next = head + 1 - min(w.Confirmations, head)
+		}
+
+		w.update(func(s *Status) { s.Last = w.next - 1 })
+	}
+
+	w.update(func(s *Status) { s.Connected = true })
+	w.Logf("Watching %s events of %s from block %d %s", strings.Join(w.Events, ", "), describeTokens(w.tokens()), w.next, transport)
+}
+
+// subscribe follows new heads over a WebSocket subscription.
+func (w *Watcher) subscribe(ctx, deliverCtx context.Context, client Client) error {
+	subscriber, ok := client.(HeadSubscriber)
+	if !ok {
+		return errors.New("client cannot subscribe to new heads, set a poll interval")
+	}
+
+	heads := make(chan *types.Header, 16)
+
+	sub, err := subscriber.SubscribeNewHead(ctx, heads)
+	if err != nil {
+		return w.failed(err)
+	}
+
+	defer sub.Unsubscribe()
+
+	head, err := client.BlockNumber(ctx)
+	if err != nil {
+		return w.failed(err)
+	}
+
+	w.start(head, "with a head subscription")
+
+	// Catch up on the blocks missed while disconnected, then follow heads
+	for {
+		if err := w.process(ctx, deliverCtx, client, head); err != nil {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case err := <-sub.Err():
+			if err == nil {
+				err = errors.New("head subscription closed")
+			}
+
+			return w.failed(err)
+		case header := <-heads:
+			head = header.Number.Uint64()
+		}
+	}
+}
+
+// process delivers the events of the blocks up to head, less confirmations,
+// in ranges of at most MaxRange blocks. It stops between ranges when ctx is
+// canceled.
+func (w *Watcher) process(ctx, deliverCtx context.Context, client Client, head uint64) error {
+	w.setHead(head)
+
+	if head < w.Confirmations {
+		return nil
+	}
+
+	safe := head - w.Confirmations
+
+	for w.next <= safe {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		to := min(w.next+w.MaxRange-1, safe)
+
+		// Token list changes apply from the next range
+		queries, _ := w.current()
+
+		logs, err := filterLogs(deliverCtx, client, queries, w.next, to)
+		if err != nil {
+			return w.failed(err)
+		}
+
+		for _, log := range logs {
+			if err := w.deliver(deliverCtx, client, log); err != nil {
+				return err
+			}
+		}
+
+		w.next = to + 1
+		w.setLast(to)
+	}
+
+	// Caught up
+	w.update(func(s *Status) { s.Progress = time.Now() })
+
+	return nil
+}
+
+// filterLogs runs the queries over a range of blocks and merges their logs in
+// chain order, without duplicates.
+func filterLogs(ctx context.Context, client Client, queries []ethereum.FilterQuery, from, to uint64) ([]types.Log, error) {
+	if len(queries) == 1 {
+		q := queries[0]
+		q.FromBlock, q.ToBlock = new(big.Int).SetUint64(from), new(big.Int).SetUint64(to)
+
+		return client.FilterLogs(ctx, q)
+	}
+
+	var logs []types.Log
+	for _, q := range queries {
+		q.FromBlock, q.ToBlock = new(big.Int).SetUint64(from), new(big.Int).SetUint64(to)
+
+		found, err := client.FilterLogs(ctx, q)
+		if err != nil {
+			return nil, err
+		}
+
+		logs = append(logs, found...)
+	}
+
+	return sortLogs(logs), nil
+}
+
+// sortLogs sorts logs in chain order and drops duplicates, such as a transfer
+// to oneself matched by both queries of Involving.
+func sortLogs(logs []types.Log) []types.Log {
+	sort.SliceStable(logs, func(i, j int) bool {
+		if logs[i].BlockNumber != logs[j].BlockNumber {
+			return logs[i].BlockNumber < logs[j].BlockNumber
+		}
+
+		return logs[i].Index < logs[j].Index
+	})
+
+	seen := make(map[string]bool)
+
+	var unique []types.Log
+	for _, log := range logs {
+		key := fmt.Sprintf("%s:%t", sink.ID(log), log.Removed)
+		if !seen[key] {
+			seen[key] = true
+			unique = append(unique, log)
+		}
+	}
+
+	return unique
+}
+
+func (w *Watcher) deliver(ctx context.Context, client Client, log types.Log) error {
+	event, err := parse(log)
+	if err != nil {
+		w.Logf("Skipping log %s: %v", sink.ID(log), err)
+		return nil
+	}
+
+	m, err := w.tokenMetadata(ctx, client, log.Address)
+	if err != nil {
+		return err
+	}
+
+	event.Symbol, event.Decimals, event.DecimalsUnknown = m.symbol, m.decimals, m.unknown
+
+	// Removed logs keep no block time, their block may be gone
+	if !log.Removed {
+		if event.BlockTime, err = w.blockTime(ctx, client, log.BlockHash); err != nil {
+			return err
+		}
+	}
+
+	err = w.Sink.Write(ctx, event)
+
+	switch {
+	case errors.Is(err, sink.ErrDeadLettered):
+		w.Metrics.DeadLettered.Inc()
+		w.Logf("Delivery failed: %v", err)
+	case err != nil:
+		return fmt.Errorf("%w: deliver %s: %w", errSink, sink.ID(log), err)
+	default:
+		w.Metrics.Events.WithLabelValues(event.Event).Inc()
+	}
+
+	return nil
+}
+
+// blockTime returns the time of a block, remembering the last one, as events
+// come in chain order.
+func (w *Watcher) blockTime(ctx context.Context, client Client, hash common.Hash) (uint64, error) {
+	if w.block.hash == hash {
+		return w.block.time, nil
+	}
+
+	header, err := client.HeaderByHash(ctx, hash)
+	if err != nil {
+		return 0, w.failed(fmt.Errorf("block %s: %w", hash.Hex(), err))
+	}
+
+	w.block = blockTime{hash: hash, time: header.Time}
+
+	return header.Time, nil
+}
+
+// failed counts a failed RPC request or subscription, unless the watcher
+// canceled it, and returns err.
+func (w *Watcher) failed(err error) error {
+	if !errors.Is(err, context.Canceled) {
+		w.Metrics.RPCErrors.Inc()
+	}
+
+	return err
+}
+
+// describeTokens names a single token by address, and counts several.
+func describeTokens(tokens []common.Address) string {
+	if len(tokens) == 1 {
+		return tokens[0].Hex()
+	}
+
+	return fmt.Sprintf("%d tokens", len(tokens))
+}
+
+func (w *Watcher) setHead(head uint64) {
+	w.update(func(s *Status) {
+		s.Head = max(s.Head, head)
+	})
+}
+
+func (w *Watcher) setLast(last uint64) {
+	w.update(func(s *Status) {
+		s.Last = last
+		s.Progress = time.Now()
+	})
+}
+
+// update changes the status and the gauges that mirror it.
+func (w *Watcher) update(f func(s *Status)) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	f(&w.status)
+
+	w.Metrics.Head.Set(float64(w.status.Head))
+	w.Metrics.LastBlock.Set(float64(w.status.Last))
+	w.Metrics.Lag.Set(float64(w.status.Lag()))
+}
+
+// parse converts a log to the event of its first topic.
+func parse(log types.Log) (sink.Event, error) {
+	switch log.Topics[0] {
+	case eventIDs["Transfer"]:
+		transfer, err := filterer.ParseTransfer(log)
+		if err != nil {
+			return sink.Event{}, err
+		}
+
+		return sink.FromTransfer(transfer), nil
+
+	case eventIDs["Approval"]:
+		approval, err := filterer.ParseApproval(log)
+		if err != nil {
+			return sink.Event{}, err
+		}
+
+		return sink.FromApproval(approval), nil
+	}
+
+	return sink.Event{}, fmt.Errorf("unexpected topic %s", log.Topics[0].Hex())
+}
+
+var (
+	eventIDs = mustEventIDs(Events)
+
+	// filterer parses logs without a backend
+	filterer, _ = token.NewTokenFilterer(common.Address{}, nil)
+)
+
+func mustEventIDs(names []string) map[string]common.Hash {
+	parsed, err := token.TokenMetaData.GetAbi()
+	if err != nil {
+		panic(err)
+	}
+
+	ids := make(map[string]common.Hash)
+	for _, name := range names {
+		ids[name] = parsed.Events[name].ID
+	}
+
+	return ids
+}
@@ -0,0 +1,617 @@
+package watcher_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go-ethereum-example/gen/fixtures"
+	"go-ethereum-example/pkg/erc20"
+	"go-ethereum-example/pkg/sink"
+	"go-ethereum-example/pkg/testchain"
+	"go-ethereum-example/pkg/watcher"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+var supply = new(big.Int).Mul(big.NewInt(1_000_000), big.NewInt(1e18))
+
+// recorder is a sink that passes events to a channel, and fails when err is
+// set.
+type recorder struct {
+	events chan sink.Event
+	err    error
+}
+
+func (r *recorder) Write(ctx context.Context, event sink.Event) error {
+	if r.err != nil {
+		return r.err
+	}
+
+	r.events <- event
+	return nil
+}
+
+func (r *recorder) Close() error {
+	return nil
+}
+
+func (r *recorder) next(t *testing.T) sink.Event {
+	t.Helper()
+
+	select {
+	case event := <-r.events:
+		return event
+	case <-time.After(10 * time.Second):
+		t.Fatal("no event delivered")
+		return sink.Event{}
+	}
+}
+
+// flakyClient fails its first FilterLogs calls.
+type flakyClient struct {
+	*testchain.Client
+	failures *atomic.Int32
+}
+
+func (c flakyClient) FilterLogs(ctx context.Context, q ethereum.FilterQuery) ([]types.Log, error) {
+	if c.failures.Add(-1) >= 0 {
+		return nil, errors.New("connection reset")
+	}
+
+	return c.Client.FilterLogs(ctx, q)
+}
+
+func start(t *testing.T, w *watcher.Watcher) (cancel func() error) {
+	t.Helper()
+
+	ctx, stop := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+
+	go func() { done <- w.Run(ctx) }()
+
+	t.Cleanup(stop)
+
+	return func() error {
+		stop()
+
+		select {
+		case err := <-done:
+			return err
+		case <-time.After(10 * time.Second):
+			t.Fatal("watcher did not stop")
+			return nil
+		}
+	}
+}
+
+func TestWatcherDeliversInOrder(t *testing.T) {
+	chain := testchain.New(t)
+	owner := chain.Accounts[0]
+
+	address, tokenInstance := chain.DeployToken(owner, supply)
+
+	failures := new(atomic.Int32)
+	failures.Store(2)
+
+	out := &recorder{events: make(chan sink.Event, 100)}
+	metrics := watcher.NewMetrics(prometheus.NewRegistry())
+
+	w := &watcher.Watcher{
+		Dial: func(ctx context.Context) (watcher.Client, error) {
+			return flakyClient{Client: chain.Client, failures: failures}, nil
+		},
+		Tokens:     []common.Address{address},
+		Sink:       out,
+		FromBlock:  1,
+		MinBackoff: 10 * time.Millisecond,
+		Metrics:    metrics,
+	}
+
+	stop := start(t, w)
+
+	// The mint of the deployment, despite the failed requests
+	if mint := out.next(t); mint.From != (common.Address{}) || mint.Value != supply.String() {
+		t.Errorf("first event %+v, want the mint", mint)
+	}
+
+	for i, a := range chain.Accounts[1:4] {
+		if _, err := tokenInstance.Transfer(chain.Transactor(owner), a.Address, big.NewInt(int64(i+1))); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var last sink.Event
+	for i, a := range chain.Accounts[1:4] {
+		event := out.next(t)
+		if event.To != a.Address || event.Value != big.NewInt(int64(i+1)).String() {
+			t.Errorf("event %d: %+v", i, event)
+		}
+
+		if event.BlockNumber <= last.BlockNumber {
+			t.Errorf("event %d in block %d after block %d", i, event.BlockNumber, last.BlockNumber)
+		}
+
+		last = event
+	}
+
+	if err := stop(); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := testutil.ToFloat64(metrics.Events.WithLabelValues("Transfer")); got != 4 {
+		t.Errorf("events processed %v, want 4", got)
+	}
+
+	if got := testutil.ToFloat64(metrics.Reconnects); got != 2 {
+		t.Errorf("reconnects %v, want 2", got)
+	}
+
+	if got := testutil.ToFloat64(metrics.RPCErrors); got != 2 {
+		t.Errorf("RPC errors %v, want 2", got)
+	}
+
+	status := w.Status()
+	if status.Last != last.BlockNumber || status.Lag() != 0 {
+		t.Errorf("status %+v after block %d", status, last.BlockNumber)
+	}
+
+	select {
+	case event := <-out.events:
+		t.Errorf("duplicate event %+v", event)
+	default:
+	}
+}
+
+func TestWatcherFilters(t *testing.T) {
+	chain := testchain.New(t)
+	owner, alice, bob := chain.Accounts[0], chain.Accounts[1], chain.Accounts[2]
+
+	address, tokenInstance := chain.DeployToken(owner, supply)
+
+	// Fund alice, so she can transfer and approve
+	if _, err := tokenInstance.Transfer(chain.Transactor(owner), alice.Address, big.NewInt(100)); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name  string
+		setup func(w *watcher.Watcher)
+		want  []string // event:from:to:value
+	}{
+		{
+			name:  "involving",
+			setup: func(w *watcher.Watcher) { w.Involving = []common.Address{alice.Address} },
+			want:  []string{"Transfer:owner:alice:100", "Transfer:alice:bob:1", "Approval:owner:alice:3", "Transfer:alice:alice:4", "Approval:alice:bob:5"},
+		},
+		{
+			name:  "from",
+			setup: func(w *watcher.Watcher) { w.From = []common.Address{owner.Address} },
+			want:  []string{"Transfer:owner:alice:100", "Transfer:owner:bob:2", "Approval:owner:alice:3"},
+		},
+		{
+			name: "from and to",
+			setup: func(w *watcher.Watcher) {
+				w.From, w.To = []common.Address{alice.Address}, []common.Address{bob.Address}
+			},
+			want: []string{"Transfer:alice:bob:1", "Approval:alice:bob:5"},
+		},
+	}
+
+	// All events are mined before the watchers start, and each replays them
+	// from the block after the deployment
+	transact := []func() error{
+		func() error {
+			_, err := tokenInstance.Transfer(chain.Transactor(alice), bob.Address, big.NewInt(1))
+			return err
+		},
+		func() error {
+			_, err := tokenInstance.Transfer(chain.Transactor(owner), bob.Address, big.NewInt(2))
+			return err
+		},
+		func() error {
+			_, err := tokenInstance.Approve(chain.Transactor(owner), alice.Address, big.NewInt(3))
+			return err
+		},
+		func() error {
+			_, err := tokenInstance.Transfer(chain.Transactor(alice), alice.Address, big.NewInt(4))
+			return err
+		},
+		func() error {
+			_, err := tokenInstance.Approve(chain.Transactor(alice), bob.Address, big.NewInt(5))
+			return err
+		},
+	}
+
+	for _, f := range transact {
+		if err := f(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	names := map[common.Address]string{owner.Address: "owner", alice.Address: "alice", bob.Address: "bob"}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := &recorder{events: make(chan sink.Event, 100)}
+
+			w := &watcher.Watcher{
+				Dial:      func(ctx context.Context) (watcher.Client, error) { return chain.Client, nil },
+				Tokens:    []common.Address{address},
+				Sink:      out,
+				Events:    []string{"Transfer", "Approval"},
+				FromBlock: 2,
+			}
+			tt.setup(w)
+
+			stop := start(t, w)
+
+			var got []string
+			for range tt.want {
+				event := out.next(t)
+				got = append(got, fmt.Sprintf("%s:%s:%s:%s", event.Event, names[event.From], names[event.To], event.Value))
+			}
+
+			if err := stop(); err != nil {
+				t.Fatal(err)
+			}
+
+			if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("events %v, want %v", got, tt.want)
+			}
+
+			select {
+			case event := <-out.events:
+				t.Errorf("unexpected event %+v", event)
+			default:
+			}
+		})
+	}
+
+	// Invalid options stop Run before it connects
+	for _, w := range []*watcher.Watcher{
+		{},
+		{Tokens: []common.Address{address}, Events: []string{"Mint"}},
+		{Tokens: []common.Address{address}, Involving: []common.Address{alice.Address}, From: []common.Address{owner.Address}},
+	} {
+		if err := w.Run(context.Background()); err == nil {
+			t.Errorf("Run with tokens %v, events %v, involving %v and from %v: no error", w.Tokens, w.Events, w.Involving, w.From)
+		}
+	}
+}
+
+func TestWatcherTokens(t *testing.T) {
+	chain := testchain.New(t)
+	owner, alice := chain.Accounts[0], chain.Accounts[1]
+
+	mtk, _ := chain.DeployToken(owner, supply)
+
+	usdt, tx, _, err := fixtures.DeployNoReturnToken(chain.Transactor(owner), chain.Client, supply)
+	if err != nil {
+		t.Fatal(err)
+	}
+	chain.Receipt(tx)
+
+	manifest := filepath.Join(t.TempDir(), "tokens.json")
+	writeManifest := func(tokens ...common.Address) {
+		b, err := json.Marshal(watcher.Manifest{Tokens: tokens})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if err := os.WriteFile(manifest, b, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Duplicates are dropped
+	writeManifest(mtk, usdt, mtk)
+
+	m, err := watcher.LoadManifest(manifest)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(m.Tokens) != 2 {
+		t.Fatalf("manifest tokens %v, want 2", m.Tokens)
+	}
+
+	out := &recorder{events: make(chan sink.Event, 100)}
+	logs := new(logger)
+
+	w := &watcher.Watcher{
+		Dial:   func(ctx context.Context) (watcher.Client, error) { return chain.Client, nil },
+		Tokens: m.Tokens,
+		Sink:   out,
+		Logf:   logs.Logf,
+	}
+
+	stop := start(t, w)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go w.WatchManifest(ctx, manifest, 10*time.Millisecond)
+
+	transfer := func(address common.Address, value int64) {
+		t.Helper()
+
+		if _, err := erc20.New(address, chain.Client).Transfer(chain.Transactor(owner), alice.Address, big.NewInt(value)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Both tokens, enriched with their metadata
+	waitFor(t, func() bool { return w.Status().Connected })
+
+	transfer(mtk, 1)
+	transfer(usdt, 2)
+
+	for _, want := range []sink.Event{
+		{Token: mtk, Symbol: "MTK", Decimals: 18, Value: "1"},
+		{Token: usdt, Symbol: "USDT", Decimals: 6, Value: "2"},
+	} {
+		event := out.next(t)
+		if event.Token != want.Token || event.Symbol != want.Symbol || event.Decimals != want.Decimals || event.DecimalsUnknown || event.Value != want.Value {
+			t.Errorf("event %+v, want %+v", event, want)
+		}
+
+		header, err := chain.Client.HeaderByHash(context.Background(), event.BlockHash)
+		if err != nil || event.BlockTime != header.Time {
+			t.Errorf("block time %d: %v", event.BlockTime, err)
+		}
+	}
+
+	// An invalid manifest keeps the tokens, a valid one replaces them
+	if err := os.WriteFile(manifest, []byte(`{"tokens": [`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	waitFor(t, func() bool { return logs.contains("cannot load manifest") })
+
+	writeManifest(usdt)
+	waitFor(t, func() bool { return logs.contains("Manifest changed") })
+
+	transfer(mtk, 3)
+	transfer(usdt, 4)
+
+	if event := out.next(t); event.Token != usdt || event.Value != "4" {
+		t.Errorf("event %+v, want the USDT transfer only", event)
+	}
+
+	if err := stop(); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case event := <-out.events:
+		t.Errorf("unexpected event %+v", event)
+	default:
+	}
+
+	// A manifest needs tokens
+	writeManifest()
+
+	if _, err := watcher.LoadManifest(manifest); err == nil {
+		t.Error("manifest without tokens: no error")
+	}
+}
+
+func TestWatcherStopsOnSinkError(t *testing.T) {
+	chain := testchain.New(t)
+	address, _ := chain.DeployToken(chain.Accounts[0], supply)
+
+	w := &watcher.Watcher{
+		Dial:      func(ctx context.Context) (watcher.Client, error) { return chain.Client, nil },
+		Tokens:    []common.Address{address},
+		Sink:      &recorder{err: errors.New("disk full")},
+		FromBlock: 1,
+	}
+
+	done := make(chan error, 1)
+	go func() { done <- w.Run(context.Background()) }()
+
+	select {
+	case err := <-done:
+		if err == nil {
+			t.Fatal("Run returned nil after a sink error")
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatal("watcher kept running after a sink error")
+	}
+
+	// The failed block is not marked as delivered
+	if last := w.Status().Last; last != 0 {
+		t.Errorf("last block %d, want 0", last)
+	}
+}
+
+func TestWatcherCountsDeadLettered(t *testing.T) {
+	chain := testchain.New(t)
+	address, _ := chain.DeployToken(chain.Accounts[0], supply)
+
+	metrics := watcher.NewMetrics(prometheus.NewRegistry())
+
+	w := &watcher.Watcher{
+		Dial:      func(ctx context.Context) (watcher.Client, error) { return chain.Client, nil },
+		Tokens:    []common.Address{address},
+		Sink:      &recorder{err: fmt.Errorf("%w: webhook down", sink.ErrDeadLettered)},
+		FromBlock: 1,
+		Metrics:   metrics,
+	}
+
+	stop := start(t, w)
+
+	deadline := time.Now().Add(10 * time.Second)
+	for testutil.ToFloat64(metrics.DeadLettered) == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("mint not dead-lettered")
+		}
+
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	if err := stop(); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := testutil.ToFloat64(metrics.Events.WithLabelValues("Transfer")); got != 0 {
+		t.Errorf("events processed %v, want 0", got)
+	}
+}
+
+func TestHealth(t *testing.T) {
+	chain := testchain.New(t)
+	address, _ := chain.DeployToken(chain.Accounts[0], supply)
+
+	out := &recorder{events: make(chan sink.Event, 100)}
+
+	w := &watcher.Watcher{
+		Dial:   func(ctx context.Context) (watcher.Client, error) { return chain.Client, nil },
+		Tokens: []common.Address{address},
+		Sink:   out,
+
+		// Stay far enough behind head to lag
+		Confirmations: 5,
+	}
+
+	health := &watcher.Health{Watcher: w, MaxLag: 3, Grace: time.Minute}
+
+	chain.MineBlocks(10)
+
+	check := func(handler http.HandlerFunc) (int, map[string]any) {
+		rec := httptest.NewRecorder()
+		handler(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+		var body map[string]any
+		if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+			t.Fatal(err)
+		}
+
+		return rec.Code, body
+	}
+
+	if code, _ := check(health.Readyz); code != http.StatusServiceUnavailable {
+		t.Errorf("ready before start: %d", code)
+	}
+
+	stop := start(t, w)
+
+	waitFor(t, func() bool { return w.Status().Connected && w.Status().Lag() > health.MaxLag })
+
+	if code, body := check(health.Readyz); code != http.StatusServiceUnavailable || body["error"] == nil {
+		t.Errorf("ready while lagging: %d %v", code, body)
+	}
+
+	// Lagging by design is still alive
+	if code, _ := check(health.Healthz); code != http.StatusOK {
+		t.Errorf("not alive while lagging: %d", code)
+	}
+
+	health.MaxLag = 5
+	if code, body := check(health.Readyz); code != http.StatusOK || body["lag"] != float64(5) {
+		t.Errorf("not ready within max lag: %d %v", code, body)
+	}
+
+	if err := stop(); err != nil {
+		t.Fatal(err)
+	}
+
+	if code, body := check(health.Readyz); code != http.StatusServiceUnavailable || body["draining"] != true {
+		t.Errorf("ready after stop: %d %v", code, body)
+	}
+}
+
+// TestHealthNeverConnected checks that a watcher that cannot connect is
+// reported dead once Grace has passed since its start.
+func TestHealthNeverConnected(t *testing.T) {
+	w := &watcher.Watcher{
+		Dial: func(ctx context.Context) (watcher.Client, error) {
+			return nil, errors.New("connection refused")
+		},
+		Tokens:     []common.Address{common.HexToAddress("0x5FbDB2315678afecb367f032d93F642f64180aa3")},
+		Sink:       &recorder{events: make(chan sink.Event, 1)},
+		MinBackoff: 10 * time.Millisecond,
+		MaxBackoff: 10 * time.Millisecond,
+	}
+
+	health := &watcher.Health{Watcher: w, MaxLag: 3, Grace: 200 * time.Millisecond}
+
+	healthz := func() int {
+		rec := httptest.NewRecorder()
+		health.Healthz(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+		return rec.Code
+	}
+
+	stop := start(t, w)
+
+	waitFor(t, func() bool { return !w.Status().Started.IsZero() })
+
+	if code := healthz(); code != http.StatusOK {
+		t.Errorf("not alive within grace: %d", code)
+	}
+
+	waitFor(t, func() bool { return healthz() == http.StatusServiceUnavailable })
+
+	if w.Status().Connected || !w.Status().Progress.IsZero() {
+		t.Errorf("status %+v", w.Status())
+	}
+
+	if err := stop(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// logger records the messages of a watcher.
+type logger struct {
+	mu       sync.Mutex
+	messages []string
+}
+
+func (l *logger) Logf(format string, args ...any) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.messages = append(l.messages, fmt.Sprintf(format, args...))
+}
+
+func (l *logger) contains(s string) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for _, message := range l.messages {
+		if strings.Contains(message, s) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+
+	deadline := time.Now().Add(10 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal("condition not met")
+		}
+
+		time.Sleep(10 * time.Millisecond)
+	}
+}